@@ -0,0 +1,264 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/chain/archive"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/urfave/cli"
+)
+
+var (
+	// ArchiveFileFlag sets the chain archive file.
+	ArchiveFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "chain archive file",
+	}
+	// FromHeightFlag sets the height of the first block to export.
+	FromHeightFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "height of the first block to export",
+	}
+	// ToHeightFlag sets the height of the last block to export.
+	ToHeightFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "height of the last block to export (default: chain tip)",
+	}
+	// ChunkSizeFlag sets the number of blocks per archive chunk.
+	ChunkSizeFlag = cli.IntFlag{
+		Name:  "chunk",
+		Usage: "number of blocks per archive chunk",
+		Value: archive.DefaultChunkSize,
+	}
+	// TrustedFlag disables the certificate verification on import.
+	TrustedFlag = cli.BoolFlag{
+		Name:  "trusted",
+		Usage: "skip the block certificate verification, for archives exported by a node you trust only",
+	}
+)
+
+var archiveCommands = []cli.Command{
+	{
+		Name:   "export-chain",
+		Usage:  "exports the blockchain into an archive file",
+		Flags:  []cli.Flag{ArchiveFileFlag, FromHeightFlag, ToHeightFlag, ChunkSizeFlag},
+		Action: exportChainAction,
+	},
+	{
+		Name:   "import-chain",
+		Usage:  "imports the blockchain from an archive file",
+		Flags:  []cli.Flag{ArchiveFileFlag, TrustedFlag},
+		Action: importChainAction,
+	},
+}
+
+// loadCommandConfig loads the node configurations for a subcommand, reading
+// the config file from the global flag only.
+func loadCommandConfig(ctx *cli.Context) error {
+	return cfg.Load("dusk", nil, func() (string, error) {
+		return ctx.GlobalString(ConfigFlag.Name), nil
+	})
+}
+
+func exportChainAction(ctx *cli.Context) error {
+	file := ctx.String(ArchiveFileFlag.Name)
+	if file == "" {
+		return errors.New("missing archive file")
+	}
+
+	if err := loadCommandConfig(ctx); err != nil {
+		return err
+	}
+
+	drvr, db, err := openDatabase(true)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = drvr.Close()
+	}()
+
+	from := ctx.Uint64(FromHeightFlag.Name)
+	to := ctx.Uint64(ToHeightFlag.Name)
+
+	if !ctx.IsSet(ToHeightFlag.Name) {
+		if err = db.View(func(t database.Transaction) error {
+			to, err = t.FetchCurrentHeight()
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	if from > to {
+		return fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	bw := bufio.NewWriter(f)
+
+	w, err := archive.NewWriter(bw, protocol.MagicFromConfig(), ctx.Int(ChunkSizeFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	if err = archive.Export(db, w, from, to); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+
+	log.WithField("from", from).WithField("to", to).
+		WithField("file", file).Info("chain exported")
+	return nil
+}
+
+func importChainAction(ctx *cli.Context) error {
+	file := ctx.String(ArchiveFileFlag.Name)
+	if file == "" {
+		return errors.New("missing archive file")
+	}
+
+	if err := loadCommandConfig(ctx); err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	r, err := archive.NewReader(f)
+	if err != nil {
+		return err
+	}
+
+	if r.Network() != protocol.MagicFromConfig() {
+		return fmt.Errorf("archive network %d does not match %s", uint8(r.Network()), protocol.MagicFromConfig())
+	}
+
+	parentCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gctx, gcancel := context.WithTimeout(parentCtx, time.Duration(cfg.Get().RPC.Rusk.ConnectionTimeout)*time.Millisecond)
+	defer gcancel()
+
+	proxy, ruskConn := setupGRPCClients(gctx)
+
+	defer func() {
+		_ = ruskConn.Close()
+	}()
+
+	drvr, db, err := openDatabase(false)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = drvr.Close()
+	}()
+
+	// Blocks go through the Chain acceptance path, so that the state
+	// transitions are executed as for the blocks received from the network.
+	c, err := LaunchChain(parentCtx, nil, proxy, eventbus.New(), rpcbus.New(), nil, db)
+	if err != nil {
+		return err
+	}
+
+	// The archive is not authenticated, so its certificates are verified
+	// unless the operator vouches for it
+	verify := !ctx.Bool(TrustedFlag.Name)
+	if !verify {
+		log.WithField("file", file).Warn("importing a trusted archive, block certificates are not verified")
+	}
+
+	var imported uint64
+
+	for {
+		blk, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// Blocks we already have must match the local chain
+		var hash []byte
+
+		err = db.View(func(t database.Transaction) error {
+			var e error
+			hash, e = t.FetchBlockHashByHeight(blk.Header.Height)
+			return e
+		})
+
+		if err == nil {
+			if !bytes.Equal(hash, blk.Header.Hash) {
+				return fmt.Errorf("archive conflicts with the local chain at height %d", blk.Header.Height)
+			}
+
+			continue
+		}
+
+		if err = c.ImportBlock(*blk, verify); err != nil {
+			return fmt.Errorf("could not import block at height %d: %w", blk.Header.Height, err)
+		}
+
+		imported++
+	}
+
+	log.WithField("imported", imported).WithField("file", file).Info("chain imported")
+	return nil
+}
+
+// openDatabase opens the chain database with the configured driver.
+func openDatabase(readOnly bool) (database.Driver, database.DB, error) {
+	drvr, err := database.From(cfg.Get().Database.Driver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := drvr.Open(cfg.Get().Database.Dir, protocol.MagicFromConfig(), readOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return drvr, db, nil
+}
@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+//go:build byzantine
+// +build byzantine
+
+package main
+
+import (
+	"time"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/byzantine"
+)
+
+// setupByzantine returns the configured misbehaviour of the node, if any.
+// Only the nodes built with the byzantine tag, for the test harness, can
+// misbehave.
+func setupByzantine() (*byzantine.Faulty, error) {
+	conf := cfg.Get().Consensus.Byzantine
+	if len(conf.Faults) == 0 {
+		return nil, nil
+	}
+
+	faults, err := byzantine.ParseFaults(conf.Faults)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("faults", conf.Faults).Warn("node configured to misbehave in consensus")
+
+	return byzantine.New(byzantine.Behaviour{
+		Faults:         faults,
+		CandidateDelay: time.Duration(conf.CandidateDelay) * time.Millisecond,
+		Flood:          conf.Flood,
+	}), nil
+}
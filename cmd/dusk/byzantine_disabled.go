@@ -0,0 +1,27 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+//go:build !byzantine
+// +build !byzantine
+
+package main
+
+import (
+	"errors"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/byzantine"
+)
+
+// setupByzantine refuses to start a node configured to misbehave, as the
+// misbehaviours are only available to the nodes built with the byzantine tag.
+func setupByzantine() (*byzantine.Faulty, error) {
+	if len(cfg.Get().Consensus.Byzantine.Faults) > 0 {
+		return nil, errors.New("consensus.byzantine is only supported by nodes built with the byzantine tag")
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/urfave/cli"
+)
+
+var (
+	// RepairFlag enables the repair of the derived indexes on db check.
+	RepairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "rebuild the derived indexes from block headers and bodies, and delete the orphan headers",
+	}
+	// DryRunFlag runs the schema migrations without writing anything.
+	DryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "run the migrations without writing anything",
+	}
+	// ReportFileFlag sets the file the db check report is written to.
+	ReportFileFlag = cli.StringFlag{
+		Name:  "report",
+		Usage: "file to write the JSON report to (default: stdout)",
+	}
+)
+
+var dbCommands = []cli.Command{
+	{
+		Name:  "db",
+		Usage: "blockchain database maintenance",
+		Subcommands: []cli.Command{
+			{
+				Name:   "check",
+				Usage:  "checks the integrity of the whole blockchain database",
+				Flags:  []cli.Flag{RepairFlag, ReportFileFlag},
+				Action: dbCheckAction,
+			},
+			{
+				Name:   "migrate",
+				Usage:  "applies the pending schema migrations to the blockchain database",
+				Flags:  []cli.Flag{DryRunFlag},
+				Action: dbMigrateAction,
+			},
+		},
+	},
+}
+
+func dbCheckAction(ctx *cli.Context) error {
+	if err := loadCommandConfig(ctx); err != nil {
+		return err
+	}
+
+	repair := ctx.Bool(RepairFlag.Name)
+
+	drvr, err := database.From(cfg.Get().Database.Driver)
+	if err != nil {
+		return err
+	}
+
+	db, err := drvr.Open(cfg.Get().Database.Dir, protocol.MagicFromConfig(), !repair)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = drvr.Close()
+	}()
+
+	report, err := heavy.Check(db)
+	if err != nil {
+		return err
+	}
+
+	if repair && !report.OK() {
+		if err = heavy.Repair(db); err != nil {
+			return err
+		}
+
+		after, err := heavy.Check(db)
+		if err != nil {
+			return err
+		}
+
+		report.Repaired = true
+		report.Remaining = after.Issues
+	}
+
+	var w io.Writer = os.Stdout
+
+	if file := ctx.String(ReportFileFlag.Name); file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err = enc.Encode(report); err != nil {
+		return err
+	}
+
+	log.WithField("issues", len(report.Issues)).
+		WithField("repaired", report.Repaired).
+		WithField("remaining", len(report.Remaining)).
+		Info("database check completed")
+
+	if (!report.Repaired && !report.OK()) || len(report.Remaining) > 0 {
+		return errors.New("database is inconsistent")
+	}
+
+	return nil
+}
+
+func dbMigrateAction(ctx *cli.Context) error {
+	if err := loadCommandConfig(ctx); err != nil {
+		return err
+	}
+
+	drvr, err := database.From(cfg.Get().Database.Driver)
+	if err != nil {
+		return err
+	}
+
+	if drvr.Name() != heavy.DriverName {
+		return fmt.Errorf("migrations are not supported by %s driver", drvr.Name())
+	}
+
+	defer func() {
+		_ = drvr.Close()
+	}()
+
+	dryRun := ctx.Bool(DryRunFlag.Name)
+
+	from, to, err := heavy.Migrate(cfg.Get().Database.Dir, dryRun)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("from", from).WithField("to", to).
+		WithField("dry_run", dryRun).Info("database migrated")
+	return nil
+}
@@ -42,6 +42,8 @@ func init() {
 			Action:  genesis.Action,
 		},
 	}
+	app.Commands = append(app.Commands, archiveCommands...)
+	app.Commands = append(app.Commands, dbCommands...)
 	app.Flags = append(app.Flags, CLIFlags...)
 	app.Flags = append(app.Flags, GlobalFlags...)
 }
@@ -20,13 +20,19 @@ import (
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/forecast"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector"
 	consensuskey "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakeautomaton"
 	walletdb "github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	// Register the bbolt driver, selected by database.driver
+	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/bbolt"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
@@ -60,6 +66,8 @@ type Server struct {
 	gqlServer  *gql.Server
 
 	ruskConn      *grpc.ClientConn
+	signer        *signer.Remote
+	history       *signer.History
 	readerFactory *peer.ReaderFactory
 	kadPeer       *kadcast.Peer
 
@@ -173,6 +181,17 @@ func Setup() *Server {
 
 	driver, db := heavy.CreateDBConnection()
 
+	if keep := cfg.Get().Database.PruneKeepBlocks; keep > 0 {
+		var pruner *heavy.Pruner
+
+		pruner, err = heavy.NewPruner(db, keep, time.Duration(cfg.Get().Database.PruneInterval)*time.Second)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		go pruner.Run(parentCtx)
+	}
+
 	processor := peer.NewMessageProcessor(eventBus)
 	registerPeerServices(processor, db, eventBus, rpcBus)
 
@@ -183,18 +202,42 @@ func Setup() *Server {
 
 	proxy, ruskConn := setupGRPCClients(gctx)
 
+	remoteSigner, err := setupRemoteSigner(parentCtx)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// With a remote signer, the wallet of the node holds the BLS public key
+	// of the signer only
+	var blsPubKey []byte
+	if remoteSigner != nil {
+		blsPubKey = remoteSigner.PubKey()
+	}
+
 	var w *wallet.Wallet
 
 	if _, err = os.Stat(cfg.Get().Wallet.File); err == nil {
 		w, err = loadWallet(pw)
 	} else {
-		w, err = createWallet(nil, pw, proxy.KeyMaster())
+		w, err = createWallet(nil, pw, proxy.KeyMaster(), blsPubKey)
 	}
 
 	if err != nil {
 		log.Panic(err)
 	}
 
+	var consensusSigner signer.Signer
+
+	if remoteSigner != nil {
+		if err = w.DelegateConsensusKeys(blsPubKey); err != nil {
+			log.Panic(err)
+		}
+
+		consensusSigner = remoteSigner
+	} else {
+		consensusSigner = signer.NewLocal(w.Keys())
+	}
+
 	m := mempool.NewMempool(db, eventBus, rpcBus, proxy.Prober(), grpcServer)
 	m.Run(parentCtx)
 	processor.Register(topics.Tx, m.ProcessTx)
@@ -216,9 +259,42 @@ func Setup() *Server {
 		EventBus:    eventBus,
 		RPCBus:      rpcBus,
 		Keys:        w.Keys(),
+		Signer:      consensusSigner,
 		TimerLength: cfg.ConsensusTimeOut,
+		Detector:    equivocation.NewDetector(consensusSigner, db),
 	}
 
+	if conf := cfg.Get().Consensus; conf.MaxTimeOut > 0 {
+		e.Timeouts = consensus.NewTimeouts(
+			time.Duration(conf.ConsensusTimeOut)*time.Second,
+			time.Duration(conf.MinTimeOut)*time.Millisecond,
+			time.Duration(conf.MaxTimeOut)*time.Millisecond,
+		)
+	}
+
+	// The round records are served by the consensus API
+	if cfg.Get().API.Enabled {
+		e.Telemetry = consensus.NewTelemetry()
+	}
+
+	var history *signer.History
+
+	if path := cfg.Get().Consensus.SigningHistory; path != "" {
+		history, err = signer.OpenHistory(path)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		e.History = history
+	}
+
+	if e.Byzantine, err = setupByzantine(); err != nil {
+		log.Panic(err)
+	}
+
+	equivocation.NewService(db, proxy.Provider(), rpcBus, grpcServer)
+	inspector.NewService(db, grpcServer)
+
 	cl := loop.New(e, &w.PublicKey)
 	processor.Register(topics.Candidate, cl.ProcessCandidate)
 
@@ -228,6 +304,7 @@ func Setup() *Server {
 	}
 
 	processor.Register(topics.Block, c.ProcessBlockFromNetwork)
+	processor.Register(topics.Headers, c.ProcessHeadersFromNetwork)
 
 	// Instantiate GraphQL server
 	var gqlServer *gql.Server
@@ -267,6 +344,8 @@ func Setup() *Server {
 		gqlServer:     gqlServer,
 		grpcServer:    grpcServer,
 		ruskConn:      ruskConn,
+		signer:        remoteSigner,
+		history:       history,
 		readerFactory: readerFactory,
 		dbDriver:      driver,
 		ctx:           parentCtx,
@@ -274,12 +353,22 @@ func Setup() *Server {
 	}
 
 	// Setting up the transactor component
-	_, err = transactor.New(eventBus, rpcBus, nil, grpcServer, proxy, w, c.CalculateSyncProgress)
+	tr, err := transactor.New(eventBus, rpcBus, nil, grpcServer, proxy, w, c.CalculateSyncProgress)
 	if err != nil {
 		log.Panic(err)
 	}
 
-	_ = stakeautomaton.New(eventBus, rpcBus, grpcServer)
+	_ = stakeautomaton.New(eventBus, rpcBus, grpcServer, stakeautomaton.Env{
+		Store:        w,
+		DB:           db,
+		PubKeyBLS:    e.Keys.BLSPubKey,
+		Balance:      tr.Balance,
+		SyncProgress: c.CalculateSyncProgress,
+	})
+
+	// The Provisioner service is extended with the duty forecasts of the
+	// consensus keys
+	forecast.NewService(db, e.Keys.BLSPubKey, grpcServer)
 
 	// Setting up and launch kadcast peer
 	kcfg := cfg.Get().Kadcast
@@ -332,6 +421,14 @@ func (s *Server) Close() {
 	// Close Rusk client connection
 	_ = s.ruskConn.Close()
 
+	if s.signer != nil {
+		_ = s.signer.Close()
+	}
+
+	if s.history != nil {
+		_ = s.history.Close()
+	}
+
 	// kadcast client grpc
 	if s.kadPeer != nil {
 		s.kadPeer.Close()
@@ -389,6 +486,7 @@ func registerPeerServices(processor *peer.MessageProcessor, db database.DB, even
 	processor.Register(topics.Pong, responding.ProcessPong)
 	processor.Register(topics.Inv, dataRequestor.RequestMissingItems)
 	processor.Register(topics.GetBlocks, bhb.AdvertiseMissingBlocks)
+	processor.Register(topics.GetHeaders, bhb.ProvideHeaders)
 	processor.Register(topics.GetCandidate, cb.ProvideCandidate)
 	processor.Register(topics.NewBlock, cp.Process)
 	processor.Register(topics.Reduction, cp.Process)
@@ -410,7 +508,35 @@ func setupGRPCClients(ctx context.Context) (transactions.Proxy, *grpc.ClientConn
 
 	txTimeout := time.Duration(cfg.Get().RPC.Rusk.ContractTimeout) * time.Millisecond
 	defaultTimeout := time.Duration(cfg.Get().RPC.Rusk.DefaultTimeout) * time.Millisecond
-	return transactions.NewProxy(ruskClient, keysClient, transferClient, stakeClient, txTimeout, defaultTimeout), ruskConn
+	return transactions.NewProxy(ruskClient, keysClient, transferClient, stakeClient, txTimeout, defaultTimeout, cfg.BlockGasLimit), ruskConn
+}
+
+// setupRemoteSigner connects to the remote signer, if configured.
+func setupRemoteSigner(ctx context.Context) (*signer.Remote, error) {
+	conf := cfg.Get().Consensus.Signer
+	if conf.Network == "" {
+		return nil, nil
+	}
+
+	timeout := time.Duration(conf.Timeout) * time.Millisecond
+
+	dctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r, err := signer.Dial(dctx, signer.Setup{
+		Network:  conf.Network,
+		Address:  conf.Address,
+		CertFile: conf.CertFile,
+		KeyFile:  conf.KeyFile,
+		CAFile:   conf.CAFile,
+		Timeout:  timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the remote signer: %w", err)
+	}
+
+	log.WithField("address", conf.Address).Info("consensus signatures delegated to the remote signer")
+	return r, nil
 }
 
 func loadWallet(password string) (*wallet.Wallet, error) {
@@ -424,7 +550,9 @@ func loadWallet(password string) (*wallet.Wallet, error) {
 	return wallet.LoadFromFile(testnet, db, password, cfg.Get().Wallet.File)
 }
 
-func createWallet(seed []byte, password string, keyMaster transactions.KeyMaster) (*wallet.Wallet, error) {
+// createWallet creates a wallet with new BLS keys, or with the BLS public key
+// of a remote signer if blsPubKey is set.
+func createWallet(seed []byte, password string, keyMaster transactions.KeyMaster, blsPubKey []byte) (*wallet.Wallet, error) {
 	// First load the database
 	db, err := walletdb.New(cfg.Get().Wallet.Store)
 	if err != nil {
@@ -456,10 +584,14 @@ func createWallet(seed []byte, password string, keyMaster transactions.KeyMaster
 		ViewKey:   vk,
 	}
 
-	consensusKeys := consensuskey.NewRandKeys()
+	if blsPubKey != nil {
+		keysJSON.PublicKeyBLS = blsPubKey
+	} else {
+		consensusKeys := consensuskey.NewRandKeys()
 
-	keysJSON.SecretKeyBLS = consensusKeys.BLSSecretKey
-	keysJSON.PublicKeyBLS = consensusKeys.BLSPubKey
+		keysJSON.SecretKeyBLS = consensusKeys.BLSSecretKey
+		keysJSON.PublicKeyBLS = consensusKeys.BLSPubKey
+	}
 
 	// Then create the wallet with seed and password
 	w, err := wallet.LoadFromSeed(testnet, db, password, cfg.Get().Wallet.File, keysJSON)
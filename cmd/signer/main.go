@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer/signerpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc"
+)
+
+var (
+	walletFile  = flag.String("wallet", "wallet.dat", "wallet file holding the consensus keys")
+	historyFile = flag.String("history", "signer.db", "signing history file")
+	network     = flag.String("network", "unix", "network to listen on, either unix or tcp")
+	address     = flag.String("address", "/tmp/dusk-signer.sock", "unix socket path or tcp address to listen on")
+	certFile    = flag.String("cert", "", "TLS certificate of the signer (tcp only)")
+	keyFile     = flag.String("key", "", "TLS key of the signer (tcp only)")
+	caFile      = flag.String("ca", "", "CA of the node certificate (tcp only)")
+	strip       = flag.String("strip", "", "write a copy of the wallet without the BLS secret key to this file, for the node, and exit")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.WithError(err).Fatal("signer failed")
+	}
+}
+
+func run() error {
+	pw := os.Getenv("DUSK_WALLET_PASS")
+	if pw == "" {
+		p, err := readPassword("Enter password:")
+		if err != nil {
+			return err
+		}
+
+		pw = string(p)
+	}
+
+	if *strip != "" {
+		if err := wallet.StripConsensusSecretKey(pw, *walletFile, *strip); err != nil {
+			return fmt.Errorf("could not strip wallet: %w", err)
+		}
+
+		log.WithField("file", *strip).Info("wallet copied without the BLS secret key")
+		return nil
+	}
+
+	// The wallet database is not needed to read the consensus keys
+	w, err := wallet.LoadFromFile(0, nil, pw, *walletFile)
+	if err != nil {
+		return fmt.Errorf("could not load wallet: %w", err)
+	}
+
+	history, err := signer.OpenHistory(*historyFile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = history.Close()
+	}()
+
+	var opts []grpc.ServerOption
+
+	switch *network {
+	case "unix":
+		// Remove obsolete unix socket file
+		_ = os.Remove(*address)
+	case "tcp":
+		creds, err := signer.ServerCredentials(*certFile, *keyFile, *caFile)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, grpc.Creds(creds))
+	default:
+		return fmt.Errorf("unsupported network %s", *network)
+	}
+
+	l, err := net.Listen(*network, *address)
+	if err != nil {
+		return err
+	}
+
+	if *network == "unix" {
+		// Only the owner of the socket can request signatures
+		if err := os.Chmod(*address, 0o600); err != nil {
+			return err
+		}
+	}
+
+	srv := grpc.NewServer(opts...)
+	signerpb.RegisterSignerServer(srv, signer.NewServer(w.Keys(), history))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-interrupt
+		srv.GracefulStop()
+	}()
+
+	log.WithField("network", *network).
+		WithField("address", *address).
+		Info("signer listening")
+
+	return srv.Serve(l)
+}
+
+// This is to bypass issue with stdin from non-tty.
+func readPassword(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		fmt.Fprintln(os.Stderr, prompt)
+		return terminal.ReadPassword(fd)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if scanner.Scan() {
+		return scanner.Bytes(), nil
+	}
+
+	return nil, scanner.Err()
+}
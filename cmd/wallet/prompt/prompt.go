@@ -66,7 +66,7 @@ func WalletMenu(client *conf.NodeClient) error {
 				return err
 			}
 
-			res = fmt.Sprintf("Unlocked balance: %.8f\nLocked balance: %.8f\n", float64(resp.UnlockedBalance)/float64(wallet.DUSK), float64(resp.LockedBalance)/float64(wallet.DUSK))
+			res = fmt.Sprintf("Confirmed balance: %.8f\nUnconfirmed balance: %.8f\n", float64(resp.UnlockedBalance)/float64(wallet.DUSK), float64(resp.LockedBalance)/float64(wallet.DUSK))
 		case "Show Address":
 			resp, err := client.WalletClient.GetAddress(context.Background(), &node.EmptyRequest{})
 			if err != nil {
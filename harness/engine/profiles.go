@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
@@ -179,6 +181,40 @@ func Profile4(index int, node *DuskNode, walletPath string) {
 	viper.Set("kadcast.grpc.DialTimeout", 10)
 }
 
+// Profile5 builds dusk.toml with the first nodes misbehaving in consensus.
+// The faults are set with DUSK_BYZANTINE_FAULTS (comma-separated, defaults to
+// withhold), and the number of faulty nodes with DUSK_BYZANTINE_NODES
+// (defaults to 1). The nodes must be built with the byzantine tag (see make
+// build-byzantine), as the others refuse to start.
+func Profile5(index int, node *DuskNode, walletPath string) {
+	Profile1(index, node, walletPath)
+
+	faulty := 1
+
+	if n, err := strconv.Atoi(os.Getenv("DUSK_BYZANTINE_NODES")); err == nil {
+		faulty = n
+	}
+
+	if index >= faulty {
+		return
+	}
+
+	faults := "withhold"
+	if f := os.Getenv("DUSK_BYZANTINE_FAULTS"); f != "" {
+		faults = f
+	}
+
+	viper.Set("consensus.byzantine.faults", strings.Split(faults, ","))
+	viper.Set("consensus.byzantine.candidatedelay", 3000)
+	viper.Set("consensus.byzantine.flood", 10)
+}
+
+// Profile6 builds dusk.toml with the mempool journal enabled.
+func Profile6(index int, node *DuskNode, walletPath string) {
+	Profile1(index, node, walletPath)
+	viper.Set("mempool.journalPath", node.Dir+"/mempool.journal")
+}
+
 //nolint
 func getOutboundAddr(port int) string {
 	conn, err := net.Dial("udp", "8.8.8.8:80")
@@ -200,4 +236,6 @@ func initProfiles() {
 	profileList["defaultWithLite"] = Profile2
 	profileList["kadcast"] = Profile3
 	profileList["kadcast_uds"] = Profile4
+	profileList["byzantine"] = Profile5
+	profileList["journal"] = Profile6
 }
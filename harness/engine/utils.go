@@ -436,7 +436,8 @@ func (n *Network) GetWalletAddress(ind uint) (string, []byte, error) {
 }
 
 // GetBalance makes an attempt to get wallet balance of a specified node.
-// Returns both UnlockedBalance and LockedBalance.
+// Returns both the confirmed balance (UnlockedBalance) and the unconfirmed one
+// (LockedBalance).
 func (n *Network) GetBalance(ind uint) (uint64, uint64, error) {
 	c := n.grpcClients[n.nodes[ind].Id]
 
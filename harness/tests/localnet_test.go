@@ -30,7 +30,7 @@ var (
 	localNetSizeStr = os.Getenv("DUSK_NETWORK_SIZE")
 	localNetSize    = 10
 
-	// tomlProfile could be 'default', 'kadcast', kadcast_uds.
+	// tomlProfile could be 'default', 'kadcast', kadcast_uds, byzantine, journal.
 	tomlProfile = os.Getenv("DUSK_NETWORK_PROFILE")
 )
 
@@ -72,6 +72,10 @@ func TestMain(m *testing.M) {
 			tomlProfile = "default"
 			localNet.NetworkType = engine.GossipNetwork
 		}
+	case "byzantine", "journal":
+		{
+			localNet.NetworkType = engine.GossipNetwork
+		}
 	case "kadcast":
 		fallthrough
 	case "kadcast_uds":
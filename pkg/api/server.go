@@ -115,6 +115,8 @@ func (s *Server) InitRouting() *pat.Router {
 
 	r.HandleFunc("/consensus/provisioners", capi.GetProvisionersHandler).Methods("GET")
 	r.HandleFunc("/consensus/roundinfo", capi.GetRoundInfoHandler).Methods("GET")
+	r.HandleFunc("/consensus/rounds", capi.GetRoundRecordsHandler).Methods("GET")
+	r.HandleFunc("/consensus/roundstats", capi.GetRoundStatsHandler).Methods("GET")
 	r.HandleFunc("/consensus/eventqueuestatus", capi.GetEventQueueStatusHandler).Methods("GET")
 	r.HandleFunc("/p2p/logs", capi.GetP2PLogsHandler).Methods("GET")
 	r.HandleFunc("/p2p/count", capi.GetP2PCountHandler).Methods("GET")
@@ -7,6 +7,8 @@
 package config
 
 import (
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
@@ -37,6 +39,17 @@ const (
 
 	// Block Gas limit TBD.
 	BlockGasLimit = 100000000
+
+	// DefaultSignerTimeout is the default timeout (in milliseconds) of the
+	// requests to the remote signer.
+	DefaultSignerTimeout = 1000
+
+	// DefaultMaxSlashTxs is the default cap of the Slash txs in the mempool.
+	DefaultMaxSlashTxs = 128
+
+	// MaxSlashTxsPerBlock is the cap of the Slash txs in a block. A block
+	// carrying more of them is invalid.
+	MaxSlashTxsPerBlock = 16
 )
 
 // KadcastInitHeader is used as default initial kadcast message header.
@@ -51,3 +64,38 @@ func DecodeGenesis() *block.Block {
 
 	return genesis.Generate(cfg)
 }
+
+// Checkpoints returns the trusted checkpoints of the current network, as
+// block hashes by height. The checkpoints of the genesis preset are merged
+// with the ones set in the config file, which take precedence. They are
+// validated when the config is loaded.
+func Checkpoints() (map[uint64][]byte, error) {
+	reg := Get()
+	return reg.checkpoints()
+}
+
+func (r *Registry) checkpoints() (map[uint64][]byte, error) {
+	checkpoints := make(map[uint64][]byte)
+
+	if cfg, err := genesis.GetPresetConfig(r.General.Network); err == nil {
+		preset, err := cfg.Checkpoints()
+		if err != nil {
+			return nil, err
+		}
+
+		for height, hash := range preset {
+			checkpoints[height] = hash
+		}
+	}
+
+	for _, c := range r.Checkpoint {
+		hash, err := hex.DecodeString(c.Hash)
+		if err != nil || len(hash) != 32 {
+			return nil, fmt.Errorf("invalid checkpoint hash at height %d", c.Height)
+		}
+
+		checkpoints[c.Height] = hash
+	}
+
+	return checkpoints, nil
+}
@@ -7,6 +7,7 @@
 package genesis
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -30,6 +31,10 @@ type Config struct {
 	coinbaseValue uint64
 
 	coinbaseAmount uint
+
+	// checkpoints are the trusted hex-encoded block hashes by height of a
+	// long-running network. Nodes reject any chain conflicting with them.
+	checkpoints map[uint64]string
 }
 
 // NewConfig will construct a new genesis config. This function does sanity checks
@@ -57,3 +62,21 @@ func NewConfig(timestamp int64, initialParticipants []keys.PublicKey, committeeM
 
 	return c, nil
 }
+
+// Checkpoints returns the trusted block hashes by height of the network. The
+// genesis block generated from the config is always a checkpoint.
+func (c Config) Checkpoints() (map[uint64][]byte, error) {
+	checkpoints := make(map[uint64][]byte, len(c.checkpoints)+1)
+	checkpoints[0] = Generate(c).Header.Hash
+
+	for height, hash := range c.checkpoints {
+		b, err := hex.DecodeString(hash)
+		if err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid checkpoint hash at height %d", height)
+		}
+
+		checkpoints[height] = b
+	}
+
+	return checkpoints, nil
+}
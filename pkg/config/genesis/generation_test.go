@@ -24,6 +24,16 @@ func TestEquality(t *testing.T) {
 	assert.True(t, b1.Equals(b2))
 }
 
+// The genesis block of a preset is a trusted checkpoint.
+func TestGenesisCheckpoint(t *testing.T) {
+	cfg, err := genesis.GetPresetConfig("devnet")
+	assert.NoError(t, err)
+
+	checkpoints, err := cfg.Checkpoints()
+	assert.NoError(t, err)
+	assert.Equal(t, genesis.Generate(cfg).Header.Hash, checkpoints[0])
+}
+
 /*
 import (
 	"bytes"
@@ -23,6 +23,10 @@ func GetPresetConfig(name string) (Config, error) {
 	return c, nil
 }
 
+// The genesis block of a preset is always a trusted checkpoint. Presets of
+// long-running networks should also list the hashes of later blocks in
+// checkpoints, so that new nodes are protected from long-range forks on
+// bootstrap.
 var configurations = map[string]Config{
 	"devnet": {
 		timestamp:            1600000000,
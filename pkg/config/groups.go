@@ -84,10 +84,27 @@ type seedersConfiguration struct {
 	Fixed     []string
 }
 
+// Trusted checkpoint. See also config.Checkpoints.
+type checkpointConfiguration struct {
+	Height uint64
+	// Hash is the hex-encoded hash of the block at Height.
+	Hash string
+}
+
 // pkg/core/database package configs.
 type databaseConfiguration struct {
 	Driver string
 	Dir    string
+
+	// PruneKeepBlocks enables the pruning mode of heavy driver if non-zero.
+	// Only the bodies of the last PruneKeepBlocks blocks are retained, while
+	// headers and certificates are kept for the entire chain.
+	PruneKeepBlocks uint64
+	// PruneInterval is the time (in seconds) between two pruning runs.
+	PruneInterval int64
+	// Indexes enables the secondary indexes of heavy driver (txs by type
+	// and blocks by timestamp).
+	Indexes bool
 }
 
 // wallet configs.
@@ -177,6 +194,26 @@ type mempoolConfiguration struct {
 	PropagateTimeout string
 	PropagateBurst   uint32
 
+	// TxTTL is how long a tx is kept in the mempool, since it was received.
+	// If empty, txs do not expire.
+	TxTTL string
+
+	// ReplaceByFeeMargin is how much higher, in percent, the fee per byte of
+	// a tx must be to replace the pending txs it conflicts with.
+	ReplaceByFeeMargin uint32
+
+	// MaxSlashTxs caps the amount of Slash txs in the mempool. As they pay
+	// no fee, they are never evicted in favor of other txs.
+	MaxSlashTxs uint32
+	// MaxSlashTxsPerBlock caps the amount of Slash txs included in a
+	// candidate block, ahead of the txs paying a fee. It can not exceed the
+	// MaxSlashTxsPerBlock consensus rule.
+	MaxSlashTxsPerBlock uint32
+
+	// JournalPath is the file where the accepted txs are journaled, to be
+	// reloaded on restart. If empty, txs are not journaled.
+	JournalPath string
+
 	// diskpool config
 	DiskPoolDir string
 
@@ -189,6 +226,94 @@ type consensusConfiguration struct {
 	DefaultAmount   uint64
 	// ConsensusTimeOut is the time out for consensus step timers.
 	ConsensusTimeOut int64
+	// MinTimeOut and MaxTimeOut (in milliseconds) bound the step timers,
+	// which grow with the step number and adapt to the observed rounds. If
+	// MaxTimeOut is zero, ConsensusTimeOut is used as is.
+	MinTimeOut int64
+	MaxTimeOut int64
 	// UseCompressedKeys determines if AggregatePks works with compressed or uncompressed pks.
 	UseCompressedKeys bool
+	// SigningHistory is the file recording the votes signed by the node, so
+	// that no conflicting ones are signed after a restart. If empty, votes
+	// are not recorded.
+	SigningHistory string
+	// Slashing enables the Slash txs reporting the equivocations. Rusk does
+	// not slash the accused provisioners yet, so it must stay disabled until
+	// it does: the evidence is recorded, while the Slash txs are refused by
+	// the mempool and in the blocks. All the nodes of a network must agree
+	// on it.
+	Slashing bool
+
+	Signer signerConfiguration
+
+	// StakeAutomaton sets the policies of the stake automation.
+	StakeAutomaton stakeAutomatonConfiguration
+
+	// Byzantine makes the node misbehave. Only meant for the test harness: a
+	// node not built with the byzantine tag refuses to start if it is set.
+	Byzantine byzantineConfiguration
+}
+
+// Byzantine behaviour configs. See pkg/core/consensus/byzantine.
+type byzantineConfiguration struct {
+	// Faults lists the misbehaviours: withhold, randomvote, latecandidate,
+	// equivocate and floodaggragreement.
+	Faults []string
+	// CandidateDelay is the delay (in milliseconds) of the candidates.
+	CandidateDelay int64
+	// Flood is the number of bogus AggrAgreement sent along each one.
+	Flood int
+}
+
+// Stake automation configs. See pkg/core/consensus/stakeautomaton. Amounts
+// are expressed in whole units of DUSK.
+type stakeAutomatonConfiguration struct {
+	// TargetAmount is the amount kept at stake, topping up when needed. If
+	// zero, DefaultAmount is used.
+	TargetAmount uint64
+	// Reserve is the part of the wallet balance which is never staked.
+	Reserve uint64
+	// StopBelow halts the automation while the balance is below it.
+	StopBelow uint64
+	// RequireSync only stakes once the node is fully synced.
+	RequireSync bool
+	// RenewalOffset is how many blocks before their expiry the stakes are
+	// renewed.
+	RenewalOffset uint64
+}
+
+// Remote signer configs. See pkg/core/consensus/signer.
+type signerConfiguration struct {
+	// Network is either "unix" or "tcp". If empty, the consensus keys of the
+	// wallet are used.
+	Network string
+	Address string
+
+	// TLS certificate and key of the node, and CA of the signer. Required
+	// over tcp.
+	CertFile string
+	KeyFile  string
+	CAFile   string
+
+	// Timeout is the time (in milliseconds) a signing request can take. It
+	// defaults to DefaultSignerTimeout, and must be positive.
+	Timeout int64
+}
+
+// pkg/core/chain synchronizer configs.
+type syncConfiguration struct {
+	// HeadersFirst enables fetching and validating header chains before
+	// downloading the block bodies in parallel from several peers.
+	HeadersFirst bool
+	// WindowSize is the number of blocks requested from a single peer at once.
+	WindowSize uint32
+	// MaxPeers is the maximum number of peers to download blocks from.
+	MaxPeers uint32
+	// WindowTimeout is the number of seconds a peer has to deliver its
+	// window before it is re-assigned to another peer.
+	WindowTimeout int64
+	// TrustCheckpoints skips the certificate verification of the blocks
+	// whose header chain, downloaded by the headers-first sync, hash-links
+	// to a checkpoint.
+	TrustCheckpoints bool
 }
@@ -59,6 +59,7 @@ type Registry struct {
 	Kadcast   kadcastConfiguration
 	Mempool   mempoolConfiguration
 	Consensus consensusConfiguration
+	Sync      syncConfiguration
 
 	RPC rpcConfiguration
 	Gql gqlConfiguration
@@ -67,6 +68,7 @@ type Registry struct {
 	Performance performanceConfiguration
 	Logger      loggerConfiguration
 	Profile     []profileConfiguration
+	Checkpoint  []checkpointConfiguration
 
 	lock *sync.RWMutex
 }
@@ -151,6 +153,7 @@ func (r *Registry) init(secondary interface{}) error {
 	}
 
 	defineENV()
+	defineDefaults()
 
 	// Uncomment on debugging only. This will list all levels of configurations
 	// viper.Debug()
@@ -168,6 +171,20 @@ func (r *Registry) init(secondary interface{}) error {
 		}
 	}
 
+	// A malformed checkpoint would be noticed only once the chain is loaded
+	if _, err := r.checkpoints(); err != nil {
+		return err
+	}
+
+	if r.Consensus.Signer.Network != "" && r.Consensus.Signer.Timeout <= 0 {
+		return fmt.Errorf("invalid consensus.signer.timeout %d, it must be positive", r.Consensus.Signer.Timeout)
+	}
+
+	// A candidate block above the cap would be refused by the network
+	if r.Mempool.MaxSlashTxsPerBlock > MaxSlashTxsPerBlock {
+		return fmt.Errorf("invalid mempool.maxslashtxsperblock %d, it must not exceed %d", r.Mempool.MaxSlashTxsPerBlock, MaxSlashTxsPerBlock)
+	}
+
 	r.UsedConfigFile = viper.ConfigFileUsed()
 	return nil
 }
@@ -233,6 +250,16 @@ func defineENV() {
 	}
 }
 
+// define the defaults of the settings which have no valid zero value.
+func defineDefaults() {
+	// A zero timeout would fail every signing request
+	viper.SetDefault("consensus.signer.timeout", DefaultSignerTimeout)
+
+	// Zero caps would keep the Slash txs out of the mempool and the blocks
+	viper.SetDefault("mempool.maxSlashTxs", DefaultMaxSlashTxs)
+	viper.SetDefault("mempool.maxSlashTxsPerBlock", MaxSlashTxsPerBlock)
+}
+
 // Mock should be used only in test packages. It could be useful when a unit
 // test needs to be rerun with configs different from the default ones.
 func Mock(m *Registry) {
@@ -258,6 +285,14 @@ func init() {
 	r.Consensus.DefaultLockTime = 1000
 	r.Consensus.DefaultAmount = 10
 	r.Consensus.ConsensusTimeOut = 5
+	r.Consensus.StakeAutomaton.RequireSync = true
+	r.Consensus.StakeAutomaton.RenewalOffset = 100
 	r.Timeout.TimeoutBrokerGetCandidate = 2
 	r.Mempool.MaxInvItems = 10000
+	r.Mempool.ReplaceByFeeMargin = 10
+	r.Mempool.MaxSlashTxs = DefaultMaxSlashTxs
+	r.Mempool.MaxSlashTxsPerBlock = MaxSlashTxsPerBlock
+	r.Sync.WindowSize = 50
+	r.Sync.MaxPeers = 8
+	r.Sync.WindowTimeout = 10
 }
@@ -7,6 +7,8 @@
 package config
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -166,6 +168,79 @@ func TestSecondaryRegistry(t *testing.T) {
 	if Get().Profile[1].Name != "cpu" {
 		t.Errorf("Invalid Profile [1]: %s", Get().Profile[1].Name)
 	}
+
+	if len(Get().Checkpoint) != 1 || Get().Checkpoint[0].Height != 10 {
+		t.Errorf("Invalid Checkpoint: %v", Get().Checkpoint)
+	}
+
+	checkpoints, err := Checkpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := checkpoints[10]; !ok {
+		t.Error("Checkpoint at height 10 not found")
+	}
+}
+
+func TestInvalidCheckpoint(t *testing.T) {
+	Reset()
+
+	path := t.TempDir() + "/dusk.toml"
+	conf := "[[checkpoint]]\nheight = 10\nhash = \"not a hash\"\n"
+
+	if err := ioutil.WriteFile(path, []byte(conf), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadFromFile(path); err == nil {
+		t.Error("Invalid checkpoint loaded")
+	}
+}
+
+func TestSignerTimeout(t *testing.T) {
+	dir := t.TempDir()
+	conf := "[consensus.signer]\nnetwork = \"unix\"\n"
+
+	Reset()
+
+	if err := ioutil.WriteFile(dir+"/default.toml", []byte(conf), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LoadFromFile(dir + "/default.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Consensus.Signer.Timeout != DefaultSignerTimeout {
+		t.Errorf("Invalid default signer timeout %d", r.Consensus.Signer.Timeout)
+	}
+
+	Reset()
+
+	if err := ioutil.WriteFile(dir+"/zero.toml", []byte(conf+"timeout = 0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadFromFile(dir + "/zero.toml"); err == nil {
+		t.Error("Zero signer timeout loaded")
+	}
+}
+
+func TestMaxSlashTxsPerBlock(t *testing.T) {
+	dir := t.TempDir()
+	conf := fmt.Sprintf("[mempool]\nmaxSlashTxsPerBlock = %d\n", MaxSlashTxsPerBlock+1)
+
+	Reset()
+
+	if err := ioutil.WriteFile(dir+"/default.toml", []byte(conf), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadFromFile(dir + "/default.toml"); err == nil {
+		t.Error("Slash txs cap above the consensus rule loaded")
+	}
 }
 
 func Reset() {
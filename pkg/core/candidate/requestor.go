@@ -62,7 +62,7 @@ func (r *Requestor) RequestCandidate(ctx context.Context, hash []byte) (block.Bl
 	defer r.setRequesting(false)
 
 	if err := r.publishGetCandidate(hash); err != nil {
-		return block.Block{}, nil
+		return block.Block{}, err
 	}
 
 	for {
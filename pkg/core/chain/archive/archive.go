@@ -0,0 +1,252 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package archive implements a portable file format to export and import the
+// blockchain.
+//
+// An archive starts with a header, followed by a sequence of chunks, and ends
+// with a trailer. All integers are little endian.
+//
+//	header:  magic [4]byte "DCHA" | version uint8 | network uint8
+//	chunk:   count uint32 | length uint32 | payload [length]byte | checksum [32]byte
+//	trailer: count uint32 (always 0) | total uint64
+//
+// The payload of a chunk holds count blocks, serialized in the wire format.
+// The checksum is the sha3-256 hash of the payload.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// Version of the archive format.
+const Version uint8 = 1
+
+// DefaultChunkSize is the suggested number of blocks per chunk.
+const DefaultChunkSize = 100
+
+// maxChunkLength caps the payload length of a chunk on reading.
+const maxChunkLength = 512 * 1024 * 1024
+
+var magic = [4]byte{'D', 'C', 'H', 'A'}
+
+var (
+	// ErrInvalidArchive is returned when the archive header is not recognized.
+	ErrInvalidArchive = errors.New("archive: invalid header")
+	// ErrChecksumMismatch is returned when a chunk is corrupted.
+	ErrChecksumMismatch = errors.New("archive: chunk checksum mismatch")
+	// ErrTruncated is returned when the archive ends before its trailer, or
+	// the trailer does not match the blocks read.
+	ErrTruncated = errors.New("archive: truncated archive")
+)
+
+// Writer writes blocks into an archive. Close must be called to flush the
+// last chunk and write the trailer.
+type Writer struct {
+	w         io.Writer
+	chunkSize int
+
+	chunk bytes.Buffer
+	count uint32
+	total uint64
+}
+
+// NewWriter writes the archive header into w, and returns a Writer ready
+// for use.
+func NewWriter(w io.Writer, network protocol.Magic, chunkSize int) (*Writer, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	header := make([]byte, 0, 6)
+	header = append(header, magic[:]...)
+	header = append(header, Version, uint8(network))
+
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: w, chunkSize: chunkSize}, nil
+}
+
+// Write appends a block to the archive.
+func (a *Writer) Write(blk *block.Block) error {
+	if err := message.MarshalBlock(&a.chunk, blk); err != nil {
+		return err
+	}
+
+	a.count++
+	a.total++
+
+	if int(a.count) >= a.chunkSize {
+		return a.flush()
+	}
+
+	return nil
+}
+
+// Close flushes any pending block and writes the trailer. It does not close
+// the underlying writer.
+func (a *Writer) Close() error {
+	if err := a.flush(); err != nil {
+		return err
+	}
+
+	trailer := make([]byte, 12)
+	binary.LittleEndian.PutUint64(trailer[4:], a.total)
+
+	_, err := a.w.Write(trailer)
+	return err
+}
+
+func (a *Writer) flush() error {
+	if a.count == 0 {
+		return nil
+	}
+
+	checksum, err := hash.Sha3256(a.chunk.Bytes())
+	if err != nil {
+		return err
+	}
+
+	prefix := make([]byte, 8)
+	binary.LittleEndian.PutUint32(prefix[:4], a.count)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(a.chunk.Len()))
+
+	for _, b := range [][]byte{prefix, a.chunk.Bytes(), checksum} {
+		if _, err := a.w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	a.chunk.Reset()
+	a.count = 0
+	return nil
+}
+
+// Reader reads blocks from an archive. Each chunk is verified against its
+// checksum before any of its blocks is returned.
+type Reader struct {
+	r       *bufio.Reader
+	network protocol.Magic
+
+	chunk *bytes.Buffer
+	count uint32
+	total uint64
+}
+
+// NewReader reads and verifies the archive header from r, and returns a
+// Reader ready for use.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, 6)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, ErrInvalidArchive
+	}
+
+	if !bytes.Equal(header[:4], magic[:]) {
+		return nil, ErrInvalidArchive
+	}
+
+	if header[4] != Version {
+		return nil, fmt.Errorf("archive: unsupported version %d", header[4])
+	}
+
+	return &Reader{r: br, network: protocol.Magic(header[5])}, nil
+}
+
+// Network returns the network the archive was exported from.
+func (a *Reader) Network() protocol.Magic {
+	return a.network
+}
+
+// Next returns the next block in the archive. It returns io.EOF once the
+// trailer is reached.
+func (a *Reader) Next() (*block.Block, error) {
+	if a.count == 0 {
+		if err := a.readChunk(); err != nil {
+			return nil, err
+		}
+	}
+
+	blk := block.NewBlock()
+	if err := message.UnmarshalBlock(a.chunk, blk); err != nil {
+		return nil, err
+	}
+
+	a.count--
+	a.total++
+
+	return blk, nil
+}
+
+func (a *Reader) readChunk() error {
+	prefix := make([]byte, 4)
+	if _, err := io.ReadFull(a.r, prefix); err != nil {
+		return ErrTruncated
+	}
+
+	count := binary.LittleEndian.Uint32(prefix)
+	if count == 0 {
+		return a.readTrailer()
+	}
+
+	if _, err := io.ReadFull(a.r, prefix); err != nil {
+		return ErrTruncated
+	}
+
+	length := binary.LittleEndian.Uint32(prefix)
+	if length > maxChunkLength {
+		return fmt.Errorf("archive: chunk too large (%d bytes)", length)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(a.r, payload); err != nil {
+		return ErrTruncated
+	}
+
+	checksum := make([]byte, 32)
+	if _, err := io.ReadFull(a.r, checksum); err != nil {
+		return ErrTruncated
+	}
+
+	expected, err := hash.Sha3256(payload)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(expected, checksum) {
+		return ErrChecksumMismatch
+	}
+
+	a.chunk = bytes.NewBuffer(payload)
+	a.count = count
+	return nil
+}
+
+func (a *Reader) readTrailer() error {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(a.r, b); err != nil {
+		return ErrTruncated
+	}
+
+	if binary.LittleEndian.Uint64(b) != a.total {
+		return ErrTruncated
+	}
+
+	return io.EOF
+}
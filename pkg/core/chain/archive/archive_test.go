@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package archive_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/chain/archive"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	blocks := make([]*block.Block, 5)
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 2)
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blocks[i])
+		}))
+	}
+
+	// Chunks of 2 blocks, so that the last one is not full
+	buf := new(bytes.Buffer)
+	w, err := archive.NewWriter(buf, protocol.DevNet, 2)
+	assert.NoError(err)
+	assert.NoError(archive.Export(db, w, 0, 4))
+	assert.NoError(w.Close())
+
+	r, err := archive.NewReader(buf)
+	assert.NoError(err)
+	assert.Equal(protocol.DevNet, r.Network())
+
+	for i := range blocks {
+		blk, err := r.Next()
+		assert.NoError(err)
+		assert.True(blocks[i].Equals(blk))
+	}
+
+	_, err = r.Next()
+	assert.Equal(io.EOF, err)
+}
+
+func TestCorruptedArchive(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	w, err := archive.NewWriter(buf, protocol.DevNet, 1)
+	assert.NoError(err)
+	assert.NoError(w.Write(helper.RandomBlock(1, 1)))
+	assert.NoError(w.Close())
+
+	b := buf.Bytes()
+
+	// Flip a byte in the payload of the first chunk
+	corrupted := make([]byte, len(b))
+	copy(corrupted, b)
+	corrupted[20] ^= 0xff
+
+	r, err := archive.NewReader(bytes.NewReader(corrupted))
+	assert.NoError(err)
+
+	_, err = r.Next()
+	assert.Equal(archive.ErrChecksumMismatch, err)
+
+	// Drop the trailer
+	r, err = archive.NewReader(bytes.NewReader(b[:len(b)-12]))
+	assert.NoError(err)
+
+	_, err = r.Next()
+	assert.NoError(err)
+
+	_, err = r.Next()
+	assert.Equal(archive.ErrTruncated, err)
+
+	// Not an archive
+	_, err = archive.NewReader(bytes.NewReader([]byte("not an archive")))
+	assert.Equal(archive.ErrInvalidArchive, err)
+}
@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package archive
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+)
+
+// Export streams the blocks in the height range [from, to] from db into the
+// archive, one block at a time.
+func Export(db database.DB, w *Writer, from, to uint64) error {
+	for height := from; height <= to; height++ {
+		var blk *block.Block
+
+		err := db.View(func(t database.Transaction) error {
+			hash, err := t.FetchBlockHashByHeight(height)
+			if err != nil {
+				return err
+			}
+
+			blk, err = t.FetchBlock(hash)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := w.Write(blk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
@@ -34,8 +35,10 @@ import (
 )
 
 var (
-	errInvalidStateHash = errors.New("invalid state hash")
-	log                 = logger.WithFields(logger.Fields{"process": "chain"})
+	errInvalidStateHash   = errors.New("invalid state hash")
+	errInvalidHeaderChain = errors.New("header does not follow its predecessor")
+	errInvalidHeaderHash  = errors.New("invalid header hash")
+	log                   = logger.WithFields(logger.Fields{"process": "chain"})
 )
 
 // ErrBlockAlreadyAccepted block already known by blockchain state.
@@ -69,6 +72,8 @@ type Loader interface {
 	BlockAt(uint64) (block.Block, error)
 	// Append a block on the storage.
 	Append(*block.Block) error
+	// Revert removes all blocks above the given height from the storage.
+	Revert(height uint64) error
 }
 
 // Chain represents the nodes blockchain.
@@ -84,6 +89,17 @@ type Chain struct {
 	// verifier performs verifications on the block.
 	verifier Verifier
 
+	// trusted block hashes by height.
+	checkpoints checkpoints
+	// provenHashes are the hashes by height of the headers proven by a
+	// checkpoint, whose blocks are accepted without verifying the
+	// certificate.
+	provenHashes map[uint64][]byte
+
+	// skipCertificates disables the certificate verification while
+	// importing blocks from a trusted archive.
+	skipCertificates bool
+
 	// current blockchain tip of local state.
 	lock sync.RWMutex
 	tip  *block.Block
@@ -100,6 +116,9 @@ type Chain struct {
 	*synchronizer
 	highestSeen uint64
 
+	// competing branch collected by the fallback.
+	branch branch
+
 	// rusk client.
 	proxy transactions.Proxy
 
@@ -120,6 +139,8 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 		ctx:               ctx,
 		loop:              loop,
 		stopConsensusChan: make(chan struct{}),
+		checkpoints:       loadCheckpoints(),
+		provenHashes:      make(map[uint64][]byte),
 	}
 
 	chain.synchronizer = newSynchronizer(db, chain)
@@ -139,6 +160,12 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 
 	chain.tip = prevBlock
 
+	// The provisioners in effect at the tip are recorded for the committee
+	// inspection
+	if err := chain.storeProvisioners(prevBlock.Header.Height); err != nil {
+		log.WithError(err).Warn("could not store provisioners")
+	}
+
 	if srv != nil {
 		node.RegisterChainServer(srv, chain)
 	}
@@ -167,8 +194,14 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 
 	l.Trace("block received")
 
+	// A block which does not follow the tip, or which follows the competing
+	// branch being collected, may belong to a branch to fall back to
+	competing := blk.Header.Height <= c.tip.Header.Height ||
+		(blk.Header.Height == c.tip.Header.Height+1 && !bytes.Equal(blk.Header.PrevBlockHash, c.tip.Header.Hash)) ||
+		c.branch.extends(blk)
+
 	switch {
-	case blk.Header.Height == c.tip.Header.Height:
+	case competing:
 		{
 			// Check if we already accepted this block
 			if bytes.Equal(blk.Header.Hash, c.tip.Header.Hash) {
@@ -177,15 +210,17 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 			}
 
 			// Try to fallback
-			if err := c.tryFallback(blk); err != nil {
+			if err := c.tryFallback(srcPeerID, blk); err != nil {
+				if err == ErrBlockAlreadyAccepted || err == errFinalizedBlock {
+					l.WithError(err).Debug("failed block processing")
+					return nil, nil
+				}
+
 				l.WithError(err).Error("failed fallback procedure")
 			}
 
 			return nil, nil
 		}
-	case blk.Header.Height < c.tip.Header.Height:
-		l.WithError(ErrBlockAlreadyAccepted).Debug("failed block processing")
-		return nil, nil
 	}
 
 	if blk.Header.Height > c.highestSeen {
@@ -195,6 +230,149 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 	return c.synchronizer.processBlock(srcPeerID, c.tip.Header.Height, blk, kh)
 }
 
+// ProcessHeadersFromNetwork will handle the Headers messages sent in response
+// to a GetHeaders request of the headers-first sync.
+// Satisfies the peer.ProcessorFunc interface.
+func (c *Chain) ProcessHeadersFromNetwork(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
+	msg := m.Payload().(message.Headers)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	log.WithField("headers", len(msg.Headers)).
+		WithField("curr_h", c.tip.Header.Height).
+		Trace("headers received")
+
+	return c.synchronizer.processHeaders(srcPeerID, c.tip.Header.Height, msg.Headers)
+}
+
+// VerifyHeaders checks that the headers build a chain on top of the current
+// tip, that their hashes and certificates are valid and that they do not
+// conflict with any trusted checkpoint. It returns the
+// longest valid prefix of headers, or an error if the first header is invalid.
+// Certificates are checked against the current provisioners set, so the
+// chain is truncated at the first header which cannot be verified with it.
+// The certificates of the headers proven by a checkpoint are not verified.
+func (c *Chain) VerifyHeaders(headers []*block.Header) ([]*block.Header, error) {
+	prev := c.tip.Header
+
+	for i, h := range headers {
+		if err := c.verifyHeader(prev, h); err != nil {
+			if i == 0 {
+				return nil, err
+			}
+
+			log.WithError(err).WithField("height", h.Height).
+				Debug("header chain truncated")
+
+			headers = headers[:i]
+			break
+		}
+
+		prev = h
+	}
+
+	// Only a contiguous chain linking to a checkpoint is trusted
+	proven := c.checkpoints.proven(headers)
+	for _, h := range proven {
+		c.provenHashes[h.Height] = h.Hash
+	}
+
+	prev = c.tip.Header
+
+	for i, h := range headers {
+		if i >= len(proven) {
+			if err := verifiers.CheckBlockCertificate(*c.p, block.Block{Header: h}, prev.Seed); err != nil {
+				if i == 0 {
+					return nil, err
+				}
+
+				log.WithError(err).WithField("height", h.Height).
+					Debug("header chain truncated")
+				return headers[:i], nil
+			}
+		}
+
+		prev = h
+	}
+
+	return headers, nil
+}
+
+// verifyHeader checks that a header follows its predecessor, that its hash is
+// valid and that it does not conflict with any trusted checkpoint.
+func (c *Chain) verifyHeader(prev, h *block.Header) error {
+	if h.Height != prev.Height+1 || !bytes.Equal(h.PrevBlockHash, prev.Hash) {
+		return errInvalidHeaderChain
+	}
+
+	hash, err := h.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash, h.Hash) {
+		return errInvalidHeaderHash
+	}
+
+	return c.checkpoints.check(h.Height, h.Hash)
+}
+
+// isProven returns true if the block header was proven by a checkpoint.
+func (c *Chain) isProven(h *block.Header) bool {
+	hash, ok := c.provenHashes[h.Height]
+	return ok && bytes.Equal(hash, h.Hash)
+}
+
+// RequestBlocks sends a GetData message for the given block hashes to a
+// single peer. It is used by the headers-first sync to download block bodies
+// from several peers in parallel.
+func (c *Chain) RequestBlocks(peerAddr string, hashes [][]byte) error {
+	inv := &message.Inv{}
+	for _, hash := range hashes {
+		inv.AddItem(message.InvTypeBlock, hash)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := inv.Encode(buf); err != nil {
+		return err
+	}
+
+	if err := topics.Prepend(buf, topics.GetData); err != nil {
+		return err
+	}
+
+	topic := topics.GossipPoint
+	if config.Get().Kadcast.Enabled {
+		topic = topics.KadcastPoint
+	}
+
+	m := message.NewWithHeader(topic, *buf, []byte(peerAddr))
+	errList := c.eventBus.Publish(topic, m)
+
+	diagnostics.LogPublishErrors("chain/chain.go, topics.GetData", errList)
+	return nil
+}
+
+// ImportBlock accepts a block read from a chain archive, through the same
+// acceptance path of the blocks received from the network. The certificate
+// verification can be skipped only for archives from a trusted source.
+func (c *Chain) ImportBlock(blk block.Block, verifyCertificate bool) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if blk.Header.Height != c.tip.Header.Height+1 {
+		return fmt.Errorf("unexpected block height %d, chain tip is %d", blk.Header.Height, c.tip.Header.Height)
+	}
+
+	c.skipCertificates = !verifyCertificate
+	defer func() {
+		c.skipCertificates = false
+	}()
+
+	return c.acceptBlock(blk)
+}
+
 // TryNextConsecutiveBlockOutSync is the processing path for accepting a block
 // from the network during out-of-sync state.
 func (c *Chain) TryNextConsecutiveBlockOutSync(blk block.Block, kadcastHeight byte) error {
@@ -251,6 +429,9 @@ func (c *Chain) ProcessSyncTimerExpired(strPeerAddr string) error {
 		log.WithError(err).Warn("sync timer could not restart consensus loop")
 	}
 
+	c.downloader.stop()
+	c.headersPeer = ""
+
 	log.WithField("state", "inSync").Traceln("change sync state")
 
 	c.state = c.inSync
@@ -382,6 +563,11 @@ func (c *Chain) isValidBlock(blk block.Block, l *logrus.Entry) error {
 	// This check should avoid a possible race condition between accepting two blocks
 	// at the same height, as the probability of the committee creating two valid certificates
 	// for the same round is negligible.
+	if c.skipCertificates || c.isProven(blk.Header) {
+		l.Debug("skip certificate verification")
+		return nil
+	}
+
 	l.Debug("verifying block certificate")
 
 	var err error
@@ -430,6 +616,7 @@ func (c *Chain) acceptBlock(blk block.Block) error {
 	}
 
 	c.tip = &blk
+	delete(c.provenHashes, blk.Header.Height)
 
 	// 5. Perform all post-events on accepting a block
 	c.postAcceptBlock(blk, l)
@@ -455,7 +642,13 @@ func (c *Chain) postAcceptBlock(blk block.Block, l *logrus.Entry) {
 		l.WithError(err).Warn("candidate deletion failed")
 	}
 
-	// 3. Update Storm DB
+	// 3. Record the provisioners in effect for the next round
+	if err := c.storeProvisioners(blk.Header.Height); err != nil {
+		// failure here should not be treated as critical
+		l.WithError(err).Warn("provisioners storing failed")
+	}
+
+	// 4. Update Storm DB
 	if config.Get().API.Enabled {
 		go c.storeStakesInStormDB(blk.Header.Height)
 	}
@@ -575,6 +768,36 @@ func (c *Chain) RebuildChain(_ context.Context, e *node.EmptyRequest) (*node.Gen
 	return &node.GenericResponse{Response: "Unimplemented"}, nil
 }
 
+// storeProvisioners records the provisioner set in effect after the block at
+// height, unless it is the same as the previous one.
+func (c *Chain) storeProvisioners(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, c.p); err != nil {
+		return err
+	}
+
+	return c.db.Update(func(t database.Transaction) error {
+		prev, err := t.FetchProvisioners(height)
+
+		switch err {
+		case nil:
+			prevBuf := new(bytes.Buffer)
+			if err := user.MarshalProvisioners(prevBuf, prev); err != nil {
+				return err
+			}
+
+			if bytes.Equal(buf.Bytes(), prevBuf.Bytes()) {
+				return nil
+			}
+		case database.ErrProvisionersNotFound:
+		default:
+			return err
+		}
+
+		return t.StoreProvisioners(height, c.p)
+	})
+}
+
 func (c *Chain) storeStakesInStormDB(blkHeight uint64) {
 	store := capi.GetStormDBInstance()
 	members := make([]*capi.Member, len(c.p.Members))
@@ -9,6 +9,7 @@ package chain
 import (
 	"bytes"
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -216,15 +218,15 @@ func TestSyncProgress(t *testing.T) {
 func TestFallbackProcedure(t *testing.T) {
 	// Set up a chain instance with mocking verifiers
 	_, chain := setupChainTest(t, 1)
+	genesis := *chain.tip
 
-	// Accept block at height 1 with Certificate.Step = 6 (second iteration)
-	blk1 := helper.RandomBlock(1, 3)
+	// Certificates are not the subject of this test
+	chain.skipCertificates = true
 
-	cert1 := block.EmptyCertificate()
-	cert1.Step = 6
-	blk1.Header.Certificate = cert1
+	// Accept block at height 1 with Certificate.Step = 6 (second iteration)
+	blk1 := mockBranch(genesis, 1, 6)[0]
 
-	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk1))
+	chain.ProcessBlockFromNetwork("", message.New(topics.Block, blk1))
 
 	b, err := chain.loader.BlockAt(1)
 	assert.NoError(t, err)
@@ -232,28 +234,234 @@ func TestFallbackProcedure(t *testing.T) {
 	// assert block was accepted successfully
 	assert.True(t, bytes.Equal(b.Header.Hash, blk1.Header.Hash))
 
-	// Enforce fallback procedure at block height 1
-	blk2 := helper.RandomBlock(1, 3)
+	// Enforce fallback procedure at block height 1, with a first iteration
+	// certificate
+	branch := mockBranch(genesis, 2, 3)
+
+	chain.ProcessBlockFromNetwork("", message.New(topics.Block, branch[0]))
 
-	cert2 := block.EmptyCertificate()
-	cert2.Step = 3 // (first iteration certificate)
-	blk2.Header.Certificate = cert2
+	// assert a branch which is not longer than the local chain is not applied
+	assert.True(t, bytes.Equal(chain.tip.Header.Hash, blk1.Header.Hash))
 
-	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk2))
+	chain.ProcessBlockFromNetwork("", message.New(topics.Block, branch[1]))
 
 	b, err = chain.loader.BlockAt(1)
 	assert.NoError(t, err)
 
 	// assert block at height 1 has been rewritten from fallback procedure
-	assert.True(t, bytes.Equal(b.Header.Hash, blk2.Header.Hash))
+	assert.True(t, bytes.Equal(b.Header.Hash, branch[0].Header.Hash))
+	assert.True(t, bytes.Equal(chain.tip.Header.Hash, branch[1].Header.Hash))
+
+	// assert reverted block has been deleted
+	err = chain.db.View(func(t database.Transaction) error {
+		_, err := t.FetchBlockExists(blk1.Header.Hash)
+		return err
+	})
+	assert.Equal(t, database.ErrBlockNotFound, err)
+
+	// Ensure fallback will be canceled if the branch is from higher iteration
+	higher := append([]block.Block{blk1}, mockBranch(blk1, 2, 6)...)
+	for _, blk := range higher {
+		chain.ProcessBlockFromNetwork("", message.New(topics.Block, blk))
+	}
+
+	b, err = chain.loader.BlockAt(1)
+	assert.NoError(t, err)
 
-	// Ensure fallback will be canceled if blk is from higher iteration
-	chain.ProcessBlockFromNetwork("", message.New(topics.Block, *blk1))
+	assert.True(t, bytes.Equal(b.Header.Hash, branch[0].Header.Hash))
+	assert.True(t, bytes.Equal(chain.tip.Header.Hash, branch[1].Header.Hash))
+
+	// Ensure fallback will be canceled if it would revert a finalized block
+	for _, blk := range mockBranch(genesis, 3, 3) {
+		chain.ProcessBlockFromNetwork("", message.New(topics.Block, blk))
+	}
 
 	b, err = chain.loader.BlockAt(1)
 	assert.NoError(t, err)
 
-	assert.True(t, bytes.Equal(b.Header.Hash, blk2.Header.Hash))
+	assert.True(t, bytes.Equal(b.Header.Hash, branch[0].Header.Hash))
+	assert.True(t, bytes.Equal(chain.tip.Header.Hash, branch[1].Header.Hash))
+}
+
+func TestFallbackBranch(t *testing.T) {
+	assert := assert.New(t)
+
+	eb, chain := setupChainTest(t, 1)
+
+	// Certificates are not the subject of this test
+	chain.skipCertificates = true
+
+	getDataChan := make(chan message.Message, 10)
+	eb.Subscribe(topics.GossipPoint, eventbus.NewChanListener(getDataChan))
+
+	// A local chain of tentative blocks, and a longer competing branch
+	// forking after the first block
+	local := mockBranch(*chain.tip, 3, 6)
+	competing := mockBranch(local[0], 3, 6)
+
+	for _, blk := range local {
+		_, err := chain.ProcessBlockFromNetwork("local", message.New(topics.Block, blk))
+		assert.NoError(err)
+	}
+
+	assert.Equal(uint64(3), chain.tip.Header.Height)
+
+	// The branch is walked back from its tip to the common ancestor
+	for i := len(competing) - 1; i > 0; i-- {
+		_, err := chain.ProcessBlockFromNetwork("peer", message.New(topics.Block, competing[i]))
+		assert.NoError(err)
+
+		select {
+		case m := <-getDataChan:
+			assert.Equal([]byte("peer"), m.Header())
+
+			buf := m.Payload().(message.SafeBuffer)
+			_, err := topics.Extract(&buf)
+			assert.NoError(err)
+
+			inv := &message.Inv{}
+			assert.NoError(inv.Decode(&buf.Buffer))
+			assert.Equal(competing[i-1].Header.Hash, inv.InvList[0].Hash)
+		case <-time.After(time.Second):
+			t.Fatal("parent block not requested")
+		}
+
+		// The local chain is kept until the branch links to it
+		assert.Equal(local[2].Header.Hash, chain.tip.Header.Hash)
+	}
+
+	_, err := chain.ProcessBlockFromNetwork("peer", message.New(topics.Block, competing[0]))
+	assert.NoError(err)
+
+	// Two blocks reverted and the competing branch applied on top
+	assert.Equal(competing[2].Header.Hash, chain.tip.Header.Hash)
+
+	for i, blk := range competing {
+		b, err := chain.loader.BlockAt(uint64(i + 2))
+		assert.NoError(err)
+		assert.Equal(blk.Header.Hash, b.Header.Hash)
+	}
+
+	for _, blk := range local[1:] {
+		err := chain.db.View(func(t database.Transaction) error {
+			_, err := t.FetchBlockExists(blk.Header.Hash)
+			return err
+		})
+		assert.Equal(database.ErrBlockNotFound, err)
+	}
+}
+
+func TestFallbackRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	_, chain := setupChainTest(t, 1)
+
+	// Certificates are not the subject of this test
+	chain.skipCertificates = true
+
+	e := &heightExecutor{
+		PermissiveExecutor: transactions.MockExecutor(1),
+		pubKeyBLS:          key.NewRandKeys().BLSPubKey,
+	}
+	chain.proxy = &transactions.MockProxy{E: e}
+
+	local := mockBranch(*chain.tip, 3, 6)
+	competing := mockBranch(local[0], 3, 6)
+
+	// The second block of the competing branch is rejected, after the first
+	// one has been applied
+	var ancestorStake uint64
+
+	chain.verifier = &hookVerifier{check: func(blk block.Block) error {
+		if bytes.Equal(blk.Header.Hash, competing[0].Header.Hash) {
+			ancestorStake, _ = chain.p.GetStake(e.pubKeyBLS)
+		}
+
+		if bytes.Equal(blk.Header.Hash, competing[1].Header.Hash) {
+			return errors.New("invalid block")
+		}
+
+		return nil
+	}}
+
+	for _, blk := range append(local, competing...) {
+		_, err := chain.ProcessBlockFromNetwork("peer", message.New(topics.Block, blk))
+		assert.NoError(err)
+	}
+
+	// The branch is checked against the provisioners of the common ancestor
+	assert.Equal(local[0].Header.Height, ancestorStake)
+
+	// The reverted blocks are replayed on top of the common ancestor
+	assert.Equal([]uint64{1, 2, 3, 2, 2, 3}, e.heights)
+	assert.Equal(local[2].Header.Hash, chain.tip.Header.Hash)
+
+	stake, err := chain.p.GetStake(e.pubKeyBLS)
+	assert.NoError(err)
+	assert.Equal(local[2].Header.Height, stake)
+
+	for _, blk := range local {
+		b, err := chain.loader.BlockAt(blk.Header.Height)
+		assert.NoError(err)
+		assert.Equal(blk.Header.Hash, b.Header.Hash)
+	}
+
+	err = chain.db.View(func(t database.Transaction) error {
+		_, err := t.FetchBlockExists(competing[0].Header.Hash)
+		return err
+	})
+	assert.Equal(database.ErrBlockNotFound, err)
+}
+
+// heightExecutor records the heights of the accepted blocks, and returns a
+// single provisioner staking the height of the last one.
+type heightExecutor struct {
+	*transactions.PermissiveExecutor
+	pubKeyBLS []byte
+	heights   []uint64
+}
+
+// Accept ...
+func (e *heightExecutor) Accept(_ context.Context, _ []transactions.ContractCall, _ []byte, height uint64, _ uint64) (user.Provisioners, []byte, error) {
+	e.heights = append(e.heights, height)
+
+	p := user.NewProvisioners()
+	if err := p.Add(e.pubKeyBLS, height, 0, height+1000); err != nil {
+		return user.Provisioners{}, nil, err
+	}
+
+	return *p, make([]byte, 32), nil
+}
+
+// hookVerifier passes the sanity check of a block to check.
+type hookVerifier struct {
+	MockVerifier
+	check func(blk block.Block) error
+}
+
+// SanityCheckBlock ...
+func (v *hookVerifier) SanityCheckBlock(_ block.Block, blk block.Block) error {
+	return v.check(blk)
+}
+
+// mockBranch returns n consecutive blocks on top of prev, agreed at the given
+// step.
+func mockBranch(prev block.Block, n int, step uint8) []block.Block {
+	blocks := make([]block.Block, n)
+
+	for i := range blocks {
+		blk := helper.RandomBlock(prev.Header.Height+1, 1)
+		blk.Header.PrevBlockHash = prev.Header.Hash
+
+		cert := block.EmptyCertificate()
+		cert.Step = step
+		blk.Header.Certificate = cert
+
+		blocks[i] = *blk
+		prev = *blk
+	}
+
+	return blocks
 }
 
 // mock a block which can be accepted by the chain.
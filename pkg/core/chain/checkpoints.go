@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+)
+
+var errCheckpointMismatch = errors.New("block conflicts with a trusted checkpoint")
+
+// checkpoints is a set of trusted block hashes by height. Any chain which
+// conflicts with a checkpoint is rejected, which protects new nodes from
+// long-range forks served by a malicious sync peer.
+type checkpoints map[uint64][]byte
+
+// loadCheckpoints loads the checkpoints of the config. They are validated
+// when the config is loaded, so an error here comes from a mocked config.
+func loadCheckpoints() checkpoints {
+	c, err := config.Checkpoints()
+	if err != nil {
+		log.WithError(err).Error("could not load the checkpoints")
+		return checkpoints{}
+	}
+
+	return checkpoints(c)
+}
+
+// check returns errCheckpointMismatch if there is a checkpoint at the given
+// height and its hash differs from the given one.
+func (c checkpoints) check(height uint64, hash []byte) error {
+	expected, ok := c[height]
+	if ok && !bytes.Equal(expected, hash) {
+		return errCheckpointMismatch
+	}
+
+	return nil
+}
+
+// proven returns the prefix of a header chain whose hashes are proven by a
+// checkpoint, if enabled. The headers must be a contiguous chain with valid
+// hashes: as each header commits to the hash of its predecessor, all headers
+// up to the highest checkpoint they match are authentic, and their
+// certificates need not be verified.
+func (c checkpoints) proven(headers []*block.Header) []*block.Header {
+	if !config.Get().Sync.TrustCheckpoints {
+		return nil
+	}
+
+	for i := len(headers) - 1; i >= 0; i-- {
+		if expected, ok := c[headers[i].Height]; ok && bytes.Equal(expected, headers[i].Hash) {
+			return headers[:i+1]
+		}
+	}
+
+	return nil
+}
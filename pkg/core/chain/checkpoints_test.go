@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCheckpoints(t *testing.T) {
+	assert := assert.New(t)
+
+	blk := helper.RandomBlock(5, 1)
+	forged := helper.RandomBlock(5, 1)
+
+	c := checkpoints{5: blk.Header.Hash, 10: forged.Header.Hash}
+
+	assert.NoError(c.check(5, blk.Header.Hash))
+	assert.Equal(errCheckpointMismatch, c.check(5, forged.Header.Hash))
+
+	// No checkpoint at this height
+	assert.NoError(c.check(6, forged.Header.Hash))
+
+}
+
+func TestVerifyHeadersProven(t *testing.T) {
+	assert := assert.New(t)
+
+	r := config.Get()
+	r.Sync.TrustCheckpoints = true
+	config.Mock(&r)
+
+	defer func() {
+		r.Sync.TrustCheckpoints = false
+		config.Mock(&r)
+	}()
+
+	_, c := setupChainTest(t, 1)
+
+	headers := mockHeaders(t, c.tip.Header, 5)
+
+	// Without a checkpoint, the certificates are verified from height 2
+	valid, err := c.VerifyHeaders(headers)
+	assert.NoError(err)
+	assert.Len(valid, 1)
+	assert.Empty(c.provenHashes)
+
+	// The headers linking to a checkpoint are proven, the ones above it
+	// are not
+	c.checkpoints = checkpoints{4: headers[3].Hash}
+
+	valid, err = c.VerifyHeaders(headers)
+	assert.NoError(err)
+	assert.Len(valid, 4)
+	assert.Len(c.provenHashes, 4)
+
+	for _, h := range headers[:4] {
+		assert.True(c.isProven(h))
+	}
+
+	assert.False(c.isProven(headers[4]))
+
+	// A forged header chain below a checkpoint it does not link to is not
+	// trusted
+	c.provenHashes = make(map[uint64][]byte)
+	forged := mockHeaders(t, c.tip.Header, 3)
+
+	valid, err = c.VerifyHeaders(forged)
+	assert.NoError(err)
+	assert.Len(valid, 1)
+	assert.Empty(c.provenHashes)
+	assert.False(c.isProven(forged[1]))
+}
+
+// mockHeaders returns a chain of n headers with valid hashes on top of prev.
+func mockHeaders(t *testing.T, prev *block.Header, n int) []*block.Header {
+	headers := make([]*block.Header, n)
+
+	for i := range headers {
+		h := helper.RandomBlock(prev.Height+1, 1).Header
+		h.PrevBlockHash = prev.Hash
+
+		hash, err := h.CalculateHash()
+		assert.NoError(t, err)
+
+		h.Hash = hash
+		headers[i] = h
+		prev = h
+	}
+
+	return headers
+}
+
+func TestCheckpointConflictingBlock(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+
+	blk := helper.RandomBlock(10, 1)
+	s.checkpoints = checkpoints{10: helper.RandomBlock(10, 1).Header.Hash}
+
+	// A future block conflicting with a checkpoint does not trigger syncing
+	resp, err := s.processBlock("", 0, *blk, 0)
+	assert.Equal(errCheckpointMismatch, err)
+	assert.Nil(resp)
+	assert.Empty(s.sequencer.blockPool)
+}
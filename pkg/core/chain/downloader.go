@@ -0,0 +1,321 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+)
+
+const (
+	defaultWindowSize    = 50
+	defaultMaxSyncPeers  = 8
+	defaultWindowTimeout = 10 * time.Second
+)
+
+var errUnexpectedBlock = errors.New("block does not match the validated header")
+
+// window is a range of consecutive blocks assigned to a single peer.
+type window struct {
+	peer     string
+	deadline time.Time
+
+	// hashes of the blocks not delivered yet, by height.
+	missing map[uint64][]byte
+}
+
+// hashes returns the hashes of the missing blocks in ascending height order.
+func (w *window) hashes() [][]byte {
+	hashes := make([][]byte, 0, len(w.missing))
+	from, to := w.bounds()
+
+	for height := from; height <= to; height++ {
+		if hash, ok := w.missing[height]; ok {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+// bounds returns the lowest and highest height missing in the window.
+func (w *window) bounds() (uint64, uint64) {
+	var from, to uint64
+
+	first := true
+
+	for height := range w.missing {
+		if first || height < from {
+			from = height
+		}
+
+		if first || height > to {
+			to = height
+		}
+
+		first = false
+	}
+
+	return from, to
+}
+
+// downloader schedules the download of the block bodies, whose headers have
+// already been validated, from several peers in parallel. Each peer works on
+// a single window of consecutive blocks at a time. A window which is not
+// delivered before its deadline is re-assigned to another peer.
+type downloader struct {
+	lock sync.Mutex
+
+	windowSize uint64
+	maxPeers   int
+	timeout    time.Duration
+
+	// request sends a GetData for the given block hashes to a single peer.
+	request func(peerAddr string, hashes [][]byte) error
+
+	// expected block hashes by height, as advertised by the headers.
+	expected map[uint64][]byte
+	// windows is the set of windows not fully delivered, ordered by height.
+	windows []*window
+	// peers we can download blocks from.
+	peers []string
+
+	quit chan struct{}
+}
+
+func newDownloader(windowSize uint64, maxPeers int, timeout time.Duration, request func(string, [][]byte) error) *downloader {
+	if windowSize == 0 {
+		windowSize = defaultWindowSize
+	}
+
+	if maxPeers <= 0 {
+		maxPeers = defaultMaxSyncPeers
+	}
+
+	if timeout <= 0 {
+		timeout = defaultWindowTimeout
+	}
+
+	return &downloader{
+		windowSize: windowSize,
+		maxPeers:   maxPeers,
+		timeout:    timeout,
+		request:    request,
+		expected:   make(map[uint64][]byte),
+	}
+}
+
+// addPeer adds a peer to the set of peers we download blocks from, and
+// assigns it an idle window, if any.
+func (d *downloader) addPeer(peerAddr string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, p := range d.peers {
+		if p == peerAddr {
+			return
+		}
+	}
+
+	if len(d.peers) >= d.maxPeers {
+		return
+	}
+
+	d.peers = append(d.peers, peerAddr)
+	d.assign(time.Now())
+}
+
+// schedule splits the validated headers into windows and distributes them
+// among the known peers. Any previously scheduled download is discarded.
+func (d *downloader) schedule(headers []*block.Header) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.reset()
+
+	var w *window
+
+	for _, h := range headers {
+		if w == nil || uint64(len(w.missing)) >= d.windowSize {
+			w = &window{missing: make(map[uint64][]byte)}
+			d.windows = append(d.windows, w)
+		}
+
+		w.missing[h.Height] = h.Hash
+		d.expected[h.Height] = h.Hash
+	}
+
+	d.quit = make(chan struct{})
+	go d.run(d.quit, d.timeout/2)
+
+	d.assign(time.Now())
+}
+
+// deliver checks that a block matches the validated header at its height,
+// and marks it as downloaded. Blocks which are not part of the scheduled
+// download are ignored.
+func (d *downloader) deliver(blk block.Block) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	hash, ok := d.expected[blk.Header.Height]
+	if !ok {
+		return nil
+	}
+
+	if !bytes.Equal(hash, blk.Header.Hash) {
+		return errUnexpectedBlock
+	}
+
+	for i, w := range d.windows {
+		if _, ok := w.missing[blk.Header.Height]; !ok {
+			continue
+		}
+
+		delete(w.missing, blk.Header.Height)
+
+		if len(w.missing) == 0 {
+			d.windows = append(d.windows[:i], d.windows[i+1:]...)
+			d.assign(time.Now())
+		}
+
+		// All windows delivered, no need to watch for deadlines anymore
+		if len(d.windows) == 0 && d.quit != nil {
+			close(d.quit)
+			d.quit = nil
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// cleanup forgets about the blocks up to the given height, as they have
+// already been accepted.
+func (d *downloader) cleanup(currentHeight uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for height := range d.expected {
+		if height <= currentHeight {
+			delete(d.expected, height)
+		}
+	}
+}
+
+// stop terminates the scheduled download.
+func (d *downloader) stop() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.reset()
+	d.peers = nil
+}
+
+// expire re-assigns the windows whose deadline has passed. The peer which
+// failed to deliver is dropped, unless it is the only one we know about.
+func (d *downloader) expire(now time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, w := range d.windows {
+		if w.peer == "" || now.Before(w.deadline) {
+			continue
+		}
+
+		slog.WithField("r_addr", w.peer).
+			WithField("missing", len(w.missing)).
+			Warn("sync window expired")
+
+		d.removePeer(w.peer)
+		w.peer = ""
+	}
+
+	d.assign(now)
+}
+
+// assign hands over the idle windows to the peers without any work.
+func (d *downloader) assign(now time.Time) {
+	busy := make(map[string]struct{})
+
+	for _, w := range d.windows {
+		if w.peer != "" {
+			busy[w.peer] = struct{}{}
+		}
+	}
+
+	for _, w := range d.windows {
+		if w.peer != "" {
+			continue
+		}
+
+		peer, ok := d.idlePeer(busy)
+		if !ok {
+			return
+		}
+
+		busy[peer] = struct{}{}
+		w.peer = peer
+		w.deadline = now.Add(d.timeout)
+
+		if err := d.request(peer, w.hashes()); err != nil {
+			slog.WithError(err).WithField("r_addr", peer).Warn("could not request blocks")
+		}
+	}
+}
+
+func (d *downloader) idlePeer(busy map[string]struct{}) (string, bool) {
+	for _, p := range d.peers {
+		if _, ok := busy[p]; !ok {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+func (d *downloader) removePeer(peerAddr string) {
+	if len(d.peers) <= 1 {
+		return
+	}
+
+	for i, p := range d.peers {
+		if p == peerAddr {
+			d.peers = append(d.peers[:i], d.peers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (d *downloader) reset() {
+	if d.quit != nil {
+		close(d.quit)
+		d.quit = nil
+	}
+
+	d.windows = nil
+	d.expected = make(map[uint64][]byte)
+}
+
+func (d *downloader) run(quit chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case now := <-ticker.C:
+			d.expire(now)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestDownloaderWindows(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newRequestRecorder()
+	d := newDownloader(2, 2, time.Minute, r.request)
+
+	defer d.stop()
+
+	d.addPeer("peer_a")
+	d.addPeer("peer_b")
+
+	blks := randomBlocks(1, 5)
+	d.schedule(headersOf(blks))
+
+	// Each peer is assigned a single window
+	assert.Equal([][]byte{blks[0].Header.Hash, blks[1].Header.Hash}, r.last("peer_a"))
+	assert.Equal([][]byte{blks[2].Header.Hash, blks[3].Header.Hash}, r.last("peer_b"))
+
+	// Once peer_a delivers its window, it is assigned the next one
+	assert.NoError(d.deliver(*blks[0]))
+	assert.NoError(d.deliver(*blks[1]))
+	assert.Equal([][]byte{blks[4].Header.Hash}, r.last("peer_a"))
+
+	// A block not matching the validated header is rejected
+	forged := helper.RandomBlock(4, 1)
+	assert.Equal(errUnexpectedBlock, d.deliver(*forged))
+
+	// Blocks outside of the scheduled download are ignored
+	assert.NoError(d.deliver(*helper.RandomBlock(10, 1)))
+}
+
+func TestDownloaderReassignOnTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newRequestRecorder()
+	d := newDownloader(2, 2, time.Minute, r.request)
+
+	defer d.stop()
+
+	d.addPeer("peer_a")
+
+	blks := randomBlocks(1, 4)
+	d.schedule(headersOf(blks))
+
+	// peer_b joins, and takes the second window
+	d.addPeer("peer_b")
+	assert.Equal([][]byte{blks[2].Header.Hash, blks[3].Header.Hash}, r.last("peer_b"))
+
+	// peer_b delivers, while peer_a provides only one block of its window
+	assert.NoError(d.deliver(*blks[2]))
+	assert.NoError(d.deliver(*blks[3]))
+	assert.NoError(d.deliver(*blks[0]))
+
+	// On timeout, the missing block is requested from peer_b
+	d.expire(time.Now().Add(2 * time.Minute))
+	assert.Equal([][]byte{blks[1].Header.Hash}, r.last("peer_b"))
+
+	// peer_a is dropped
+	assert.Equal([]string{"peer_b"}, d.peers)
+}
+
+type requestRecorder struct {
+	lock     sync.Mutex
+	requests map[string][][][]byte
+}
+
+func newRequestRecorder() *requestRecorder {
+	return &requestRecorder{requests: make(map[string][][][]byte)}
+}
+
+func (r *requestRecorder) request(peerAddr string, hashes [][]byte) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.requests[peerAddr] = append(r.requests[peerAddr], hashes)
+	return nil
+}
+
+func (r *requestRecorder) last(peerAddr string) [][]byte {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reqs := r.requests[peerAddr]
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	return reqs[len(reqs)-1]
+}
+
+func randomBlocks(from uint64, amount int) []*block.Block {
+	blks := make([]*block.Block, amount)
+	for i := range blks {
+		blks[i] = helper.RandomBlock(from+uint64(i), 1)
+	}
+
+	return blks
+}
+
+func headersOf(blks []*block.Block) []*block.Header {
+	headers := make([]*block.Header, len(blks))
+	for i, blk := range blks {
+		headers[i] = blk.Header
+	}
+
+	return headers
+}
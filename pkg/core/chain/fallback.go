@@ -7,69 +7,340 @@
 package chain
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 )
 
-func (c *Chain) tryFallback(blk block.Block) error {
-	l := log.WithField("curr_h", c.tip.Header.Height).WithField("event", "fallback")
+// maxBranchLength is the amount of blocks of a competing branch collected
+// while looking for the common ancestor.
+const maxBranchLength = 100
+
+var (
+	errEmptyBranch         = errors.New("empty branch")
+	errNonContiguousBranch = errors.New("branch blocks are not consecutive")
+	errFinalizedBlock      = errors.New("cannot revert a finalized block")
+	errBranchTooLong       = errors.New("no common ancestor within the branch length limit")
+	errShortBranch         = errors.New("branch is not longer than the local chain")
+)
+
+// branch is a competing branch being collected from a peer, lowest block
+// first. It grows downwards, by asking the peer for the parent of its first
+// block, until it links to the local chain, and upwards, with the blocks the
+// peer keeps propagating on top of it.
+type branch struct {
+	peer   string
+	blocks []block.Block
+}
+
+// link adds a block to the branch, if the block is the parent or the child of
+// the branch, and is propagated by the same peer. Otherwise, a new branch is
+// started from the block.
+func (b *branch) link(peer string, blk block.Block) []block.Block {
+	switch {
+	case b.peer == peer && len(b.blocks) > 0 &&
+		bytes.Equal(b.blocks[0].Header.PrevBlockHash, blk.Header.Hash) &&
+		b.blocks[0].Header.Height == blk.Header.Height+1:
+		b.blocks = append([]block.Block{blk}, b.blocks...)
+	case b.extends(blk):
+		b.blocks = append(b.blocks, blk)
+	default:
+		b.peer = peer
+		b.blocks = []block.Block{blk}
+	}
+
+	return b.blocks
+}
+
+// extends returns true if the block is the child of the last block of the
+// branch.
+func (b *branch) extends(blk block.Block) bool {
+	if len(b.blocks) == 0 {
+		return false
+	}
+
+	last := b.blocks[len(b.blocks)-1].Header
+	return blk.Header.Height == last.Height+1 && bytes.Equal(blk.Header.PrevBlockHash, last.Hash)
+}
+
+func (b *branch) reset() {
+	b.peer = ""
+	b.blocks = nil
+}
+
+// tryFallback makes an attempt to replace the local chain with a competing
+// branch, from a block received from the network which does not follow the
+// local tip. The rest of the branch is requested from the peer, block by
+// block, down to the common ancestor. Once linked, the branch is applied as
+// soon as it outgrows the local chain.
+func (c *Chain) tryFallback(srcPeerID string, blk block.Block) error {
+	if blk.Header.Height <= c.tip.Header.Height {
+		local, err := c.blockAt(blk.Header.Height)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(local.Header.Hash, blk.Header.Hash) {
+			return ErrBlockAlreadyAccepted
+		}
+	}
+
+	blocks := c.branch.link(srcPeerID, blk)
+	first := blocks[0].Header
+
+	if first.Height == 0 {
+		c.branch.reset()
+		return fmt.Errorf("invalid fork height %d", first.Height)
+	}
+
+	ancestor, err := c.blockAt(first.Height - 1)
+	if err == nil && bytes.Equal(ancestor.Header.Hash, first.PrevBlockHash) {
+		if blocks[len(blocks)-1].Header.Height <= c.tip.Header.Height {
+			log.WithField("fork_h", first.Height-1).
+				WithField("branch_len", len(blocks)).
+				WithField("r_addr", srcPeerID).
+				WithField("event", "fallback").
+				Debug("wait for a longer branch")
+
+			// Keep collecting the blocks propagated on top of the branch
+			return nil
+		}
+
+		c.branch.reset()
+		return c.reorganize(blocks)
+	}
+
+	if len(blocks) >= maxBranchLength {
+		c.branch.reset()
+		return errBranchTooLong
+	}
+
+	log.WithField("fork_h", first.Height-1).
+		WithField("branch_len", len(blocks)).
+		WithField("r_addr", srcPeerID).
+		WithField("event", "fallback").
+		Debug("request parent block")
+
+	// The fork is deeper, walk the branch back to the common ancestor
+	return c.RequestBlocks(srcPeerID, [][]byte{first.PrevBlockHash})
+}
+
+// reorganize implements the fork-choice path of the Chain. It rewinds the local
+// chain down to the common ancestor of the competing branch, re-applies the
+// branch on top of it and rolls the mempool back across every reverted block.
+//
+// The branch must be a sequence of consecutive blocks whose first block is at
+// or below the chain tip, and whose last block is above it. The branch wins if
+// its first block has been agreed at a lower (or the same) iteration than the
+// local block at the same height. Finalized blocks are never reverted.
+//
+// The branch is verified against the provisioners in effect after the common
+// ancestor. If any block of the branch is rejected, the local chain is
+// restored.
+func (c *Chain) reorganize(branch []block.Block) error {
+	if len(branch) == 0 {
+		return errEmptyBranch
+	}
+
+	forkHeight := branch[0].Header.Height
+	if forkHeight == 0 || forkHeight > c.tip.Header.Height {
+		return fmt.Errorf("invalid fork height %d", forkHeight)
+	}
+
+	for i := 1; i < len(branch); i++ {
+		if branch[i].Header.Height != branch[i-1].Header.Height+1 ||
+			!bytes.Equal(branch[i].Header.PrevBlockHash, branch[i-1].Header.Hash) {
+			return errNonContiguousBranch
+		}
+	}
+
+	if branch[len(branch)-1].Header.Height <= c.tip.Header.Height {
+		return errShortBranch
+	}
+
+	l := log.WithField("curr_h", c.tip.Header.Height).
+		WithField("fork_h", forkHeight).
+		WithField("branch_len", len(branch)).
+		WithField("event", "fallback")
+
+	localBlk, err := c.blockAt(forkHeight)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(localBlk.Header.Hash, branch[0].Header.Hash) {
+		return ErrBlockAlreadyAccepted
+	}
+
 	l.Info("initialize procedure")
 
 	// Prioritize the lowest iteration
-	if blk.Header.Certificate.Step > c.tip.Header.Certificate.Step {
+	if branch[0].Header.Certificate.Step > localBlk.Header.Certificate.Step {
 		l.Info("discarded")
 		return nil
 	}
 
-	// Fetch Previous block
-	prevBlk, err := c.loader.BlockAt(c.tip.Header.Height - 1)
+	// Collect the local blocks to be reverted, tip first
+	reverted := make([]block.Block, 0, c.tip.Header.Height-forkHeight+1)
+
+	for h := c.tip.Header.Height; h >= forkHeight; h-- {
+		blk, err := c.blockAt(h)
+		if err != nil {
+			return err
+		}
+
+		if isFinalized(blk) {
+			l.WithField("final_h", h).Info("discarded")
+			return errFinalizedBlock
+		}
+
+		reverted = append(reverted, blk)
+	}
+
+	ancestor, err := c.loader.BlockAt(forkHeight - 1)
 	if err != nil {
 		return err
 	}
 
-	oldTip := c.tip.Copy().(block.Block)
-	c.tip = &prevBlk
+	ancestorP, err := c.provisionersAt(ancestor.Header.Height)
+	if err != nil {
+		return err
+	}
 
-	// Perform verify and accept block procedure
-	if err := c.acceptBlock(blk); err != nil {
-		c.tip = &oldTip
+	// Rewind the local chain to the common ancestor
+	if err := c.loader.Revert(ancestor.Header.Height); err != nil {
 		return err
 	}
 
+	c.tip = &ancestor
+	c.p = ancestorP
+
+	// Perform verify and accept block procedure on each block of the branch
+	for i, blk := range branch {
+		if err := c.acceptBlock(blk); err != nil {
+			l.WithError(err).WithField("height", blk.Header.Height).Error("branch rejected")
+			c.restoreChain(ancestor, ancestorP, reverted, branch[:i])
+			return err
+		}
+	}
+
 	// Perform a mempool rollback
-	c.rollbackMempool(&oldTip, &blk)
+	c.rollbackMempool(reverted, branch)
 
 	if err := c.RestartConsensus(); err != nil {
 		l.WithError(err).Warn("failed to restart consensus loop")
 	}
 
-	l.Info("completed")
+	l.WithField("reverted", len(reverted)).Info("completed")
 
 	return nil
 }
 
-func (c *Chain) rollbackMempool(oldBlk, newBlk *block.Block) {
-	// Find diff txs between consensus-split block and new block
-	for _, tx := range oldBlk.Txs {
-		if tx.Type() == transactions.Distribute {
-			// Distribute tx should not be resubmitted
-			continue
+// restoreChain removes the applied part of a rejected branch, and replays the
+// reverted blocks (tip first) on top of the common ancestor, so that the state
+// and the provisioners move back to the local chain along with the blocks.
+// The txs of the applied blocks are then resubmitted to the mempool.
+func (c *Chain) restoreChain(ancestor block.Block, p *user.Provisioners, reverted, applied []block.Block) {
+	if err := c.loader.Revert(ancestor.Header.Height); err != nil {
+		log.WithError(err).Error("could not remove rejected branch")
+		return
+	}
+
+	c.tip = &ancestor
+	c.p = p
+
+	for i := len(reverted) - 1; i >= 0; i-- {
+		blk := reverted[i]
+		l := log.WithField("height", blk.Header.Height).WithField("event", "fallback")
+
+		if err := c.runStateTransition(*c.tip, blk); err != nil {
+			l.WithError(err).Error("could not restore block")
+			return
 		}
 
-		h, err := tx.CalculateHash()
-		if err != nil {
-			break
+		if err := c.loader.Append(&blk); err != nil {
+			l.WithError(err).Error("could not restore block")
+			return
 		}
 
-		if _, err := newBlk.Tx(h); err != nil {
-			// transaction has not been accepted by new block then it should be resubmitted to mempool.
+		c.tip = &blk
+		c.postAcceptBlock(blk, l)
+	}
+
+	c.rollbackMempool(applied, reverted)
+}
+
+// provisionersAt returns the provisioner set in effect after the block at the
+// given height.
+func (c *Chain) provisionersAt(height uint64) (*user.Provisioners, error) {
+	var p *user.Provisioners
+
+	err := c.db.View(func(t database.Transaction) error {
+		var err error
+		p, err = t.FetchProvisioners(height)
+		return err
+	})
+
+	return p, err
+}
+
+// blockAt returns the block of the local chain at the given height.
+func (c *Chain) blockAt(height uint64) (block.Block, error) {
+	if height == c.tip.Header.Height {
+		return c.tip.Copy().(block.Block), nil
+	}
+
+	return c.loader.BlockAt(height)
+}
+
+// isFinalized returns true if the block was agreed at the first iteration of
+// its round. The state transition of such a block cannot be reverted.
+func isFinalized(blk block.Block) bool {
+	return blk.Header.Certificate.Step == 3
+}
+
+// rollbackMempool resubmits to the mempool all txs of the reverted blocks that
+// are not included in the new branch.
+func (c *Chain) rollbackMempool(reverted, branch []block.Block) {
+	// Resubmit from the oldest reverted block onwards
+	for i := len(reverted) - 1; i >= 0; i-- {
+		for _, tx := range reverted[i].Txs {
+			if tx.Type() == transactions.Distribute {
+				// Distribute tx should not be resubmitted
+				continue
+			}
+
+			h, err := tx.CalculateHash()
+			if err != nil {
+				break
+			}
+
+			if branchHasTx(branch, h) {
+				continue
+			}
+
+			// transaction has not been accepted by new branch then it should be resubmitted to mempool.
 			if _, err := c.rpcBus.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 5*time.Second); err != nil {
 				log.WithError(err).Warn("could not resubmit txs")
 			}
 		}
 	}
 }
+
+func branchHasTx(branch []block.Block, txID []byte) bool {
+	for i := range branch {
+		if _, err := branch[i].Tx(txID); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
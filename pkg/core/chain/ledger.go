@@ -26,4 +26,10 @@ type Ledger interface {
 	StopConsensus()
 
 	ProcessSyncTimerExpired(strPeerAddr string) error
+
+	// VerifyHeaders returns the longest valid prefix of a header chain
+	// following the chain tip.
+	VerifyHeaders(headers []*block.Header) ([]*block.Header, error)
+	// RequestBlocks asks a single peer for the blocks with the given hashes.
+	RequestBlocks(peerAddr string, hashes [][]byte) error
 }
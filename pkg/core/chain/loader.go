@@ -11,6 +11,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
@@ -28,11 +30,16 @@ type DBLoader struct {
 
 	// Unsure if the genesis block needs to be here.
 	genesis *block.Block
+
+	// trusted block hashes by height.
+	checkpoints checkpoints
 }
 
 // SanityCheckBlock will verify whether we have not seed the block before
-// (duplicate), perform a check on the block header and verifies the coinbase
-// transactions. It leaves the bulk of transaction verification to the executor
+// (duplicate), that it does not conflict with a trusted checkpoint, perform a
+// check on the block header and verifies the coinbase and the Slash
+// transactions. It leaves
+// the bulk of transaction verification to the executor
 // Return nil if the sanity check passes.
 func (l *DBLoader) SanityCheckBlock(prevBlock block.Block, blk block.Block) error {
 	// 1. Check that we have not seen this block before
@@ -49,6 +56,10 @@ func (l *DBLoader) SanityCheckBlock(prevBlock block.Block, blk block.Block) erro
 		return err
 	}
 
+	if err := l.checkpoints.check(blk.Header.Height, blk.Header.Hash); err != nil {
+		return err
+	}
+
 	if err := verifiers.CheckBlockHeader(prevBlock, blk); err != nil {
 		return err
 	}
@@ -57,12 +68,44 @@ func (l *DBLoader) SanityCheckBlock(prevBlock block.Block, blk block.Block) erro
 		return err
 	}
 
+	if err := l.checkSlashTxs(blk); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkSlashTxs verifies the Slash txs of a block against the chain. Rusk
+// does not slash the accused provisioners yet, so that a block carrying any is
+// refused unless slashing is enabled.
+func (l *DBLoader) checkSlashTxs(blk block.Block) error {
+	evidence, err := verifiers.CheckSlashTxs(blk.Txs)
+	if err != nil {
+		return err
+	}
+
+	if len(evidence) == 0 {
+		return nil
+	}
+
+	if !config.Get().Consensus.Slashing {
+		return errors.New("slash transactions are not enabled")
+	}
+
+	i := inspector.New(l.db)
+
+	for _, e := range evidence {
+		if err := i.CheckEvidence(*e, blk.Header.Height); err != nil {
+			return fmt.Errorf("invalid slash transaction: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // NewDBLoader returns a Loader which gets the Chain Tip from the DB.
 func NewDBLoader(db database.DB, genesis *block.Block) *DBLoader {
-	return &DBLoader{db: db, genesis: genesis}
+	return &DBLoader{db: db, genesis: genesis, checkpoints: loadCheckpoints()}
 }
 
 // Height returns the height of the blockchain stored in the DB.
@@ -85,6 +128,14 @@ func (l *DBLoader) Append(blk *block.Block) error {
 	})
 }
 
+// Revert deletes all blocks above the given height in a single atomic
+// transaction. On success, the stored chain tip is the block at height.
+func (l *DBLoader) Revert(height uint64) error {
+	return l.db.Update(func(t database.Transaction) error {
+		return t.RevertToHeight(height)
+	})
+}
+
 // BlockAt returns the block stored at a given height.
 func (l *DBLoader) BlockAt(searchingHeight uint64) (block.Block, error) {
 	var blk *block.Block
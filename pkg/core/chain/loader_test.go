@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCheckSlashTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	// A single provisioner, drawn in every committee
+	_, db := lite.CreateDBConnection()
+	p, keys := consensus.MockProvisioners(1)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for height := uint64(0); height < 3; height++ {
+			if err := t.StoreBlock(helper.RandomBlock(height, 1)); err != nil {
+				return err
+			}
+		}
+
+		return t.StoreProvisioners(0, p)
+	}))
+
+	l := NewDBLoader(db, nil)
+
+	slashBlock := func(height uint64, evidence ...*slashing.Evidence) *block.Block {
+		blk := helper.RandomBlock(height, 1)
+
+		slashTxs := make([]transactions.ContractCall, len(evidence))
+		for i, e := range evidence {
+			slashTxs[i] = slashing.MockSlashTx(e)
+		}
+
+		blk.Txs = append(slashTxs, blk.Txs...)
+		return blk
+	}
+
+	e := slashing.MockEvidenceOf(keys[0], 2, 2)
+
+	// Slash txs are refused unless slashing is enabled
+	assert.Error(l.checkSlashTxs(*slashBlock(3, e)))
+
+	r := config.Get()
+	r.Consensus.Slashing = true
+	config.Mock(&r)
+
+	defer func() {
+		r.Consensus.Slashing = false
+		config.Mock(&r)
+	}()
+
+	assert.NoError(l.checkSlashTxs(*slashBlock(3, e)))
+
+	// The accused must be drawn in the committee of the step
+	assert.Error(l.checkSlashTxs(*slashBlock(3, slashing.MockEvidence(2, 2))))
+
+	// A block reports an equivocation once
+	assert.Error(l.checkSlashTxs(*slashBlock(3, e, slashing.MockEvidenceOf(keys[0], 2, 2))))
+
+	// A block carries a capped amount of Slash txs
+	capped := make([]*slashing.Evidence, config.MaxSlashTxsPerBlock+1)
+	for i := range capped {
+		capped[i] = slashing.MockEvidenceOf(keys[0], 2, uint8(i+1))
+	}
+
+	assert.Error(l.checkSlashTxs(*slashBlock(3, capped...)))
+
+	// An equivocation reported by a previous block is not reported again
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(slashBlock(3, e))
+	}))
+
+	assert.Error(l.checkSlashTxs(*slashBlock(4, slashing.MockEvidenceOf(keys[0], 2, 2))))
+	assert.NoError(l.checkSlashTxs(*slashBlock(4, slashing.MockEvidenceOf(keys[0], 2, 3))))
+}
@@ -65,6 +65,15 @@ func (m *MockLoader) Append(blk *block.Block) error {
 	return nil
 }
 
+// Revert drops all blocks above height from the internal blockchain representation.
+func (m *MockLoader) Revert(height uint64) error {
+	if height+1 < uint64(len(m.blockchain)) {
+		m.blockchain = m.blockchain[:height+1]
+	}
+
+	return nil
+}
+
 // BlockAt the block to the internal blockchain representation.
 func (m *MockLoader) BlockAt(index uint64) (block.Block, error) {
 	return m.blockchain[index], nil
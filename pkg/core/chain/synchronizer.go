@@ -63,6 +63,22 @@ func (s *synchronizer) inSync(srcPeerAddr string, currentHeight uint64, blk bloc
 func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk block.Block, kadcastHeight byte) ([]bytes.Buffer, error) {
 	var err error
 
+	if s.headersFirst {
+		// Any block body must match the header validated at its height
+		if err = s.downloader.deliver(blk); err != nil {
+			slog.WithField("r_addr", srcPeerAddr).
+				WithField("blk_height", blk.Header.Height).
+				WithError(err).Warn("peer provided unexpected block")
+			return nil, err
+		}
+
+		// A peer propagating blocks beyond the sync target is ahead of
+		// us, so we can download from it as well
+		if blk.Header.Height > s.hrange.to {
+			s.downloader.addPeer(srcPeerAddr)
+		}
+	}
+
 	// Once we validate successfully the next block from the syncing
 	// Peer we can consider terminating Consensus for efficiency
 	// purposes.
@@ -110,8 +126,14 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 		}
 
 		// Peer does provide a valid consecutive block
-		// outSyncTimer should restart its counter
-		if err = s.timer.Reset(srcPeerAddr); err != nil {
+		// outSyncTimer should restart its counter. In headers-first mode
+		// blocks are provided by several peers on behalf of the syncing one.
+		owner := srcPeerAddr
+		if s.headersFirst {
+			owner = s.timer.ownerID
+		}
+
+		if err = s.timer.Reset(owner); err != nil {
 			slog.WithError(err).WithField("state", "outsync").
 				Warn("timer error")
 		}
@@ -119,6 +141,7 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 		if blk.Header.Height == s.hrange.to {
 			// Sync Target reached. outSyncTimer is not anymore needed
 			s.timer.Cancel()
+			s.downloader.stop()
 
 			// if we reach the target we get into sync mode
 			// and trigger the consensus again
@@ -152,18 +175,33 @@ type synchronizer struct {
 	}
 
 	timer *outSyncTimer
+
+	// headers-first sync mode.
+	headersFirst bool
+	downloader   *downloader
+	// headersPeer is the peer we are awaiting a Headers message from.
+	headersPeer string
+
+	// trusted block hashes by height.
+	checkpoints checkpoints
 }
 
 // newSynchronizer returns an initialized synchronizer, ready for use.
 func newSynchronizer(db database.DB, chain Ledger) *synchronizer {
 	s := &synchronizer{
-		db:        db,
-		sequencer: newSequencer(),
-		chain:     chain,
+		db:          db,
+		sequencer:   newSequencer(),
+		chain:       chain,
+		checkpoints: loadCheckpoints(),
 	}
 
 	s.timer = newSyncTimer(syncTimeout, chain.ProcessSyncTimerExpired)
 
+	cfg := config.Get().Sync
+	s.headersFirst = cfg.HeadersFirst
+	s.downloader = newDownloader(uint64(cfg.WindowSize), int(cfg.MaxPeers),
+		time.Duration(cfg.WindowTimeout)*time.Second, chain.RequestBlocks)
+
 	slog.WithField("state", "insync").Debug(changeStatelabel)
 
 	s.state = s.inSync
@@ -175,6 +213,16 @@ func (s *synchronizer) processBlock(srcPeerID string, currentHeight uint64, blk
 	// Clean up sequencer
 	s.sequencer.cleanup(currentHeight)
 	s.sequencer.dump()
+	s.downloader.cleanup(currentHeight)
+
+	// Reject any chain conflicting with a trusted checkpoint, before the
+	// block is queued or leads us into syncing
+	if err = s.checkpoints.check(blk.Header.Height, blk.Header.Hash); err != nil {
+		slog.WithField("r_addr", srcPeerID).
+			WithField("blk_height", blk.Header.Height).
+			WithError(err).Warn("block rejected")
+		return nil, err
+	}
 
 	currState := s.state
 	res, err = currState(srcPeerID, currentHeight, blk, kadcastHeight)
@@ -202,9 +250,64 @@ func (s *synchronizer) startSync(strPeerAddr string, tipHeight, currentHeight ui
 	}
 
 	msgGetBlocks := createGetBlocksMsg(hash)
+
+	if s.headersFirst {
+		// Fetch and validate the header chain first. The syncing peer is
+		// the first one we download block bodies from.
+		s.headersPeer = strPeerAddr
+		s.downloader.addPeer(strPeerAddr)
+
+		return marshalGetHeaders(msgGetBlocks)
+	}
+
 	return marshalGetBlocks(msgGetBlocks)
 }
 
+// processHeaders handles a Headers message sent in response to our
+// GetHeaders. The longest valid prefix of the header chain becomes the sync
+// target, and the download of the block bodies is scheduled.
+func (s *synchronizer) processHeaders(srcPeerAddr string, currentHeight uint64, headers []*block.Header) ([]bytes.Buffer, error) {
+	// Discard any Headers message we did not ask for
+	if !s.headersFirst || srcPeerAddr != s.headersPeer {
+		return nil, nil
+	}
+
+	s.headersPeer = ""
+
+	if len(headers) == 0 || headers[0].Height != currentHeight+1 {
+		return nil, nil
+	}
+
+	valid, err := s.chain.VerifyHeaders(headers)
+	if err != nil {
+		// Syncing Peer has provided an invalid header chain
+		slog.WithField("r_addr", srcPeerAddr).WithError(err).
+			Warn("syncing peer provided invalid headers")
+		slog.WithField("state", "insync").Debug(changeStatelabel)
+
+		s.timer.Cancel()
+		s.downloader.stop()
+
+		s.state = s.inSync
+		return nil, err
+	}
+
+	s.hrange.to = valid[len(valid)-1].Height
+
+	slog.WithField("from", valid[0].Height).
+		WithField("to", s.hrange.to).
+		WithField("r_addr", srcPeerAddr).
+		Info("headers validated")
+
+	s.downloader.schedule(valid)
+
+	if err = s.timer.Reset(srcPeerAddr); err != nil {
+		slog.WithError(err).Warn("timer error")
+	}
+
+	return nil, nil
+}
+
 func (s *synchronizer) setSyncTarget(tipHeight, maxHeight uint64) {
 	s.hrange.to = tipHeight
 	if tipHeight > maxHeight {
@@ -218,6 +321,16 @@ func createGetBlocksMsg(latestHash []byte) *message.GetBlocks {
 	return msg
 }
 
+//nolint:unparam
+func marshalGetHeaders(msg *message.GetBlocks) ([]bytes.Buffer, error) {
+	buf := topics.GetHeaders.ToBuffer()
+	if err := msg.Encode(&buf); err != nil {
+		return nil, err
+	}
+
+	return []bytes.Buffer{buf}, nil
+}
+
 //nolint:unparam
 func marshalGetBlocks(msg *message.GetBlocks) ([]bytes.Buffer, error) {
 	buf := topics.GetBlocks.ToBuffer()
@@ -50,6 +50,38 @@ func TestFutureBlocks(t *testing.T) {
 	assert.NotEmpty(s.sequencer.blockPool[height])
 }
 
+func TestFutureBlocksHeadersFirst(t *testing.T) {
+	assert := assert.New(t)
+	s, _ := setupSynchronizerTest()
+	s.headersFirst = true
+
+	defer s.downloader.stop()
+
+	blk := helper.RandomBlock(10, 1)
+	resp, err := s.processBlock("peer_a", 0, *blk, 0)
+	assert.NoError(err)
+
+	// Response should be of the GetHeaders topic
+	assert.Equal(resp[0].Bytes()[0], uint8(topics.GetHeaders))
+
+	// Headers from a peer we did not ask are discarded
+	blks := randomBlocks(1, 3)
+	_, err = s.processHeaders("peer_b", 0, headersOf(blks))
+	assert.NoError(err)
+	assert.Empty(s.downloader.windows)
+
+	// Headers from the syncing peer set the target and schedule the download
+	_, err = s.processHeaders("peer_a", 0, headersOf(blks))
+	assert.NoError(err)
+	assert.Equal(uint64(3), s.hrange.to)
+	assert.Len(s.downloader.windows, 1)
+	assert.Equal("peer_a", s.downloader.windows[0].peer)
+
+	// A block not matching its header is rejected
+	_, err = s.processBlock("peer_b", 0, *helper.RandomBlock(2, 1), 0)
+	assert.Equal(errUnexpectedBlock, err)
+}
+
 func setupSynchronizerTest() (*synchronizer, chan consensus.Results) {
 	c := make(chan consensus.Results, 1)
 	m := &mockChain{tipHeight: 0, catchBlockChan: c}
@@ -97,3 +129,11 @@ func (m *mockChain) StopConsensus() {}
 func (m *mockChain) ProcessSyncTimerExpired(string) error {
 	return nil
 }
+
+func (m *mockChain) VerifyHeaders(headers []*block.Header) ([]*block.Header, error) {
+	return headers, nil
+}
+
+func (m *mockChain) RequestBlocks(string, [][]byte) error {
+	return nil
+}
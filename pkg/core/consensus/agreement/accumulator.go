@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	log "github.com/sirupsen/logrus"
 )
@@ -22,15 +23,18 @@ type Accumulator struct {
 	eventChan          chan message.Agreement
 	CollectedVotesChan chan []message.Agreement
 	storeMap           *storeMap
+	detector           *equivocation.Detector
 
 	workersQuitChan chan struct{}
 }
 
 // NewAccumulator initializes a worker pool, starts up an Accumulator and returns it.
-func newAccumulator(handler Handler, workerAmount int) *Accumulator {
+// The detector can be nil.
+func newAccumulator(handler Handler, workerAmount int, detector *equivocation.Detector) *Accumulator {
 	// create accumulator
 	a := &Accumulator{
 		handler:            handler,
+		detector:           detector,
 		verificationChan:   make(chan message.Agreement, 100),
 		eventChan:          make(chan message.Agreement, 100),
 		CollectedVotesChan: make(chan []message.Agreement, 1),
@@ -63,6 +67,13 @@ func (a *Accumulator) Accumulate() {
 	for ev := range a.eventChan {
 		hdr := ev.State()
 
+		// An Agreement for a different hash than the one already sent by the
+		// same Provisioner at this step is an equivocation
+		if a.detector.CheckAgreement(ev) {
+			lg.Warnln("Agreement was not accumulated since it conflicts with a previous one")
+			continue
+		}
+
 		// Obtain corresponding block agreement cache given its hash
 		var s *store
 		if s = a.storeMap.getStoreByHash(hdr.BlockHash); s == nil {
@@ -25,7 +25,7 @@ func TestAccumulatorProcessing(t *testing.T) {
 	hlp := NewHelper(nr)
 	hash, _ := crypto.RandEntropy(32)
 	handler := NewHandler(hlp.Keys, *hlp.P, []byte{0, 0, 0, 0})
-	accumulator := newAccumulator(handler, 4)
+	accumulator := newAccumulator(handler, 4, nil)
 
 	evs := hlp.Spawn(hash)
 	for _, msg := range evs {
@@ -41,7 +41,7 @@ func TestAccumulatorProcessingAggregation(t *testing.T) {
 	hlp := NewHelper(nr)
 	hash, _ := crypto.RandEntropy(32)
 	handler := NewHandler(hlp.Keys, *hlp.P, []byte{0, 0, 0, 0})
-	accumulator := newAccumulator(handler, 4)
+	accumulator := newAccumulator(handler, 4, nil)
 
 	evs := hlp.Spawn(hash)
 	for _, msg := range evs {
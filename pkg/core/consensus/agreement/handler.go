@@ -75,7 +75,13 @@ func (a *handler) VotesFor(pubKeyBLS []byte, round uint64, step uint8) int {
 
 // Quorum returns the amount of committee members necessary to reach a quorum.
 func (a *handler) Quorum(round uint64) int {
-	return int(math.Ceil(float64(a.CommitteeSize(round, MaxCommitteeSize)) * 0.67))
+	return QuorumOf(a.CommitteeSize(round, MaxCommitteeSize))
+}
+
+// QuorumOf returns the amount of votes necessary to reach a quorum in a
+// committee of the given size.
+func QuorumOf(committeeSize int) int {
+	return int(math.Ceil(float64(committeeSize) * 0.67))
 }
 
 // Verify checks the signature of the set.
@@ -59,7 +59,7 @@ func (s *Loop) GetControlFn() consensus.ControlFn {
 func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementChan <-chan message.Message, aggrAgreementChan <-chan message.Message, r consensus.RoundUpdate) consensus.Results {
 	// creating accumulator and handler
 	handler := NewHandler(s.Keys, r.P, r.Seed)
-	acc := newAccumulator(handler, WorkerAmount)
+	acc := newAccumulator(handler, WorkerAmount, s.Detector)
 
 	// deferring queue cleanup at the end of the execution of this round
 	defer func() {
@@ -223,7 +223,7 @@ func (s *Loop) createWinningBlock(ctx context.Context, hash []byte, cert *block.
 }
 
 func (s *Loop) requestCandidate(ctx context.Context, hash []byte) (block.Block, error) {
-	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(2*time.Second))
+	ctx, cancel := s.WithTimeout(ctx, 2*time.Second)
 	// Ensure we release the resources associated to this context.
 	defer cancel()
 	return s.requestor.RequestCandidate(ctx, hash)
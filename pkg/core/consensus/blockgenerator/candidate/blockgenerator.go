@@ -12,11 +12,11 @@ import (
 	"errors"
 	"time"
 
-	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 
@@ -75,7 +75,7 @@ func (bg *generator) GenerateCandidateMessage(ctx context.Context, r consensus.R
 
 	committee := bg.regenerateCommittee(r)
 
-	seed, err := bg.sign(r.Seed)
+	seed, err := bg.SignSeed(r.Round, r.Seed)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +100,7 @@ func (bg *generator) GenerateCandidateMessage(ctx context.Context, r consensus.R
 	// Thus, we will instead gossip it directly.
 	scr := message.NewNewBlock(hdr, r.Hash, *blk)
 
-	sig, err := bg.Sign(hdr)
+	sig, err := bg.Sign(signer.Candidate, hdr)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +138,7 @@ func (bg *generator) GenerateBlock(round uint64, seed, prevBlockHash []byte, key
 	// Construct header
 	h := &block.Header{
 		Version:       0,
-		Timestamp:     time.Now().Unix(),
+		Timestamp:     bg.Now().Unix(),
 		Height:        round,
 		PrevBlockHash: prevBlockHash,
 		TxRoot:        nil,
@@ -203,7 +203,3 @@ func (bg *generator) ConstructBlockTxs(keys [][]byte) ([]transactions.ContractCa
 
 	return txs, nil
 }
-
-func (bg *generator) sign(seed []byte) ([]byte, error) {
-	return bls.Sign(bg.Keys.BLSSecretKey, bg.Keys.BLSPubKey, seed)
-}
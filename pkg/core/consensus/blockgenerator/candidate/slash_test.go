@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package candidate_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator/candidate"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSlashTxInCandidate submits an evidence through the equivocation service,
+// and checks that the Slash tx makes it into the next candidate block.
+func TestSlashTxInCandidate(t *testing.T) {
+	assert := require.New(t)
+
+	r := config.Get()
+
+	c := r
+	c.Mempool.MaxSizeMB = 1
+	c.Mempool.PoolType = "hashmap"
+	c.Mempool.MaxInvItems = 10000
+	c.Consensus.Slashing = true
+	config.Mock(&c)
+
+	defer config.Mock(&r)
+
+	p, provisionersKeys := consensus.MockProvisioners(10)
+
+	emitter := consensus.MockEmitter(time.Second)
+	emitter.Keys = provisionersKeys[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The chain holds the provisioner accused by the evidence
+	_, db := lite.CreateDBConnection()
+	accused, accusedKeys := consensus.MockProvisioners(1)
+
+	e := slashing.MockEvidenceOf(accusedKeys[0], 5, 2)
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		if err := t.StoreBlock(helper.RandomBlock(4, 1)); err != nil {
+			return err
+		}
+
+		if err := t.StoreProvisioners(4, accused); err != nil {
+			return err
+		}
+
+		return t.StoreEvidence(e)
+	}))
+
+	m := mempool.NewMempool(db, emitter.EventBus, emitter.RPCBus, transactions.MockProxy{}.Prober(), nil)
+	m.Run(ctx)
+
+	hash, err := e.Hash()
+	assert.NoError(err)
+
+	proxy := transactions.NewProxy(nil, nil, nil, nil, time.Second, time.Second, config.BlockGasLimit)
+	svc := equivocation.NewService(db, proxy.Provider(), emitter.RPCBus, nil)
+
+	txID, err := svc.Submit(ctx, hash)
+	assert.NoError(err)
+
+	fn := func(ctx context.Context, txs []transactions.ContractCall, h uint64) ([]transactions.ContractCall, []byte, error) {
+		return txs, make([]byte, 32), nil
+	}
+
+	_, pubKey := transactions.MockKeys()
+	gen := candidate.New(emitter, pubKey, fn)
+
+	scr, err := gen.GenerateCandidateMessage(ctx, consensus.MockRoundUpdate(6, p), 1)
+	assert.NoError(err)
+
+	var found bool
+
+	for _, tx := range scr.Candidate.Txs {
+		id, err := tx.CalculateHash()
+		assert.NoError(err)
+
+		if bytes.Equal(id, txID) {
+			assert.Equal(transactions.Slash, tx.Type())
+			found = true
+		}
+	}
+
+	assert.True(found, "slash tx not included in the candidate")
+}
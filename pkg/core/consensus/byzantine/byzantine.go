@@ -0,0 +1,241 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package byzantine makes a provisioner misbehave, in order to test that the
+// consensus finalizes blocks in the presence of faulty provisioners. It must
+// never be enabled on a real network.
+package byzantine
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "consensus").WithField("actor", "byzantine")
+
+// Fault is a misbehaviour. Faults can be combined.
+type Fault uint8
+
+const (
+	// Withhold drops the Reduction and Agreement votes of the provisioner.
+	Withhold Fault = 1 << iota
+	// RandomVote replaces the hash of the votes of the provisioner with a
+	// random one.
+	RandomVote
+	// LateCandidate delays the candidates of the provisioner.
+	LateCandidate
+	// Equivocate sends, along with each vote of the provisioner, a
+	// conflicting vote for a random hash.
+	Equivocate
+	// FloodAggrAgreement sends, along with each AggrAgreement, a number of
+	// bogus ones.
+	FloodAggrAgreement
+)
+
+var faultNames = map[string]Fault{
+	"withhold":           Withhold,
+	"randomvote":         RandomVote,
+	"latecandidate":      LateCandidate,
+	"equivocate":         Equivocate,
+	"floodaggragreement": FloodAggrAgreement,
+}
+
+// ParseFaults parses a list of fault names, as found in the configuration.
+func ParseFaults(names []string) (Fault, error) {
+	var f Fault
+
+	for _, name := range names {
+		fault, ok := faultNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("byzantine: unknown fault %q", name)
+		}
+
+		f |= fault
+	}
+
+	return f, nil
+}
+
+// Behaviour of a faulty provisioner.
+type Behaviour struct {
+	Faults Fault
+	// CandidateDelay is the delay of the candidates, with LateCandidate.
+	CandidateDelay time.Duration
+	// Flood is the number of bogus AggrAgreement sent along each one, with
+	// FloodAggrAgreement.
+	Flood int
+}
+
+// Has returns true if the Behaviour includes the fault.
+func (b Behaviour) Has(f Fault) bool {
+	return b.Faults&f != 0
+}
+
+// Faulty applies a Behaviour to the outgoing messages of a provisioner.
+type Faulty struct {
+	Behaviour
+
+	lock sync.Mutex
+	// forged lists the votes forged so far. A provisioner relays its own
+	// votes as it receives them back, and forging them again each time would
+	// never end.
+	forged map[voteKey]message.Message
+}
+
+type voteKey struct {
+	kind       signer.Kind
+	round      uint64
+	step       uint8
+	equivocate bool
+}
+
+// New creates a Faulty provisioner.
+func New(b Behaviour) *Faulty {
+	return &Faulty{
+		Behaviour: b,
+		forged:    make(map[voteKey]message.Message),
+	}
+}
+
+// Out is a message to send, after Delay.
+type Out struct {
+	Msg   message.Message
+	Delay time.Duration
+}
+
+// Apply returns the messages sent in place of an outgoing message. The votes
+// of the provisioner are signed again with s, bypassing any slashing
+// protection. Messages relayed on behalf of other provisioners are left
+// untouched, except for the AggrAgreement.
+func (b *Faulty) Apply(m message.Message, s signer.Signer) []Out {
+	switch m.Category() {
+	case topics.Reduction:
+		r := m.Payload().(message.Reduction)
+		if !bytes.Equal(r.State().PubKeyBLS, s.PubKey()) {
+			break
+		}
+
+		return b.vote(m, signer.Reduction, r.State(), s)
+	case topics.Agreement:
+		a := m.Payload().(message.Agreement)
+		if !bytes.Equal(a.State().PubKeyBLS, s.PubKey()) {
+			break
+		}
+
+		return b.vote(m, signer.Agreement, a.State(), s)
+	case topics.NewBlock:
+		nb := m.Payload().(message.NewBlock)
+		if b.Has(LateCandidate) && bytes.Equal(nb.State().PubKeyBLS, s.PubKey()) {
+			return []Out{{Msg: m, Delay: b.CandidateDelay}}
+		}
+	case topics.AggrAgreement:
+		if b.Has(FloodAggrAgreement) {
+			return b.flood(m)
+		}
+	}
+
+	return []Out{{Msg: m}}
+}
+
+func (b *Faulty) vote(m message.Message, kind signer.Kind, hdr header.Header, s signer.Signer) []Out {
+	if b.Has(Withhold) {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := voteKey{kind: kind, round: hdr.Round, step: hdr.Step}
+
+	if b.Has(RandomVote) {
+		forged, ok := b.forged[key]
+		if !ok {
+			forged = forge(m, kind, hdr, s)
+			b.forged[key] = forged
+		}
+
+		if forged != nil {
+			m = forged
+		}
+	}
+
+	out := []Out{{Msg: m}}
+
+	// The conflicting vote is only sent once
+	key.equivocate = true
+
+	if _, ok := b.forged[key]; b.Has(Equivocate) && !ok {
+		forged := forge(m, kind, hdr, s)
+		b.forged[key] = forged
+
+		if forged != nil {
+			out = append(out, Out{Msg: forged})
+		}
+	}
+
+	return out
+}
+
+// forge creates a copy of a vote for a random hash, signed with s.
+func forge(m message.Message, kind signer.Kind, hdr header.Header, s signer.Signer) message.Message {
+	hdr.BlockHash, _ = crypto.RandEntropy(32)
+
+	sig, err := s.SignVote(kind, hdr)
+	if err != nil {
+		lg.WithError(err).Warn("could not sign a forged vote")
+		return nil
+	}
+
+	switch kind {
+	case signer.Reduction:
+		r := message.NewReduction(hdr)
+		r.SignedHash = sig
+		return message.NewWithHeader(topics.Reduction, *r, m.Header())
+	case signer.Agreement:
+		orig := m.Payload().(message.Agreement)
+
+		a := message.NewAgreement(hdr)
+		a.VotesPerStep = orig.VotesPerStep
+		a.SetSignature(sig)
+
+		return message.NewWithHeader(topics.Agreement, *a, m.Header())
+	default:
+		return nil
+	}
+}
+
+// flood returns an AggrAgreement along with bogus copies, carrying random
+// hashes.
+func (b *Faulty) flood(m message.Message) []Out {
+	out := []Out{{Msg: m}}
+	aggr := m.Payload().(message.AggrAgreement)
+
+	for i := 0; i < b.Flood; i++ {
+		bogus := aggr.Copy().(message.AggrAgreement)
+
+		hdr := bogus.State()
+		hdr.BlockHash, _ = crypto.RandEntropy(32)
+
+		agreement := message.NewAgreement(hdr)
+		agreement.VotesPerStep = bogus.VotesPerStep
+		agreement.SetSignature(bogus.Signature())
+		bogus.Agreement = *agreement
+
+		out = append(out, Out{Msg: message.NewWithHeader(topics.AggrAgreement, bogus, m.Header())})
+	}
+
+	return out
+}
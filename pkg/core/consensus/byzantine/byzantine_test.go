@@ -0,0 +1,140 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package byzantine
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	assert "github.com/stretchr/testify/require"
+)
+
+func mockReduction(t *testing.T, s signer.Signer, pubKey []byte) message.Message {
+	hash, _ := crypto.RandEntropy(32)
+	hdr := header.Header{Round: 2, Step: 2, BlockHash: hash, PubKeyBLS: pubKey}
+
+	sig, err := s.SignVote(signer.Reduction, hdr)
+	assert.NoError(t, err)
+
+	r := message.NewReduction(hdr)
+	r.SignedHash = sig
+
+	return message.NewWithHeader(topics.Reduction, *r, config.KadcastInitHeader)
+}
+
+func verifyReduction(t *testing.T, m message.Message) {
+	r := m.Payload().(message.Reduction)
+
+	preimage := new(bytes.Buffer)
+	assert.NoError(t, header.MarshalSignableVote(preimage, r.State()))
+	assert.NoError(t, msg.VerifyBLSSignature(r.State().PubKeyBLS, r.SignedHash, preimage.Bytes()))
+}
+
+func TestParseFaults(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := ParseFaults([]string{"withhold", " Equivocate"})
+	assert.NoError(err)
+	assert.Equal(Withhold|Equivocate, f)
+
+	_, err = ParseFaults([]string{"lie"})
+	assert.Error(err)
+}
+
+func TestVotes(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := key.NewRandKeys()
+	s := signer.NewLocal(keys)
+	m := mockReduction(t, s, keys.BLSPubKey)
+	hash := m.Payload().(message.Reduction).State().BlockHash
+
+	// Withheld
+	b := New(Behaviour{Faults: Withhold})
+	assert.Empty(b.Apply(m, s))
+
+	// Relayed votes are untouched
+	other := key.NewRandKeys()
+	relayed := mockReduction(t, signer.NewLocal(other), other.BLSPubKey)
+
+	out := b.Apply(relayed, s)
+	assert.Len(out, 1)
+	assert.Equal(relayed, out[0].Msg)
+
+	// Random vote, properly signed
+	b = New(Behaviour{Faults: RandomVote})
+
+	out = b.Apply(m, s)
+	assert.Len(out, 1)
+	assert.NotEqual(hash, out[0].Msg.Payload().(message.Reduction).State().BlockHash)
+	verifyReduction(t, out[0].Msg)
+
+	// Relaying it sends the same forged vote
+	assert.Equal(out, b.Apply(m, s))
+
+	// Equivocation
+	b = New(Behaviour{Faults: Equivocate})
+
+	out = b.Apply(m, s)
+	assert.Len(out, 2)
+	assert.Equal(m, out[0].Msg)
+
+	conflicting := out[1].Msg.Payload().(message.Reduction).State()
+	assert.Equal(uint64(2), conflicting.Round)
+	assert.Equal(uint8(2), conflicting.Step)
+	assert.NotEqual(hash, conflicting.BlockHash)
+	verifyReduction(t, out[1].Msg)
+
+	// Only once
+	assert.Len(b.Apply(m, s), 1)
+}
+
+func TestLateCandidate(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := key.NewRandKeys()
+	hdr := header.Header{Round: 2, Step: 1, BlockHash: make([]byte, 32), PubKeyBLS: keys.BLSPubKey}
+	nb := message.NewNewBlock(hdr, make([]byte, 32), *config.DecodeGenesis())
+	m := message.New(topics.NewBlock, *nb)
+
+	b := New(Behaviour{Faults: LateCandidate, CandidateDelay: time.Second})
+
+	out := b.Apply(m, signer.NewLocal(keys))
+	assert.Len(out, 1)
+	assert.Equal(time.Second, out[0].Delay)
+}
+
+func TestFloodAggrAgreement(t *testing.T) {
+	assert := assert.New(t)
+
+	hash, _ := crypto.RandEntropy(32)
+	a := message.NewAgreement(header.Header{Round: 2, Step: 3, BlockHash: hash, PubKeyBLS: make([]byte, 96)})
+	a.VotesPerStep = []*message.StepVotes{message.NewStepVotes(), message.NewStepVotes()}
+	a.SetSignature(make([]byte, 48))
+
+	m := message.New(topics.AggrAgreement, message.NewAggrAgreement(*a, 3, make([]byte, 48)))
+
+	b := New(Behaviour{Faults: FloodAggrAgreement, Flood: 5})
+
+	out := b.Apply(m, signer.NewLocal(key.NewRandKeys()))
+	assert.Len(out, 6)
+
+	for _, o := range out[1:] {
+		bogus := o.Msg.Payload().(message.AggrAgreement)
+		assert.NotEqual(hash, bogus.State().BlockHash)
+		assert.Equal(uint64(3), bogus.Bitset)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/asdine/storm/v3"
 	"github.com/asdine/storm/v3/q"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -18,6 +19,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// MaxRounds caps the amount of rounds of a request for round records.
+const MaxRounds = 100000
+
 var (
 	eventBus *eventbus.EventBus
 	rpcBus   *rpcbus.RPCBus
@@ -144,6 +148,101 @@ func GetRoundInfoHandler(res http.ResponseWriter, req *http.Request) {
 	_, _ = res.Write(outputBytes)
 }
 
+// GetRoundRecordsHandler will return RoundRecordJSON json array for the
+// rounds between height_begin and height_end.
+func GetRoundRecordsHandler(res http.ResponseWriter, req *http.Request) {
+	heightBegin, heightEnd, ok := heightRange(req)
+	if !ok {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	log.
+		WithField("heightBegin", heightBegin).
+		WithField("heightEnd", heightEnd).
+		Debug("GetRoundRecordsHandler")
+
+	records, err := fetchRoundRecords(heightBegin, heightEnd)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(records) == 0 {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	outputBytes, err := json.Marshal(records)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(outputBytes)
+}
+
+// GetRoundStatsHandler will return RoundStatsJSON json, aggregating the
+// records of the rounds between height_begin and height_end.
+func GetRoundStatsHandler(res http.ResponseWriter, req *http.Request) {
+	heightBegin, heightEnd, ok := heightRange(req)
+	if !ok {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	log.
+		WithField("heightBegin", heightBegin).
+		WithField("heightEnd", heightEnd).
+		Debug("GetRoundStatsHandler")
+
+	records, err := fetchRoundRecords(heightBegin, heightEnd)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(records) == 0 {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	outputBytes, err := json.Marshal(NewRoundStats(records))
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(outputBytes)
+}
+
+func fetchRoundRecords(heightBegin, heightEnd uint64) ([]RoundRecordJSON, error) {
+	var records []RoundRecordJSON
+
+	err := GetStormDBInstance().DB.Range("Round", heightBegin, heightEnd, &records)
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+
+	return records, err
+}
+
+// heightRange parses the height_begin and height_end parameters of a request.
+// The range spans at most MaxRounds rounds.
+func heightRange(req *http.Request) (uint64, uint64, bool) {
+	heightBegin, err := strconv.ParseUint(req.URL.Query().Get("height_begin"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	heightEnd, err := strconv.ParseUint(req.URL.Query().Get("height_end"), 10, 64)
+	if err != nil || heightEnd < heightBegin || heightEnd-heightBegin >= MaxRounds {
+		return 0, 0, false
+	}
+
+	return heightBegin, heightEnd, true
+}
+
 // GetEventQueueStatusHandler will return EventQueueJSON json.
 func GetEventQueueStatusHandler(res http.ResponseWriter, req *http.Request) {
 	heightStr := req.URL.Query().Get("height")
@@ -31,6 +31,51 @@ type RoundInfoJSON struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	Method    string    `json:"method"`
 	Name      string    `json:"name"`
+	// Timeout is the base step timeout, once adapted to the round.
+	Timeout time.Duration `json:"timeout"`
+	// Duration is the duration of the round, once agreed upon.
+	Duration time.Duration `json:"duration"`
+}
+
+// StepRecordJSON is the record of a consensus step.
+type StepRecordJSON struct {
+	Step     uint8         `json:"step"`
+	Phase    string        `json:"phase"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+	// Votes is the number of valid messages received from the committee.
+	Votes int `json:"votes"`
+	// Quorum is true if the committee reached a quorum within the step.
+	Quorum bool `json:"quorum"`
+	// Voted is true if this node cast its vote, or its candidate.
+	Voted bool `json:"voted"`
+	// Candidate is the hash of the candidate block selected by the step,
+	// along with the public key of its generator.
+	Candidate []byte `json:"candidate,omitempty"`
+	Generator []byte `json:"generator,omitempty"`
+	// Reason explains why the step ended without a result, e.g. on timeout.
+	Reason string `json:"reason,omitempty"`
+}
+
+// RoundRecordJSON is the record of a consensus round, written when the round
+// finishes.
+type RoundRecordJSON struct {
+	Round    uint64        `storm:"id" json:"round"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+	// QuorumStep is the step at which the agreed block reached a quorum, or
+	// zero if the round did not produce a block.
+	QuorumStep uint8 `json:"quorum_step"`
+	// Candidate is the hash of the agreed block, and Generator the public key
+	// of its generator if this node received its candidate.
+	Candidate []byte `json:"candidate,omitempty"`
+	Generator []byte `json:"generator,omitempty"`
+	// Voted is true if this node voted in any step of the round.
+	Voted bool             `json:"voted"`
+	Error string           `json:"error,omitempty"`
+	Steps []StepRecordJSON `json:"steps"`
 }
 
 // PeerJSON is used as JSON wrapper for peer info fields.
@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package capi
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Percentiles summarizes a distribution.
+type Percentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// PhaseStatsJSON aggregates the steps of a phase.
+type PhaseStatsJSON struct {
+	Steps int `json:"steps"`
+	// Duration of the steps, in milliseconds.
+	Duration Percentiles `json:"duration_ms"`
+	Votes    Percentiles `json:"votes"`
+	// Failures counts the steps which ended without a result.
+	Failures int `json:"failures"`
+}
+
+// RoundStatsJSON aggregates a range of round records.
+type RoundStatsJSON struct {
+	Rounds int `json:"rounds"`
+	// Duration of the rounds, in milliseconds.
+	Duration Percentiles `json:"duration_ms"`
+	// QuorumStep is the distribution of the steps at which the rounds reached
+	// a quorum.
+	QuorumStep Percentiles                `json:"quorum_step"`
+	Phases     map[string]*PhaseStatsJSON `json:"phases"`
+}
+
+// NewRoundStats aggregates round records.
+func NewRoundStats(records []RoundRecordJSON) RoundStatsJSON {
+	stats := RoundStatsJSON{
+		Rounds: len(records),
+		Phases: make(map[string]*PhaseStatsJSON),
+	}
+
+	durations := make([]float64, 0, len(records))
+	quorumSteps := make([]float64, 0, len(records))
+	stepDurations := make(map[string][]float64)
+	stepVotes := make(map[string][]float64)
+
+	for _, r := range records {
+		durations = append(durations, millis(r.Duration))
+
+		if r.QuorumStep > 0 {
+			quorumSteps = append(quorumSteps, float64(r.QuorumStep))
+		}
+
+		for _, s := range r.Steps {
+			phase, ok := stats.Phases[s.Phase]
+			if !ok {
+				phase = new(PhaseStatsJSON)
+				stats.Phases[s.Phase] = phase
+			}
+
+			phase.Steps++
+
+			if s.Reason != "" {
+				phase.Failures++
+			}
+
+			stepDurations[s.Phase] = append(stepDurations[s.Phase], millis(s.Duration))
+			stepVotes[s.Phase] = append(stepVotes[s.Phase], float64(s.Votes))
+		}
+	}
+
+	stats.Duration = percentiles(durations)
+	stats.QuorumStep = percentiles(quorumSteps)
+
+	for name, phase := range stats.Phases {
+		phase.Duration = percentiles(stepDurations[name])
+		phase.Votes = percentiles(stepVotes[name])
+	}
+
+	return stats
+}
+
+// percentiles computes the percentiles of values with the nearest-rank
+// method. The values are sorted in place.
+func percentiles(values []float64) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+
+	sort.Float64s(values)
+
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(values)))) - 1
+		if i < 0 {
+			i = 0
+		}
+
+		return values[i]
+	}
+
+	return Percentiles{
+		P50: rank(0.5),
+		P90: rank(0.9),
+		P99: rank(0.99),
+		Max: values[len(values)-1],
+	}
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
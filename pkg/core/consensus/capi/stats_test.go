@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package capi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mockRecords() []RoundRecordJSON {
+	records := make([]RoundRecordJSON, 10)
+
+	for i := range records {
+		records[i] = RoundRecordJSON{
+			Round:      uint64(i + 1),
+			Duration:   time.Duration(i+1) * time.Second,
+			QuorumStep: 3,
+			Steps: []StepRecordJSON{
+				{Step: 1, Phase: "selection", Duration: time.Second, Votes: 1},
+				{Step: 2, Phase: "reduction1", Duration: 2 * time.Second, Votes: 10},
+			},
+		}
+	}
+
+	// The last round needed a second iteration
+	records[9].QuorumStep = 6
+	records[9].Steps[1].Reason = "timed out"
+
+	return records
+}
+
+func TestRoundStats(t *testing.T) {
+	stats := NewRoundStats(mockRecords())
+
+	require.Equal(t, 10, stats.Rounds)
+	require.Equal(t, Percentiles{P50: 5000, P90: 9000, P99: 10000, Max: 10000}, stats.Duration)
+	require.Equal(t, float64(3), stats.QuorumStep.P50)
+	require.Equal(t, float64(6), stats.QuorumStep.Max)
+
+	require.Equal(t, 10, stats.Phases["reduction1"].Steps)
+	require.Equal(t, 1, stats.Phases["reduction1"].Failures)
+	require.Equal(t, float64(2000), stats.Phases["reduction1"].Duration.P99)
+	require.Equal(t, float64(1), stats.Phases["selection"].Votes.Max)
+
+	require.Equal(t, Percentiles{}, NewRoundStats(nil).Duration)
+}
+
+func TestRoundRecordsHandlers(t *testing.T) {
+	store, err := NewStormDBInstance(filepath.Join(t.TempDir(), "api.db"))
+	require.NoError(t, err)
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	SetStormDBInstance(store)
+
+	for _, r := range mockRecords() {
+		r := r
+		require.NoError(t, store.Save(&r))
+	}
+
+	get := func(h http.HandlerFunc, target string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		h(res, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return res
+	}
+
+	res := get(GetRoundRecordsHandler, "/consensus/rounds?height_begin=3&height_end=5")
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var records []RoundRecordJSON
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &records))
+	require.Len(t, records, 3)
+	require.Equal(t, uint64(3), records[0].Round)
+	require.Len(t, records[0].Steps, 2)
+
+	res = get(GetRoundStatsHandler, "/consensus/roundstats?height_begin=1&height_end=10")
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var stats RoundStatsJSON
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &stats))
+	require.Equal(t, 10, stats.Rounds)
+
+	res = get(GetRoundRecordsHandler, "/consensus/rounds?height_begin=20&height_end=30")
+	require.Equal(t, http.StatusNotFound, res.Code)
+
+	res = get(GetRoundStatsHandler, "/consensus/roundstats?height_begin=5&height_end=1")
+	require.Equal(t, http.StatusBadRequest, res.Code)
+
+	// The range is capped
+	res = get(GetRoundRecordsHandler, fmt.Sprintf("/consensus/rounds?height_begin=1&height_end=%d", MaxRounds))
+	require.Equal(t, http.StatusOK, res.Code)
+
+	res = get(GetRoundRecordsHandler, fmt.Sprintf("/consensus/rounds?height_begin=1&height_end=%d", MaxRounds+1))
+	require.Equal(t, http.StatusBadRequest, res.Code)
+
+	res = get(GetRoundStatsHandler, "/consensus/roundstats?height_begin=0&height_end=18446744073709551615")
+	require.Equal(t, http.StatusBadRequest, res.Code)
+}
@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the time to the consensus steps. Step timers rely on it, so
+// that they can be driven by a simulated clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// WithTimeout returns a copy of the parent context, which is canceled
+	// once the duration has elapsed.
+	WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
+
+// Now returns the current time of the Emitter Clock.
+func (e *Emitter) Now() time.Time {
+	return e.clock().Now()
+}
+
+// After returns a channel receiving the time of the Emitter Clock, once the
+// duration has elapsed.
+func (e *Emitter) After(d time.Duration) <-chan time.Time {
+	return e.clock().After(d)
+}
+
+// WithTimeout returns a copy of the parent context, which is canceled once the
+// duration has elapsed on the Emitter Clock.
+func (e *Emitter) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return e.clock().WithTimeout(parent, d)
+}
+
+func (e *Emitter) clock() Clock {
+	if e.Clock != nil {
+		return e.Clock
+	}
+
+	return systemClock{}
+}
@@ -7,15 +7,16 @@
 package consensus
 
 import (
-	"bytes"
 	"context"
 	"time"
 
-	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/byzantine"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -51,6 +52,24 @@ type (
 		RPCBus      *rpcbus.RPCBus
 		Keys        key.Keys
 		TimerLength time.Duration
+		// Detector reports the equivocating votes. It can be nil.
+		Detector *equivocation.Detector
+		// Signer produces the signatures of the provisioner. If nil, they are
+		// produced with Keys.
+		Signer signer.Signer
+		// History records the signatures, so that no conflicting ones are
+		// produced after a restart. It can be nil.
+		History *signer.History
+		// Clock drives the step timers. If nil, the system clock is used.
+		Clock Clock
+		// Timeouts adapts the step timeouts to the observed rounds. If nil,
+		// each step manages its own timeout.
+		Timeouts *Timeouts
+		// Byzantine makes the provisioner misbehave. It is only meant for
+		// testing, and is nil otherwise.
+		Byzantine *byzantine.Faulty
+		// Telemetry records the rounds and their steps. It can be nil.
+		Telemetry *Telemetry
 	}
 
 	// RoundUpdate carries the data about the new Round, such as the active
@@ -110,19 +129,37 @@ func (c *acceptedBlockCollector) Collect(m message.Message) {
 	c.blockChan <- m.Payload().(block.Block)
 }
 
-// Sign a header.
-func (e *Emitter) Sign(h header.Header) ([]byte, error) {
-	preimage := new(bytes.Buffer)
-	if err := header.MarshalSignableVote(preimage, h); err != nil {
-		return nil, err
+// Sign the round, step and hash of a header, as a message of the given kind.
+// It returns signer.ErrConflict if a different hash was signed for the same
+// round and step, by a message of any kind.
+func (e *Emitter) Sign(kind signer.Kind, h header.Header) ([]byte, error) {
+	if e.History != nil {
+		if err := e.History.CheckVote(h); err != nil {
+			return nil, err
+		}
 	}
 
-	signedHash, err := bls.Sign(e.Keys.BLSSecretKey, e.Keys.BLSPubKey, preimage.Bytes())
-	if err != nil {
-		return nil, err
+	return e.signer().SignVote(kind, h)
+}
+
+// SignSeed signs the seed of the previous block, for a candidate of the
+// given round.
+func (e *Emitter) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	if e.History != nil {
+		if err := e.History.CheckSeed(round, seed); err != nil {
+			return nil, err
+		}
 	}
 
-	return signedHash, nil
+	return e.signer().SignSeed(round, seed)
+}
+
+func (e *Emitter) signer() signer.Signer {
+	if e.Signer != nil {
+		return e.Signer
+	}
+
+	return signer.NewLocal(e.Keys)
 }
 
 // Gossip concatenates the topic, the header and the payload,
@@ -156,6 +193,29 @@ func (e *Emitter) Kadcast(msg message.Message) error {
 
 // Republish reroutes message propagation to either Gossip or Kadcast network.
 func (e *Emitter) Republish(msg message.Message) error {
+	if e.Byzantine == nil {
+		return e.propagate(msg)
+	}
+
+	for _, out := range e.Byzantine.Apply(msg, e.signer()) {
+		if out.Delay == 0 {
+			if err := e.propagate(out.Msg); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		go func(out byzantine.Out) {
+			<-e.After(out.Delay)
+			_ = e.propagate(out.Msg)
+		}(out)
+	}
+
+	return nil
+}
+
+func (e *Emitter) propagate(msg message.Message) error {
 	if config.Get().Kadcast.Enabled {
 		return e.Kadcast(msg)
 	}
@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignAfterRestart ensures that an Emitter never signs a vote conflicting
+// with one signed before a restart.
+func TestSignAfterRestart(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "history")
+	require.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "signing.db")
+
+	history, err := signer.OpenHistory(path)
+	require.NoError(err)
+
+	e := MockEmitter(time.Second)
+	e.History = history
+
+	hash, _ := crypto.RandEntropy(32)
+	hdr := header.Header{Round: 5, Step: 2, BlockHash: hash, PubKeyBLS: e.Keys.BLSPubKey}
+
+	sig, err := e.Sign(signer.Reduction, hdr)
+	require.NoError(err)
+
+	// Restart with the same keys
+	require.NoError(history.Close())
+
+	history, err = signer.OpenHistory(path)
+	require.NoError(err)
+
+	defer history.Close()
+
+	restarted := MockEmitter(time.Second)
+	restarted.Keys = e.Keys
+	restarted.History = history
+
+	// The same vote can be signed again
+	again, err := restarted.Sign(signer.Reduction, hdr)
+	require.NoError(err)
+	require.Equal(sig, again)
+
+	// A conflicting one is refused
+	other, _ := crypto.RandEntropy(32)
+	hdr.BlockHash = other
+
+	_, err = restarted.Sign(signer.Reduction, hdr)
+	require.Equal(signer.ErrConflict, err)
+
+	// The Agreement signs the same preimage as the Reduction, so it can not
+	// carry another hash for the same round and step either
+	_, err = restarted.Sign(signer.Agreement, hdr)
+	require.Equal(signer.ErrConflict, err)
+
+	hdr.BlockHash = hash
+
+	_, err = restarted.Sign(signer.Agreement, hdr)
+	require.NoError(err)
+}
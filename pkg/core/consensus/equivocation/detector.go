@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package equivocation
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "consensus").WithField("actor", "equivocation")
+
+// roundsKept is the number of rounds, before the most recent one, for which
+// the votes are tracked.
+const roundsKept = 2
+
+type (
+	voteKey struct {
+		round  uint64
+		step   uint8
+		pubKey string
+	}
+
+	vote struct {
+		hdr       header.Header
+		signature []byte
+
+		// reported is set once the evidence of an equivocation on this
+		// vote has been recorded, so that it is recorded only once.
+		reported bool
+	}
+)
+
+// Detector keeps track of the first vote cast by each provisioner at every
+// step of the recent rounds. A second vote for a different block hash is an
+// equivocation: the Detector has the evidence signed by the Signer of the node,
+// which may be a remote signer, and stores it into the database.
+//
+// Reduction and Agreement votes share a single slot per round and step, as
+// they sign the same preimage: an honest provisioner only sends an Agreement
+// for the hash it voted for.
+//
+// The Detector is safe for concurrent use. A nil Detector detects nothing.
+type Detector struct {
+	signer signer.Signer
+	db     database.DB
+
+	lock     sync.Mutex
+	votes    map[voteKey]*vote
+	maxRound uint64
+}
+
+// NewDetector returns a Detector which signs the evidence with s, and stores
+// it into db.
+func NewDetector(s signer.Signer, db database.DB) *Detector {
+	return &Detector{
+		signer: s,
+		db:     db,
+		votes:  make(map[voteKey]*vote),
+	}
+}
+
+// CheckReduction tracks a Reduction vote, whose signature has already been
+// verified. It returns true if the vote conflicts with another vote of the
+// same provisioner, in which case the vote should be discarded.
+func (d *Detector) CheckReduction(r message.Reduction) bool {
+	return d.check(signer.Reduction, r.State(), r.SignedHash)
+}
+
+// CheckAgreement tracks an Agreement vote, whose signature has already been
+// verified. It returns true if the vote conflicts with another vote of the
+// same provisioner, in which case the vote should be discarded.
+func (d *Detector) CheckAgreement(a message.Agreement) bool {
+	return d.check(signer.Agreement, a.State(), a.Signature())
+}
+
+func (d *Detector) check(kind signer.Kind, hdr header.Header, signature []byte) bool {
+	if d == nil {
+		return false
+	}
+
+	e, fresh := d.track(hdr, signature)
+	if e == nil {
+		return false
+	}
+
+	lg.WithField("provisioner", util.StringifyBytes(hdr.PubKeyBLS)).
+		WithField("round", hdr.Round).
+		WithField("step", hdr.Step).
+		WithField("kind", kind.String()).
+		Warn("equivocation detected")
+
+	if !fresh {
+		return true
+	}
+
+	// The evidence is signed out of the lock, as the signer may be remote
+	if err := d.signer.SignEvidence(e); err != nil {
+		lg.WithError(err).Error("could not sign equivocation evidence")
+		return true
+	}
+
+	if err := d.record(e); err != nil {
+		lg.WithError(err).Error("could not record equivocation evidence")
+	}
+
+	return true
+}
+
+// track records the vote and returns the unsigned evidence of an equivocation,
+// if the vote conflicts with another one. fresh is set if the equivocation has
+// not been reported yet.
+func (d *Detector) track(hdr header.Header, signature []byte) (e *slashing.Evidence, fresh bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if hdr.Round > d.maxRound {
+		d.maxRound = hdr.Round
+		d.prune()
+	}
+
+	if hdr.Round+roundsKept < d.maxRound {
+		return nil, false
+	}
+
+	k := voteKey{round: hdr.Round, step: hdr.Step, pubKey: string(hdr.PubKeyBLS)}
+
+	first, ok := d.votes[k]
+	if !ok {
+		d.votes[k] = &vote{hdr: hdr, signature: signature}
+		return nil, false
+	}
+
+	if bytes.Equal(first.hdr.BlockHash, hdr.BlockHash) {
+		return nil, false
+	}
+
+	e = slashing.New(first.hdr, hdr, first.signature, signature)
+	if first.reported {
+		return e, false
+	}
+
+	first.reported = true
+	return e, true
+}
+
+// record stores a signed Evidence.
+func (d *Detector) record(e *slashing.Evidence) error {
+	if e.Signature == nil || d.db == nil {
+		return nil
+	}
+
+	return d.db.Update(func(t database.Transaction) error {
+		return t.StoreEvidence(e)
+	})
+}
+
+// prune drops the votes of the rounds which are no longer tracked.
+func (d *Detector) prune() {
+	for k := range d.votes {
+		if k.round+roundsKept < d.maxRound {
+			delete(d.votes, k)
+		}
+	}
+}
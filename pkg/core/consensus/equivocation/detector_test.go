@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package equivocation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	assert "github.com/stretchr/testify/require"
+)
+
+func mockReduction(keys key.Keys, round uint64, step uint8) message.Reduction {
+	hdr, signature := slashing.MockVote(keys, round, step)
+
+	r := message.NewReduction(hdr)
+	r.SignedHash = signature
+	return *r
+}
+
+func mockAgreement(keys key.Keys, round uint64, step uint8) message.Agreement {
+	hdr, signature := slashing.MockVote(keys, round, step)
+
+	a := message.NewAgreement(hdr)
+	a.SetSignature(signature)
+	return *a
+}
+
+func fetchEvidence(t *testing.T, db database.DB) []slashing.Evidence {
+	var list []slashing.Evidence
+
+	assert.NoError(t, db.View(func(tx database.Transaction) error {
+		var err error
+		list, err = tx.FetchEvidenceByRound(0, math.MaxUint64)
+		return err
+	}))
+
+	return list
+}
+
+func TestDetectReductionEquivocation(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	reporter := key.NewRandKeys()
+	d := NewDetector(signer.NewLocal(reporter), db)
+
+	keys := key.NewRandKeys()
+	first := mockReduction(keys, 10, 2)
+
+	assert.False(d.CheckReduction(first))
+	// A duplicated vote is not an equivocation
+	assert.False(d.CheckReduction(first))
+	// Votes of other steps do not conflict
+	assert.False(d.CheckReduction(mockReduction(keys, 10, 3)))
+
+	second := mockReduction(keys, 10, 2)
+	assert.True(d.CheckReduction(second))
+
+	list := fetchEvidence(t, db)
+	assert.Len(list, 1)
+
+	e := list[0]
+	assert.NoError(e.Verify())
+	assert.Equal(keys.BLSPubKey, e.PubKeyBLS)
+	assert.Equal(reporter.BLSPubKey, e.Reporter)
+
+	// Further conflicting votes are discarded, while the equivocation is
+	// recorded once
+	assert.True(d.CheckReduction(mockReduction(keys, 10, 2)))
+	assert.Len(fetchEvidence(t, db), 1)
+}
+
+func TestDetectAgreementEquivocation(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(signer.NewLocal(key.NewRandKeys()), db)
+
+	keys := key.NewRandKeys()
+	r := mockReduction(keys, 3, 5)
+	assert.False(d.CheckReduction(r))
+
+	// The Agreement for the hash of the Reduction vote carries the same
+	// signature
+	a := message.NewAgreement(r.State())
+	a.SetSignature(r.SignedHash)
+	assert.False(d.CheckAgreement(*a))
+
+	// while an Agreement for another hash conflicts with the Reduction vote
+	assert.True(d.CheckAgreement(mockAgreement(keys, 3, 5)))
+
+	list := fetchEvidence(t, db)
+	assert.Len(list, 1)
+	assert.NoError(list[0].Verify())
+
+	// Agreements alone are tracked as well
+	other := key.NewRandKeys()
+	assert.False(d.CheckAgreement(mockAgreement(other, 3, 5)))
+	assert.True(d.CheckAgreement(mockAgreement(other, 3, 5)))
+	assert.Len(fetchEvidence(t, db), 2)
+}
+
+func TestDetectorPruning(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	d := NewDetector(signer.NewLocal(key.NewRandKeys()), db)
+
+	keys := key.NewRandKeys()
+	assert.False(d.CheckReduction(mockReduction(keys, 1, 1)))
+
+	// Moving to a later round drops the votes of the old ones
+	assert.False(d.CheckReduction(mockReduction(key.NewRandKeys(), 1+roundsKept+1, 1)))
+	assert.Len(d.votes, 1)
+
+	// Late votes of the old rounds are ignored
+	assert.False(d.CheckReduction(mockReduction(keys, 1, 1)))
+	assert.Empty(fetchEvidence(t, db))
+
+	// A nil Detector detects nothing
+	var nilDetector *Detector
+	assert.False(nilDetector.CheckReduction(mockReduction(keys, 1, 1)))
+}
@@ -0,0 +1,163 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package equivocation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation/evidencepb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"google.golang.org/grpc"
+)
+
+// submitTimeout bounds the wait for the mempool to accept a Slash transaction.
+const submitTimeout = 5 * time.Second
+
+// Service serves the evidence recorded by the Detector, and submits it to the
+// mempool as Slash transactions.
+type Service struct {
+	db       database.DB
+	provider transactions.Provider
+	rpcBus   *rpcbus.RPCBus
+}
+
+// NewService creates a Service and registers it to the gRPC server, if any.
+func NewService(db database.DB, provider transactions.Provider, rpcBus *rpcbus.RPCBus, srv *grpc.Server) *Service {
+	s := &Service{
+		db:       db,
+		provider: provider,
+		rpcBus:   rpcBus,
+	}
+
+	if srv != nil {
+		evidencepb.RegisterEvidenceServer(srv, s)
+	}
+
+	return s
+}
+
+// GetEvidence returns the evidence of the equivocations committed since the
+// requested round.
+func (s *Service) GetEvidence(ctx context.Context, req *evidencepb.EvidenceRequest) (*evidencepb.EvidenceList, error) {
+	var list []slashing.Evidence
+
+	err := s.db.View(func(t database.Transaction) error {
+		var err error
+		list, err = t.FetchEvidenceByRound(req.GetFromRound(), math.MaxUint64)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &evidencepb.EvidenceList{
+		Evidence: make([]*evidencepb.EquivocationEvidence, len(list)),
+	}
+
+	for i, e := range list {
+		pb, err := toProto(e)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Evidence[i] = pb
+	}
+
+	return resp, nil
+}
+
+// SubmitEvidence submits the evidence with the requested hash.
+func (s *Service) SubmitEvidence(ctx context.Context, req *evidencepb.SubmitRequest) (*evidencepb.SubmitResponse, error) {
+	txID, err := s.Submit(ctx, req.GetHash())
+	if err != nil {
+		return nil, err
+	}
+
+	return &evidencepb.SubmitResponse{TxId: txID}, nil
+}
+
+// Submit creates a Slash transaction out of the stored evidence with the given
+// hash, and sends it to the mempool. It returns the hash of the transaction.
+// The evidence is only recorded unless slashing is enabled.
+func (s *Service) Submit(ctx context.Context, hash []byte) ([]byte, error) {
+	if !config.Get().Consensus.Slashing {
+		return nil, errors.New("slashing not enabled, see consensus.slashing")
+	}
+
+	var e *slashing.Evidence
+
+	err := s.db.View(func(t database.Transaction) error {
+		var err error
+		e, err = t.FetchEvidence(hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid evidence: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := slashing.Marshal(buf, *e); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.provider.NewSlash(ctx, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	txID, err := tx.CalculateHash()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.rpcBus.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), submitTimeout); err != nil {
+		return nil, err
+	}
+
+	lg.WithField("evidence", e.String()).Info("equivocation evidence submitted")
+	return txID, nil
+}
+
+func toProto(e slashing.Evidence) (*evidencepb.EquivocationEvidence, error) {
+	hash, err := e.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	pb := &evidencepb.EquivocationEvidence{
+		Hash:      hash,
+		Round:     e.Round,
+		Step:      uint32(e.Step),
+		Pubkey:    e.PubKeyBLS,
+		Votes:     make([]*evidencepb.Vote, len(e.Votes)),
+		Reporter:  e.Reporter,
+		Signature: e.Signature,
+	}
+
+	for i, v := range e.Votes {
+		pb.Votes[i] = &evidencepb.Vote{
+			BlockHash: v.BlockHash,
+			Signature: v.Signature,
+		}
+	}
+
+	return pb, nil
+}
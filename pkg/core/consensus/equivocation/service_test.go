@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package equivocation
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation/evidencepb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+
+	stored := []*slashing.Evidence{
+		slashing.MockEvidence(5, 1),
+		slashing.MockEvidence(8, 3),
+	}
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for _, e := range stored {
+			if err := t.StoreEvidence(e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	// Mempool mock
+	rb := rpcbus.New()
+	mempoolChan := make(chan rpcbus.Request, 1)
+	assert.NoError(rb.Register(topics.SendMempoolTx, mempoolChan))
+
+	submitted := make(chan transactions.ContractCall, 1)
+
+	go func() {
+		for r := range mempoolChan {
+			tx := r.Params.(transactions.ContractCall)
+			submitted <- tx
+
+			txID, _ := tx.CalculateHash()
+			r.RespChan <- rpcbus.NewResponse(txID, nil)
+		}
+	}()
+
+	proxy := transactions.NewProxy(nil, nil, nil, nil, time.Second, time.Second, config.BlockGasLimit)
+
+	// gRPC server over an in-memory connection
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	NewService(db, proxy.Provider(), rb, srv)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	assert.NoError(err)
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	client := evidencepb.NewEvidenceClient(conn)
+	ctx := context.Background()
+
+	// Evidence since round 6
+	resp, err := client.GetEvidence(ctx, &evidencepb.EvidenceRequest{FromRound: 6})
+	assert.NoError(err)
+	assert.Len(resp.GetEvidence(), 1)
+
+	pb := resp.GetEvidence()[0]
+	assert.Equal(stored[1].Round, pb.GetRound())
+	assert.Equal(uint32(stored[1].Step), pb.GetStep())
+	assert.Equal(stored[1].PubKeyBLS, pb.GetPubkey())
+	assert.Equal(stored[1].Reporter, pb.GetReporter())
+	assert.Equal(stored[1].Signature, pb.GetSignature())
+
+	for i, v := range pb.GetVotes() {
+		assert.Equal(stored[1].Votes[i].BlockHash, v.GetBlockHash())
+		assert.Equal(stored[1].Votes[i].Signature, v.GetSignature())
+	}
+
+	hash, err := stored[1].Hash()
+	assert.NoError(err)
+	assert.Equal(hash, pb.GetHash())
+
+	// Submit the evidence as a Slash transaction, once slashing is enabled
+	hash, err = stored[0].Hash()
+	assert.NoError(err)
+
+	_, err = client.SubmitEvidence(ctx, &evidencepb.SubmitRequest{Hash: hash})
+	assert.Error(err)
+
+	r := config.Get()
+	r.Consensus.Slashing = true
+	config.Mock(&r)
+
+	defer func() {
+		r.Consensus.Slashing = false
+		config.Mock(&r)
+	}()
+
+	txResp, err := client.SubmitEvidence(ctx, &evidencepb.SubmitRequest{Hash: hash})
+	assert.NoError(err)
+
+	tx := <-submitted
+	assert.Equal(transactions.Slash, tx.Type())
+
+	txID, err := tx.CalculateHash()
+	assert.NoError(err)
+	assert.Equal(txID, txResp.GetTxId())
+
+	e := new(slashing.Evidence)
+	assert.NoError(slashing.Unmarshal(bytes.NewBuffer(tx.StandardTx().CallData), e))
+	assert.True(stored[0].Equal(*e))
+
+	// Unknown evidence
+	_, err = client.SubmitEvidence(ctx, &evidencepb.SubmitRequest{Hash: make([]byte, 32)})
+	assert.Error(err)
+}
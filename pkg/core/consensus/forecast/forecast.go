@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package forecast tells a provisioner in advance when it is likely to be
+// drawn as block generator or committee member, so that its operator can plan
+// maintenance around its duties.
+package forecast
+
+import (
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+)
+
+// MaxRounds caps the amount of rounds of a forecast.
+const MaxRounds = 100000
+
+// StepDuty lists the votes of the provisioner in the committee of a step.
+type StepDuty struct {
+	Step  uint8  `json:"step"`
+	Phase string `json:"phase"`
+	// Size is the number of votes in the committee.
+	Size int `json:"size"`
+	// Slots are the indexes of the sortition draws extracting the
+	// provisioner.
+	Slots []int `json:"slots"`
+}
+
+// Duties of the provisioner in the first iteration of the next round.
+type Duties struct {
+	Round uint64     `json:"round"`
+	Seed  []byte     `json:"seed"`
+	Steps []StepDuty `json:"steps"`
+}
+
+// Forecaster computes the duties of a provisioner from the provisioner set
+// and the seed of the chain tip.
+type Forecaster struct {
+	db        database.DB
+	pubKeyBLS []byte
+}
+
+// New creates a Forecaster for the provisioner identified by pubKeyBLS.
+func New(db database.DB, pubKeyBLS []byte) *Forecaster {
+	return &Forecaster{db: db, pubKeyBLS: pubKeyBLS}
+}
+
+// Forecast estimates the duties of the provisioner over the next rounds.
+func (f *Forecaster) Forecast(rounds uint64) (*user.Forecast, error) {
+	if rounds == 0 || rounds > MaxRounds {
+		return nil, errors.New("forecast: invalid amount of rounds")
+	}
+
+	height, _, p, err := f.tip()
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := p.ForecastDuties(f.pubKeyBLS, height+1, rounds, agreement.MaxCommitteeSize)
+	return &forecast, nil
+}
+
+// NextDuties returns the exact committee slots of the provisioner in the
+// first iteration of the next round, whose seed is the one of the tip.
+func (f *Forecaster) NextDuties() (*Duties, error) {
+	height, seed, p, err := f.tip()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Duties{
+		Round: height + 1,
+		Seed:  seed,
+		Steps: make([]StepDuty, 0, len(inspector.Phases)),
+	}
+
+	for i, phase := range inspector.Phases {
+		step := uint8(i + 1)
+		size := inspector.CommitteeSize(*p, d.Round, step)
+
+		slots := p.Slots(f.pubKeyBLS, seed, d.Round, step, size)
+
+		d.Steps = append(d.Steps, StepDuty{
+			Step:  step,
+			Phase: phase,
+			Size:  size,
+			Slots: slots,
+		})
+	}
+
+	return d, nil
+}
+
+// tip returns the height and the seed of the chain tip, along with the
+// provisioners in effect for the next round.
+func (f *Forecaster) tip() (uint64, []byte, *user.Provisioners, error) {
+	var (
+		height uint64
+		seed   []byte
+		p      *user.Provisioners
+	)
+
+	err := f.db.View(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		if err != nil {
+			return err
+		}
+
+		hdr, err := t.FetchBlockHeader(s.TipHash)
+		if err != nil {
+			return err
+		}
+
+		height, seed = hdr.Height, hdr.Seed
+
+		p, err = t.FetchProvisioners(height)
+		return err
+	})
+
+	return height, seed, p, err
+}
@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package forecast
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/forecast/forecastpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mockChain stores a tip at height 3 and the provisioners in effect for the
+// next round.
+func mockChain(t *testing.T) (database.DB, []key.Keys) {
+	_, db := lite.CreateDBConnection()
+	p, keys := consensus.MockProvisioners(5)
+
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreBlock(helper.RandomBlock(3, 1)); err != nil {
+			return err
+		}
+
+		return t.StoreProvisioners(3, p)
+	}))
+
+	return db, keys
+}
+
+func TestForecast(t *testing.T) {
+	assert := assert.New(t)
+
+	db, keys := mockChain(t)
+
+	f, err := New(db, keys[0].BLSPubKey).Forecast(10)
+	assert.NoError(err)
+
+	assert.Equal(uint64(4), f.From)
+	assert.InDelta(2, f.Generator.Expected, 1e-9)
+	assert.InDelta(20, f.Committee.Expected, 1e-9)
+
+	_, err = New(db, keys[0].BLSPubKey).Forecast(0)
+	assert.Error(err)
+}
+
+func TestNextDuties(t *testing.T) {
+	assert := assert.New(t)
+
+	db, keys := mockChain(t)
+
+	votes := make([]int, 3)
+
+	for _, k := range keys {
+		d, err := New(db, k.BLSPubKey).NextDuties()
+		assert.NoError(err)
+
+		assert.Equal(uint64(4), d.Round)
+		assert.Len(d.Steps, 3)
+		assert.Equal("selection", d.Steps[0].Phase)
+		assert.Equal(1, d.Steps[0].Size)
+		assert.Equal(5, d.Steps[1].Size)
+
+		for i, s := range d.Steps {
+			votes[i] += len(s.Slots)
+		}
+	}
+
+	// Every slot of the committees is taken by one of the provisioners
+	assert.Equal([]int{1, 5, 5}, votes)
+}
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	db, keys := mockChain(t)
+	srv := grpc.NewServer()
+	NewService(db, keys[0].BLSPubKey, srv)
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	defer srv.Stop()
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	assert.NoError(err)
+
+	defer conn.Close()
+
+	client := forecastpb.NewProvisionerClient(conn)
+
+	f, err := client.ForecastDuties(context.Background(), &forecastpb.ForecastRequest{Rounds: 10})
+	assert.NoError(err)
+	assert.Equal(uint64(10), f.GetRounds())
+
+	d, err := client.GetNextDuties(context.Background(), &forecastpb.NextDutiesRequest{})
+	assert.NoError(err)
+	assert.Equal(uint64(4), d.GetRound())
+	assert.Len(d.GetSteps(), 3)
+}
@@ -0,0 +1,707 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        (unknown)
+// source: forecast.proto
+
+package forecastpb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type ForecastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Rounds is the amount of rounds to forecast.
+	Rounds uint64 `protobuf:"varint,1,opt,name=rounds,proto3" json:"rounds,omitempty"`
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forecast_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ForecastRequest) GetRounds() uint64 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+// DutyForecast estimates how often a provisioner is drawn for a duty.
+type DutyForecast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Probability of being drawn at least once.
+	Probability float64 `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
+	// Expected is the expected number of draws.
+	Expected float64 `protobuf:"fixed64,2,opt,name=expected,proto3" json:"expected,omitempty"`
+}
+
+func (x *DutyForecast) Reset() {
+	*x = DutyForecast{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forecast_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DutyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DutyForecast) ProtoMessage() {}
+
+func (x *DutyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DutyForecast.ProtoReflect.Descriptor instead.
+func (*DutyForecast) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DutyForecast) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *DutyForecast) GetExpected() float64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+// Forecast estimates the duties of a provisioner over a range of rounds.
+type Forecast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From   uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	Rounds uint64 `protobuf:"varint,2,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	// Generator is the forecast of the selection steps, which draw a single
+	// block generator.
+	Generator *DutyForecast `protobuf:"bytes,3,opt,name=generator,proto3" json:"generator,omitempty"`
+	// Committee is the forecast of the reduction steps. Each draw is a vote.
+	Committee *DutyForecast `protobuf:"bytes,4,opt,name=committee,proto3" json:"committee,omitempty"`
+}
+
+func (x *Forecast) Reset() {
+	*x = Forecast{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forecast_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Forecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Forecast) ProtoMessage() {}
+
+func (x *Forecast) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Forecast.ProtoReflect.Descriptor instead.
+func (*Forecast) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Forecast) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *Forecast) GetRounds() uint64 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *Forecast) GetGenerator() *DutyForecast {
+	if x != nil {
+		return x.Generator
+	}
+	return nil
+}
+
+func (x *Forecast) GetCommittee() *DutyForecast {
+	if x != nil {
+		return x.Committee
+	}
+	return nil
+}
+
+type NextDutiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NextDutiesRequest) Reset() {
+	*x = NextDutiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forecast_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextDutiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextDutiesRequest) ProtoMessage() {}
+
+func (x *NextDutiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextDutiesRequest.ProtoReflect.Descriptor instead.
+func (*NextDutiesRequest) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{3}
+}
+
+// StepDuty lists the votes of the provisioner in the committee of a step.
+type StepDuty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step  uint32 `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	Phase string `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	// Size is the number of votes in the committee.
+	Size uint32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Slots are the indexes of the sortition draws extracting the
+	// provisioner.
+	Slots []uint32 `protobuf:"varint,4,rep,packed,name=slots,proto3" json:"slots,omitempty"`
+}
+
+func (x *StepDuty) Reset() {
+	*x = StepDuty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forecast_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepDuty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepDuty) ProtoMessage() {}
+
+func (x *StepDuty) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepDuty.ProtoReflect.Descriptor instead.
+func (*StepDuty) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StepDuty) GetStep() uint32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *StepDuty) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *StepDuty) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *StepDuty) GetSlots() []uint32 {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+// NextDuties of the provisioner in the first iteration of the next round.
+type NextDuties struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round uint64      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Seed  []byte      `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	Steps []*StepDuty `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *NextDuties) Reset() {
+	*x = NextDuties{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forecast_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextDuties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextDuties) ProtoMessage() {}
+
+func (x *NextDuties) ProtoReflect() protoreflect.Message {
+	mi := &file_forecast_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextDuties.ProtoReflect.Descriptor instead.
+func (*NextDuties) Descriptor() ([]byte, []int) {
+	return file_forecast_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NextDuties) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *NextDuties) GetSeed() []byte {
+	if x != nil {
+		return x.Seed
+	}
+	return nil
+}
+
+func (x *NextDuties) GetSteps() []*StepDuty {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+var File_forecast_proto protoreflect.FileDescriptor
+
+var file_forecast_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x22, 0x29, 0x0a, 0x0f, 0x46, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x44, 0x75, 0x74, 0x79, 0x46, 0x6f, 0x72,
+	0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x22, 0xa2, 0x01, 0x0a, 0x08, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x09,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x74, 0x79, 0x46,
+	0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x34, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x2e, 0x44, 0x75, 0x74, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x09, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x4e, 0x65, 0x78, 0x74,
+	0x44, 0x75, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5e, 0x0a,
+	0x08, 0x53, 0x74, 0x65, 0x70, 0x44, 0x75, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x60, 0x0a,
+	0x0a, 0x4e, 0x65, 0x78, 0x74, 0x44, 0x75, 0x74, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e,
+	0x53, 0x74, 0x65, 0x70, 0x44, 0x75, 0x74, 0x79, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x32,
+	0x96, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x72, 0x12,
+	0x41, 0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x44, 0x75, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x46, 0x6f, 0x72,
+	0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x66,
+	0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x22, 0x00, 0x12, 0x44, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x44, 0x75, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x44, 0x75, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x4e, 0x65, 0x78, 0x74,
+	0x44, 0x75, 0x74, 0x69, 0x65, 0x73, 0x22, 0x00, 0x42, 0x50, 0x5a, 0x4e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x75, 0x73, 0x6b, 0x2d, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2f, 0x64, 0x75, 0x73, 0x6b, 0x2d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x2f,
+	0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_forecast_proto_rawDescOnce sync.Once
+	file_forecast_proto_rawDescData = file_forecast_proto_rawDesc
+)
+
+func file_forecast_proto_rawDescGZIP() []byte {
+	file_forecast_proto_rawDescOnce.Do(func() {
+		file_forecast_proto_rawDescData = protoimpl.X.CompressGZIP(file_forecast_proto_rawDescData)
+	})
+	return file_forecast_proto_rawDescData
+}
+
+var file_forecast_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_forecast_proto_goTypes = []interface{}{
+	(*ForecastRequest)(nil),   // 0: forecast.ForecastRequest
+	(*DutyForecast)(nil),      // 1: forecast.DutyForecast
+	(*Forecast)(nil),          // 2: forecast.Forecast
+	(*NextDutiesRequest)(nil), // 3: forecast.NextDutiesRequest
+	(*StepDuty)(nil),          // 4: forecast.StepDuty
+	(*NextDuties)(nil),        // 5: forecast.NextDuties
+}
+var file_forecast_proto_depIdxs = []int32{
+	1, // 0: forecast.Forecast.generator:type_name -> forecast.DutyForecast
+	1, // 1: forecast.Forecast.committee:type_name -> forecast.DutyForecast
+	4, // 2: forecast.NextDuties.steps:type_name -> forecast.StepDuty
+	0, // 3: forecast.Provisioner.ForecastDuties:input_type -> forecast.ForecastRequest
+	3, // 4: forecast.Provisioner.GetNextDuties:input_type -> forecast.NextDutiesRequest
+	2, // 5: forecast.Provisioner.ForecastDuties:output_type -> forecast.Forecast
+	5, // 6: forecast.Provisioner.GetNextDuties:output_type -> forecast.NextDuties
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_forecast_proto_init() }
+func file_forecast_proto_init() {
+	if File_forecast_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_forecast_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForecastRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_forecast_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DutyForecast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_forecast_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Forecast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_forecast_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextDutiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_forecast_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepDuty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_forecast_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextDuties); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_forecast_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_forecast_proto_goTypes,
+		DependencyIndexes: file_forecast_proto_depIdxs,
+		MessageInfos:      file_forecast_proto_msgTypes,
+	}.Build()
+	File_forecast_proto = out.File
+	file_forecast_proto_rawDesc = nil
+	file_forecast_proto_goTypes = nil
+	file_forecast_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ProvisionerClient is the client API for Provisioner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProvisionerClient interface {
+	// ForecastDuties estimates the duties of the provisioner over the next
+	// rounds.
+	ForecastDuties(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*Forecast, error)
+	// GetNextDuties returns the committee slots of the provisioner in the
+	// next round.
+	GetNextDuties(ctx context.Context, in *NextDutiesRequest, opts ...grpc.CallOption) (*NextDuties, error)
+}
+
+type provisionerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProvisionerClient(cc grpc.ClientConnInterface) ProvisionerClient {
+	return &provisionerClient{cc}
+}
+
+func (c *provisionerClient) ForecastDuties(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*Forecast, error) {
+	out := new(Forecast)
+	err := c.cc.Invoke(ctx, "/forecast.Provisioner/ForecastDuties", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionerClient) GetNextDuties(ctx context.Context, in *NextDutiesRequest, opts ...grpc.CallOption) (*NextDuties, error) {
+	out := new(NextDuties)
+	err := c.cc.Invoke(ctx, "/forecast.Provisioner/GetNextDuties", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProvisionerServer is the server API for Provisioner service.
+type ProvisionerServer interface {
+	// ForecastDuties estimates the duties of the provisioner over the next
+	// rounds.
+	ForecastDuties(context.Context, *ForecastRequest) (*Forecast, error)
+	// GetNextDuties returns the committee slots of the provisioner in the
+	// next round.
+	GetNextDuties(context.Context, *NextDutiesRequest) (*NextDuties, error)
+}
+
+// UnimplementedProvisionerServer can be embedded to have forward compatible implementations.
+type UnimplementedProvisionerServer struct {
+}
+
+func (*UnimplementedProvisionerServer) ForecastDuties(context.Context, *ForecastRequest) (*Forecast, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForecastDuties not implemented")
+}
+func (*UnimplementedProvisionerServer) GetNextDuties(context.Context, *NextDutiesRequest) (*NextDuties, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextDuties not implemented")
+}
+
+func RegisterProvisionerServer(s *grpc.Server, srv ProvisionerServer) {
+	s.RegisterService(&_Provisioner_serviceDesc, srv)
+}
+
+func _Provisioner_ForecastDuties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionerServer).ForecastDuties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forecast.Provisioner/ForecastDuties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionerServer).ForecastDuties(ctx, req.(*ForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provisioner_GetNextDuties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextDutiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionerServer).GetNextDuties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forecast.Provisioner/GetNextDuties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionerServer).GetNextDuties(ctx, req.(*NextDutiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Provisioner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "forecast.Provisioner",
+	HandlerType: (*ProvisionerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ForecastDuties",
+			Handler:    _Provisioner_ForecastDuties_Handler,
+		},
+		{
+			MethodName: "GetNextDuties",
+			Handler:    _Provisioner_GetNextDuties_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "forecast.proto",
+}
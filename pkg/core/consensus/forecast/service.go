@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package forecast
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/forecast/forecastpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"google.golang.org/grpc"
+)
+
+// Service serves the duties computed by a Forecaster, through the Provisioner
+// gRPC service of forecastpb.
+type Service struct {
+	*Forecaster
+}
+
+// NewService creates a Service and registers it to the gRPC server, if any.
+func NewService(db database.DB, pubKeyBLS []byte, srv *grpc.Server) *Service {
+	s := &Service{Forecaster: New(db, pubKeyBLS)}
+
+	if srv != nil {
+		forecastpb.RegisterProvisionerServer(srv, s)
+	}
+
+	return s
+}
+
+// ForecastDuties estimates the duties of the provisioner over the requested
+// amount of rounds.
+func (s *Service) ForecastDuties(ctx context.Context, req *forecastpb.ForecastRequest) (*forecastpb.Forecast, error) {
+	f, err := s.Forecast(req.GetRounds())
+	if err != nil {
+		return nil, err
+	}
+
+	return &forecastpb.Forecast{
+		From:   f.From,
+		Rounds: f.Rounds,
+		Generator: &forecastpb.DutyForecast{
+			Probability: f.Generator.Probability,
+			Expected:    f.Generator.Expected,
+		},
+		Committee: &forecastpb.DutyForecast{
+			Probability: f.Committee.Probability,
+			Expected:    f.Committee.Expected,
+		},
+	}, nil
+}
+
+// GetNextDuties returns the committee slots of the provisioner in the next
+// round.
+func (s *Service) GetNextDuties(ctx context.Context, req *forecastpb.NextDutiesRequest) (*forecastpb.NextDuties, error) {
+	d, err := s.NextDuties()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &forecastpb.NextDuties{
+		Round: d.Round,
+		Seed:  d.Seed,
+		Steps: make([]*forecastpb.StepDuty, 0, len(d.Steps)),
+	}
+
+	for _, step := range d.Steps {
+		slots := make([]uint32, len(step.Slots))
+		for i, slot := range step.Slots {
+			slots[i] = uint32(slot)
+		}
+
+		resp.Steps = append(resp.Steps, &forecastpb.StepDuty{
+			Step:  uint32(step.Step),
+			Phase: step.Phase,
+			Size:  uint32(step.Size),
+			Slots: slots,
+		})
+	}
+
+	return resp, nil
+}
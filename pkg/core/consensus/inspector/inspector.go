@@ -0,0 +1,327 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package inspector recomputes the committees of past consensus steps, from
+// the provisioner sets and the seeds stored along with the chain. It lets
+// operators find out who was entitled to vote at a given round and step, and
+// who actually voted for the certificate of a block.
+package inspector
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+)
+
+var (
+	// ErrUnknownRound is returned for a round whose previous block is not
+	// stored.
+	ErrUnknownRound = errors.New("inspector: unknown round")
+	// ErrNotCommitteeMember is returned for an evidence accusing a
+	// provisioner which was not drawn in the committee of the step.
+	ErrNotCommitteeMember = errors.New("inspector: accused provisioner is not a committee member")
+	// ErrAlreadySlashed is returned for an evidence of an equivocation
+	// already reported by a Slash tx of the chain.
+	ErrAlreadySlashed = errors.New("inspector: equivocation already slashed")
+)
+
+// Phases names the steps of an iteration.
+var Phases = []string{"selection", "reduction1", "reduction2"}
+
+// CommitteeSize returns the size of the committee drawn for a step, capped as
+// by the consensus: selection steps draw a single block generator.
+func CommitteeSize(p user.Provisioners, round uint64, step uint8) int {
+	maxSize := agreement.MaxCommitteeSize
+	if (step-1)%3 == 0 {
+		maxSize = selection.MaxCommitteeSize
+	}
+
+	size := p.SubsetSizeAt(round)
+	if size > maxSize {
+		size = maxSize
+	}
+
+	return size
+}
+
+// Member of a committee.
+type Member struct {
+	PubKeyBLS []byte `json:"pubkey"`
+	// Votes is the number of times the member was drawn by the sortition,
+	// i.e. its voting weight.
+	Votes int `json:"votes"`
+	// Stake is the total stake of the member, active or not.
+	Stake uint64 `json:"stake"`
+}
+
+// Committee of a consensus step.
+type Committee struct {
+	Round uint64 `json:"round"`
+	Step  uint8  `json:"step"`
+	Phase string `json:"phase"`
+	// Size is the number of votes in the committee.
+	Size int `json:"size"`
+	// Quorum is the number of votes needed to reach agreement.
+	Quorum int `json:"quorum"`
+	// Members are ordered as in the certificate bitsets.
+	Members []Member `json:"members"`
+}
+
+// StepCheck compares the bitset of a certificate with the committee of the
+// step.
+type StepCheck struct {
+	Committee Committee `json:"committee"`
+	Bitset    uint64    `json:"bitset"`
+	// Signers are the members flagged by the bitset.
+	Signers []Member `json:"signers"`
+	// Votes is the number of votes of the signers.
+	Votes int `json:"votes"`
+	// Overflow lists the bits set beyond the size of the committee.
+	Overflow uint64 `json:"overflow"`
+}
+
+// CertificateCheck compares the certificate of a block with the committees
+// of its reduction steps.
+type CertificateCheck struct {
+	Height uint64 `json:"height"`
+	Hash   []byte `json:"hash"`
+	Step   uint8  `json:"step"`
+
+	StepOne StepCheck `json:"step_one"`
+	StepTwo StepCheck `json:"step_two"`
+
+	// Valid is true if the aggregated signatures of the certificate match the
+	// signers. Error explains why they do not.
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// Inspector recomputes committees from the database.
+type Inspector struct {
+	db database.DB
+}
+
+// New creates an Inspector.
+func New(db database.DB) *Inspector {
+	return &Inspector{db: db}
+}
+
+// Committee returns the committee of a step.
+func (i *Inspector) Committee(round uint64, step uint8) (*Committee, error) {
+	if step == 0 {
+		return nil, errors.New("inspector: steps start at 1")
+	}
+
+	p, seed, err := i.sortitionInput(round)
+	if err != nil {
+		return nil, err
+	}
+
+	c := committee(*p, seed, round, step)
+	return &c, nil
+}
+
+// CheckCertificate compares the certificate of the block at height with the
+// committees of the reduction steps which produced it.
+func (i *Inspector) CheckCertificate(height uint64) (*CertificateCheck, error) {
+	var hdr *block.Header
+
+	err := i.db.View(func(t database.Transaction) error {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		hdr, err = t.FetchBlockHeader(hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cert := hdr.Certificate
+	if cert == nil || cert.Step < 2 {
+		return nil, errors.New("inspector: the block has no certificate")
+	}
+
+	p, seed, err := i.sortitionInput(height)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &CertificateCheck{
+		Height:  height,
+		Hash:    hdr.Hash,
+		Step:    cert.Step,
+		StepOne: checkStep(committee(*p, seed, height, cert.Step-1), cert.StepOneCommittee),
+		StepTwo: checkStep(committee(*p, seed, height, cert.Step), cert.StepTwoCommittee),
+	}
+
+	if err := verifiers.CheckBlockCertificate(*p, block.Block{Header: hdr}, seed); err != nil {
+		check.Error = err.Error()
+	} else {
+		check.Valid = true
+	}
+
+	return check, nil
+}
+
+// CheckEvidence verifies the evidence carried by a Slash tx of the block at
+// height, against the chain below it. The equivocation must be committed at
+// a round up to height, by a member of the committee of its step, and must
+// not be reported by a Slash tx of a previous block.
+func (i *Inspector) CheckEvidence(e slashing.Evidence, height uint64) error {
+	if e.Round > height {
+		return fmt.Errorf("inspector: equivocation at round %d, past the block at height %d", e.Round, height)
+	}
+
+	if err := e.Verify(); err != nil {
+		return err
+	}
+
+	var slashed bool
+
+	err := i.db.View(func(t database.Transaction) error {
+		hash, err := t.FetchSlashBlock(e.PubKeyBLS, e.Round, e.Step)
+		if err == database.ErrSlashNotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		hdr, err := t.FetchBlockHeader(hash)
+		if err != nil {
+			return err
+		}
+
+		slashed = hdr.Height < height
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if slashed {
+		return ErrAlreadySlashed
+	}
+
+	c, err := i.Committee(e.Round, e.Step)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range c.Members {
+		if bytes.Equal(member.PubKeyBLS, e.PubKeyBLS) {
+			return nil
+		}
+	}
+
+	return ErrNotCommitteeMember
+}
+
+// sortitionInput returns the provisioners and the seed which the sortition
+// of a round is based on, i.e. the ones of the previous block.
+func (i *Inspector) sortitionInput(round uint64) (*user.Provisioners, []byte, error) {
+	if round == 0 {
+		return nil, nil, ErrUnknownRound
+	}
+
+	var (
+		p    *user.Provisioners
+		seed []byte
+	)
+
+	err := i.db.View(func(t database.Transaction) error {
+		hash, err := t.FetchBlockHashByHeight(round - 1)
+		if err == database.ErrBlockNotFound {
+			return ErrUnknownRound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		hdr, err := t.FetchBlockHeader(hash)
+		if err != nil {
+			return err
+		}
+
+		seed = hdr.Seed
+
+		p, err = t.FetchProvisioners(round - 1)
+		return err
+	})
+
+	return p, seed, err
+}
+
+func committee(p user.Provisioners, seed []byte, round uint64, step uint8) Committee {
+	size := CommitteeSize(p, round, step)
+	vc := p.CreateVotingCommittee(seed, round, step, size)
+
+	c := Committee{
+		Round:   round,
+		Step:    step,
+		Phase:   Phases[(step-1)%3],
+		Size:    vc.Size(),
+		Quorum:  agreement.QuorumOf(size),
+		Members: make([]Member, 0, vc.Set.Len()),
+	}
+
+	for _, bi := range vc.Set {
+		pubKey := bi.Bytes()
+		stake, _ := p.GetStake(pubKey)
+
+		c.Members = append(c.Members, Member{
+			PubKeyBLS: pubKey,
+			Votes:     vc.OccurrencesOf(pubKey),
+			Stake:     stake,
+		})
+	}
+
+	return c
+}
+
+func checkStep(c Committee, bitset uint64) StepCheck {
+	check := StepCheck{
+		Committee: c,
+		Bitset:    bitset,
+		Signers:   make([]Member, 0),
+	}
+
+	// As for the verification of the certificate, All stands for the whole
+	// committee
+	if bitset == sortedset.All {
+		bitset = 0
+		for i := range c.Members {
+			bitset |= 1 << uint(i)
+		}
+	}
+
+	for i, m := range c.Members {
+		if bitset&(1<<uint(i)) != 0 {
+			check.Signers = append(check.Signers, m)
+			check.Votes += m.Votes
+		}
+	}
+
+	if n := len(c.Members); n < 64 {
+		check.Overflow = bitset >> uint(n) << uint(n)
+	}
+
+	return check
+}
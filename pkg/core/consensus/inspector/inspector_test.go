@@ -0,0 +1,207 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package inspector
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector/inspectorpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mockChain stores three blocks, the last two being certified by a committee
+// of provisioners. The certificate of the last block flags a member beyond the
+// committee.
+func mockChain(t *testing.T) database.DB {
+	_, db := lite.CreateDBConnection()
+	p, keys := consensus.MockProvisioners(5)
+
+	blocks := make([]*block.Block, 3)
+
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 1)
+	}
+
+	for _, blk := range blocks[1:] {
+		prev := blocks[blk.Header.Height-1]
+
+		votes := message.GenVotes(blk.Header.Hash, prev.Header.Seed, blk.Header.Height, 3, keys, p)
+		blk.Header.Certificate = &block.Certificate{
+			StepOneBatchedSig: votes[0].Signature,
+			StepTwoBatchedSig: votes[1].Signature,
+			Step:              3,
+			StepOneCommittee:  votes[0].BitSet,
+			StepTwoCommittee:  votes[1].BitSet,
+		}
+	}
+
+	blocks[2].Header.Certificate.StepTwoCommittee |= 1 << 10
+
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		for _, blk := range blocks {
+			if err := t.StoreBlock(blk); err != nil {
+				return err
+			}
+		}
+
+		return t.StoreProvisioners(0, p)
+	}))
+
+	return db
+}
+
+func TestCommittee(t *testing.T) {
+	assert := assert.New(t)
+
+	i := New(mockChain(t))
+
+	c, err := i.Committee(2, 3)
+	assert.NoError(err)
+
+	assert.Equal("reduction2", c.Phase)
+	assert.Equal(5, c.Size)
+	assert.Equal(4, c.Quorum)
+
+	votes := 0
+	for _, m := range c.Members {
+		votes += m.Votes
+	}
+
+	assert.Equal(c.Size, votes)
+
+	// Selection steps draw a single block generator
+	c, err = i.Committee(2, 4)
+	assert.NoError(err)
+	assert.Equal("selection", c.Phase)
+	assert.Equal(1, c.Size)
+
+	_, err = i.Committee(4, 1)
+	assert.Equal(ErrUnknownRound, err)
+}
+
+func TestCheckCertificate(t *testing.T) {
+	assert := assert.New(t)
+
+	i := New(mockChain(t))
+
+	check, err := i.CheckCertificate(1)
+	assert.NoError(err)
+
+	assert.True(check.Valid)
+	assert.Equal("reduction1", check.StepOne.Committee.Phase)
+	assert.True(check.StepOne.Votes >= check.StepOne.Committee.Quorum)
+	assert.True(check.StepTwo.Votes >= check.StepTwo.Committee.Quorum)
+	assert.Zero(check.StepTwo.Overflow)
+
+	check, err = i.CheckCertificate(2)
+	assert.NoError(err)
+
+	// The bits beyond the committee are ignored by the verification
+	assert.True(check.Valid)
+	assert.Equal(uint64(1<<10), check.StepTwo.Overflow)
+
+	_, err = i.CheckCertificate(0)
+	assert.Error(err)
+}
+
+func TestCheckEvidence(t *testing.T) {
+	assert := assert.New(t)
+
+	// A single provisioner, drawn in every committee
+	_, db := lite.CreateDBConnection()
+	p, keys := consensus.MockProvisioners(1)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for height := uint64(0); height < 3; height++ {
+			if err := t.StoreBlock(helper.RandomBlock(height, 1)); err != nil {
+				return err
+			}
+		}
+
+		return t.StoreProvisioners(0, p)
+	}))
+
+	i := New(db)
+
+	e := slashing.MockEvidenceOf(keys[0], 2, 2)
+	assert.NoError(i.CheckEvidence(*e, 3))
+
+	// The equivocation is committed before the block
+	assert.Error(i.CheckEvidence(*slashing.MockEvidenceOf(keys[0], 4, 2), 3))
+
+	// The votes must conflict
+	forged := *e
+	forged.Votes[1] = forged.Votes[0]
+	assert.Error(i.CheckEvidence(forged, 3))
+
+	// The accused must be drawn in the committee
+	assert.Equal(ErrNotCommitteeMember, i.CheckEvidence(*slashing.MockEvidence(2, 2), 3))
+
+	// The equivocation is reported once in the chain, whatever the reporter
+	blk := helper.RandomBlock(3, 1)
+	blk.Txs = append([]transactions.ContractCall{slashing.MockSlashTx(e)}, blk.Txs...)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk)
+	}))
+
+	assert.NoError(i.CheckEvidence(*e, 3))
+
+	other := *e
+	assert.NoError(other.Sign(keys[0]))
+	assert.Equal(ErrAlreadySlashed, i.CheckEvidence(other, 4))
+}
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := grpc.NewServer()
+	NewService(mockChain(t), srv)
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	defer srv.Stop()
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	assert.NoError(err)
+
+	defer conn.Close()
+
+	client := inspectorpb.NewCommitteesClient(conn)
+
+	c, err := client.GetCommittee(context.Background(), &inspectorpb.CommitteeRequest{Round: 2, Step: 1})
+	assert.NoError(err)
+	assert.Equal("selection", c.GetPhase())
+	assert.Equal(uint32(1), c.GetSize())
+
+	_, err = client.GetCommittee(context.Background(), &inspectorpb.CommitteeRequest{Round: 2})
+	assert.Error(err)
+
+	check, err := client.CheckCertificate(context.Background(), &inspectorpb.CertificateRequest{Height: 1})
+	assert.NoError(err)
+	assert.True(check.GetValid())
+}
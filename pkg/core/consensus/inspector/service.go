@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package inspector
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector/inspectorpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"google.golang.org/grpc"
+)
+
+// Service serves the committees recomputed by an Inspector, through the
+// Committees gRPC service.
+type Service struct {
+	*Inspector
+}
+
+// NewService creates a Service and registers it to the gRPC server, if any.
+func NewService(db database.DB, srv *grpc.Server) *Service {
+	s := &Service{Inspector: New(db)}
+
+	if srv != nil {
+		inspectorpb.RegisterCommitteesServer(srv, s)
+	}
+
+	return s
+}
+
+// GetCommittee returns the committee of the requested round and step.
+func (s *Service) GetCommittee(ctx context.Context, req *inspectorpb.CommitteeRequest) (*inspectorpb.Committee, error) {
+	if req.GetStep() > math.MaxUint8 {
+		return nil, errors.New("inspector: invalid step")
+	}
+
+	c, err := s.Committee(req.GetRound(), uint8(req.GetStep()))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.toProto(), nil
+}
+
+// CheckCertificate compares the certificate of the block at the requested
+// height with the committees of its reduction steps.
+func (s *Service) CheckCertificate(ctx context.Context, req *inspectorpb.CertificateRequest) (*inspectorpb.CertificateCheck, error) {
+	check, err := s.Inspector.CheckCertificate(req.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return &inspectorpb.CertificateCheck{
+		Height:  check.Height,
+		Hash:    check.Hash,
+		Step:    uint32(check.Step),
+		StepOne: check.StepOne.toProto(),
+		StepTwo: check.StepTwo.toProto(),
+		Valid:   check.Valid,
+		Error:   check.Error,
+	}, nil
+}
+
+func (m Member) toProto() *inspectorpb.Member {
+	return &inspectorpb.Member{
+		Pubkey: m.PubKeyBLS,
+		Votes:  uint32(m.Votes),
+		Stake:  m.Stake,
+	}
+}
+
+func (c Committee) toProto() *inspectorpb.Committee {
+	pb := &inspectorpb.Committee{
+		Round:   c.Round,
+		Step:    uint32(c.Step),
+		Phase:   c.Phase,
+		Size:    uint32(c.Size),
+		Quorum:  uint32(c.Quorum),
+		Members: make([]*inspectorpb.Member, 0, len(c.Members)),
+	}
+
+	for _, m := range c.Members {
+		pb.Members = append(pb.Members, m.toProto())
+	}
+
+	return pb
+}
+
+func (s StepCheck) toProto() *inspectorpb.StepCheck {
+	pb := &inspectorpb.StepCheck{
+		Committee: s.Committee.toProto(),
+		Bitset:    s.Bitset,
+		Signers:   make([]*inspectorpb.Member, 0, len(s.Signers)),
+		Votes:     uint32(s.Votes),
+		Overflow:  s.Overflow,
+	}
+
+	for _, m := range s.Signers {
+		pb.Signers = append(pb.Signers, m.toProto())
+	}
+
+	return pb
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
@@ -25,8 +26,11 @@ var lg = log.WithField("process", "consensus").WithField("actor", "aggr")
 // StepVotes is passed on to the Reducer by use of the `haltChan` channel.
 // An Aggregator should be instantiated on a per-step basis and is no longer usable
 // after reaching quorum and sending on `haltChan`.
+// Votes of a Provisioner for a different block hash than the one he/she
+// already voted for are reported to the equivocation Detector and discarded.
 type Aggregator struct {
-	handler *Handler
+	handler  *Handler
+	detector *equivocation.Detector
 
 	voteSets map[string]struct {
 		*message.StepVotes
@@ -35,10 +39,11 @@ type Aggregator struct {
 }
 
 // NewAggregator returns an instantiated Aggregator, ready for use by both
-// reduction steps.
-func NewAggregator(handler *Handler) *Aggregator {
+// reduction steps. The detector can be nil.
+func NewAggregator(handler *Handler, detector *equivocation.Detector) *Aggregator {
 	return &Aggregator{
-		handler: handler,
+		handler:  handler,
+		detector: detector,
 		voteSets: make(map[string]struct {
 			*message.StepVotes
 			sortedset.Cluster
@@ -52,6 +57,12 @@ func NewAggregator(handler *Handler) *Aggregator {
 // added. The validation of the candidate block is left to the caller.
 func (a *Aggregator) CollectVote(ev message.Reduction) *Result {
 	hdr := ev.State()
+
+	if a.detector.CheckReduction(ev) {
+		log.Warn("Discarding conflicting vote from a Provisioner")
+		return nil
+	}
+
 	hash := string(hdr.BlockHash)
 	sv, found := a.voteSets[hash]
 
@@ -121,6 +132,25 @@ func (a *Aggregator) CollectVote(ev message.Reduction) *Result {
 	return nil
 }
 
+// MostVotes returns the amount of committee votes collected for the most
+// voted hash.
+func (a *Aggregator) MostVotes() int {
+	var most int
+
+	for _, sv := range a.voteSets {
+		if total := sv.Cluster.TotalOccurrences(); total > most {
+			most = total
+		}
+	}
+
+	return most
+}
+
+// NoQuorum explains the timeout of a step which did not reach a quorum.
+func (a *Aggregator) NoQuorum(round uint64) string {
+	return fmt.Sprintf("timed out with %d of the %d votes needed", a.MostVotes(), a.handler.Quorum(round))
+}
+
 func (a *Aggregator) addBitSet(sv *message.StepVotes, cluster sortedset.Cluster, round uint64, step uint8) {
 	committee := a.handler.Committee(round, step)
 	sv.BitSet = committee.Bits(cluster.Set)
@@ -93,8 +93,8 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 		p.SendReduction(r.Round, step, p.selectionResult.State().BlockHash)
 	}
 
-	timeoutChan := time.After(p.TimeOut)
-	p.aggregator = reduction.NewAggregator(p.handler)
+	timeoutChan := p.After(p.StepTimeout(step, p.TimeOut))
+	p.aggregator = reduction.NewAggregator(p.handler, p.Detector)
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
 		if ev.Category() == topics.Reduction {
@@ -139,6 +139,8 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 			}
 
 		case <-timeoutChan:
+			p.Telemetry.Failed(r.Round, step, p.aggregator.NoQuorum(r.Round))
+
 			// in case of timeout we proceed in the consensus with an empty hash
 			sv := p.createStepVoteMessage(reduction.EmptyResult, r.Round, step)
 			return p.next.Initialize(*sv)
@@ -164,6 +166,8 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 		return nil
 	}
 
+	p.Telemetry.VoteReceived(round, step)
+
 	if log.GetLevel() >= logrus.DebugLevel {
 		log := consensus.WithFields(r.State().Round, r.State().Step, "1th_reduction_collected",
 			r.State().BlockHash, p.handler.BLSPubKey, nil, nil, nil)
@@ -187,9 +191,12 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 		return nil
 	}
 
+	p.Telemetry.Quorum(round, step)
+
 	// if the votes converged for an empty hash we invoke halt with no
 	// StepVotes
 	if bytes.Equal(hdr.BlockHash, reduction.EmptyHash[:]) {
+		p.Telemetry.Failed(round, step, "quorum reached on an empty hash")
 		return p.createStepVoteMessage(reduction.EmptyResult, round, step)
 	}
 
@@ -203,6 +210,7 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 				WithField("round", hdr.Round).
 				WithField("step", hdr.Step).
 				Error("firststep_fetchCandidateBlock failed")
+			p.Telemetry.Failed(round, step, "candidate not fetched: "+err.Error())
 			return p.createStepVoteMessage(reduction.EmptyResult, round, step)
 		}
 	}
@@ -213,6 +221,7 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 			WithField("round", hdr.Round).
 			WithField("step", hdr.Step).
 			Error("firststep_verifyCandidateBlock failed")
+		p.Telemetry.Failed(round, step, "invalid candidate: "+err.Error())
 		return p.createStepVoteMessage(reduction.EmptyResult, round, step)
 	}
 
@@ -236,7 +245,7 @@ func (p *Phase) fetchCandidate(ctx context.Context, hash []byte) (block.Block, e
 }
 
 func (p *Phase) requestCandidate(ctx context.Context, hash []byte) (block.Block, error) {
-	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(2*time.Second))
+	ctx, cancel := p.WithTimeout(ctx, 2*time.Second)
 	// Ensure we release the resources associated to this context.
 	defer cancel()
 
@@ -12,6 +12,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	log "github.com/sirupsen/logrus"
@@ -74,9 +75,16 @@ func (r *Reduction) SendReduction(round uint64, step uint8, hash []byte) {
 		PubKeyBLS: r.Keys.BLSPubKey,
 	}
 
-	sig, err := r.Sign(hdr)
+	// Signing is refused if the vote conflicts with one signed before a
+	// restart. In that case, the node does not vote
+	sig, err := r.Sign(signer.Reduction, hdr)
 	if err != nil {
-		panic(err)
+		lg.
+			WithError(err).
+			WithField("round", round).
+			WithField("step", step).
+			Error("reduction not signed, skipping vote")
+		return
 	}
 
 	red := message.NewReduction(hdr)
@@ -86,6 +94,8 @@ func (r *Reduction) SendReduction(round uint64, step uint8, hash []byte) {
 	if err := r.Republish(m); err != nil {
 		panic(err)
 	}
+
+	r.Telemetry.Voted(round, step)
 }
 
 // ShouldProcess checks whether a message is consistent with the current round
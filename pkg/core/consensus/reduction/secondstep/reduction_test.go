@@ -52,6 +52,37 @@ type reductionTest struct {
 	testStep          func(*testing.T, consensus.Phase)
 }
 
+// noAgreement fails if an Agreement message is gossiped at the end of the
+// step.
+func noAgreement(require *require.Assertions, _ consensus.InternalPacket, streamer *eventbus.GossipStreamer) {
+	wrongChan := make(chan struct{}, 1)
+	go func() {
+		// the reductions gossiped during the step come first
+		for {
+			if _, err := streamer.Read(); err != nil {
+				return
+			}
+
+			for _, tpc := range streamer.SeenTopics() {
+				if tpc == topics.Agreement {
+					wrongChan <- struct{}{}
+					return
+				}
+			}
+		}
+	}()
+	// 200 milliseconds should be plenty to receive an Agreement,
+	// especially since this happens at the end of the
+	// reduction step
+	c := time.After(200 * time.Millisecond)
+	select {
+	case <-wrongChan:
+		require.FailNow("unexpected Agreement message")
+	case <-c:
+		return
+	}
+}
+
 func initiateTableTest(timeout time.Duration, hash []byte, round uint64, step uint8) map[string]reductionTest {
 	return map[string]reductionTest{
 		"HappyPath": {
@@ -89,6 +120,30 @@ func initiateTableTest(timeout time.Duration, hash []byte, round uint64, step ui
 			},
 		},
 
+		"QuorumOnAnotherHash": {
+			batchEvents: func(hlp *reduction.Helper) chan message.Message {
+				evChan := make(chan message.Message, hlp.Nr)
+
+				otherHash, _ := crypto.RandEntropy(32)
+
+				batch := hlp.Spawn(otherHash, round, step)
+				for _, ev := range batch {
+					evChan <- message.New(topics.Reduction, ev)
+				}
+				return evChan
+			},
+
+			// no agreement should be signed for a hash other than the one
+			// voted for
+			testResultFactory: noAgreement,
+
+			testStep: func(t *testing.T, step consensus.Phase) {
+				r := step.(*Phase)
+
+				require.Equal(t, r.TimeOut, timeout)
+			},
+		},
+
 		"Timeout": {
 			// no need to create events as we are testing timeouts
 			batchEvents: func(hlp *reduction.Helper) chan message.Message {
@@ -96,34 +151,7 @@ func initiateTableTest(timeout time.Duration, hash []byte, round uint64, step ui
 			},
 
 			// no agreement should be sent at the end of a failing second step reduction
-			testResultFactory: func(require *require.Assertions, _ consensus.InternalPacket, streamer *eventbus.GossipStreamer) {
-				wrongChan := make(chan struct{}, 1)
-				go func() {
-					for i := 0; ; i++ {
-						if i == 2 {
-							break
-						}
-						tpcs := streamer.SeenTopics()
-						for _, tpc := range tpcs {
-							if tpc == topics.Agreement {
-								wrongChan <- struct{}{}
-								return
-							}
-						}
-						streamer.Read()
-					}
-				}()
-				// 200 milliseconds should be plenty to receive an Agreement,
-				// especially since this happens at the end of the
-				// reduction step
-				c := time.After(200 * time.Millisecond)
-				select {
-				case <-wrongChan:
-					require.FailNow("unexpected Agreement message")
-				case <-c:
-					return
-				}
-			},
+			testResultFactory: noAgreement,
 
 			// testing that the timeout doubled
 			testStep: func(t *testing.T, step consensus.Phase) {
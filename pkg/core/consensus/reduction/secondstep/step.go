@@ -16,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
@@ -89,8 +90,8 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 		p.SendReduction(r.Round, step, p.firstStepVotesMsg.BlockHash)
 	}
 
-	timeoutChan := time.After(p.TimeOut)
-	p.aggregator = reduction.NewAggregator(p.handler)
+	timeoutChan := p.After(p.StepTimeout(step, p.TimeOut))
+	p.aggregator = reduction.NewAggregator(p.handler, p.Detector)
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
 		if ev.Category() == topics.Reduction {
@@ -141,6 +142,8 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 			}
 
 		case <-timeoutChan:
+			p.Telemetry.Failed(r.Round, step, p.aggregator.NoQuorum(r.Round))
+
 			// in case of timeout we increase the timeout and that's it
 			p.IncreaseTimeout(r.Round)
 			return p.next.Initialize(nil)
@@ -172,6 +175,8 @@ func (p *Phase) collectReduction(r message.Reduction, round uint64, step uint8)
 		return nil
 	}
 
+	p.Telemetry.VoteReceived(round, step)
+
 	if log.GetLevel() >= logrus.DebugLevel {
 		log := consensus.WithFields(hdr.Round, hdr.Step, "2nd_reduction_collected",
 			hdr.BlockHash, p.handler.BLSPubKey, nil, nil, nil)
@@ -188,6 +193,9 @@ func (p *Phase) collectReduction(r message.Reduction, round uint64, step uint8)
 	}
 
 	result := p.aggregator.CollectVote(r)
+	if result != nil {
+		p.Telemetry.Quorum(round, step)
+	}
 
 	return p.createStepVoteMessage(result, round, step)
 }
@@ -216,6 +224,19 @@ func (p *Phase) createStepVoteMessage(r *reduction.Result, round uint64, step ui
 }
 
 func (p *Phase) sendAgreement(round uint64, step uint8, svm *message.StepVotesMsg) {
+	// The Agreement signs the same preimage as the Reduction vote of this
+	// step. Signing it for another hash than the one voted for would be an
+	// equivocation
+	if !bytes.Equal(svm.BlockHash, p.firstStepVotesMsg.BlockHash) {
+		lg.WithFields(log.Fields{
+			"round": round,
+			"step":  step,
+			"hash":  hex.EncodeToString(svm.BlockHash),
+			"voted": hex.EncodeToString(p.firstStepVotesMsg.BlockHash),
+		}).Warnln("quorum reached on another hash than voted for, skipping agreement")
+		return
+	}
+
 	lg.WithFields(log.Fields{
 		"round": round,
 		"step":  step,
@@ -229,9 +250,14 @@ func (p *Phase) sendAgreement(round uint64, step uint8, svm *message.StepVotesMs
 		BlockHash: svm.BlockHash,
 	}
 
-	sig, err := p.Sign(hdr)
+	sig, err := p.Sign(signer.Agreement, hdr)
 	if err != nil {
-		panic(err)
+		lg.
+			WithError(err).
+			WithField("round", round).
+			WithField("step", step).
+			Error("agreement not signed, skipping it")
+		return
 	}
 
 	lg.WithFields(log.Fields{
@@ -121,6 +121,8 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, evChan ch
 		}
 
 		evChan <- message.NewWithHeader(topics.NewBlock, *scr, []byte{config.KadcastInitialHeight})
+
+		p.Telemetry.Voted(r.Round, step)
 	}
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
@@ -129,7 +131,7 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, evChan ch
 		}
 	}
 
-	timeoutChan := time.After(p.timeout)
+	timeoutChan := p.After(p.StepTimeout(step, p.timeout))
 
 	for {
 		select {
@@ -140,6 +142,9 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, evChan ch
 					continue
 				}
 
+				p.Telemetry.VoteReceived(r.Round, step)
+				p.Telemetry.Candidate(r.Round, step, b.Candidate.Header.Hash, b.State().PubKeyBLS)
+
 				go func() {
 					<-timeoutChan
 				}()
@@ -147,6 +152,7 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, evChan ch
 				return p.endSelection(b)
 			}
 		case <-timeoutChan:
+			p.Telemetry.Failed(r.Round, step, "timed out without a valid candidate")
 			return p.endSelection(message.EmptyNewBlock())
 		case <-ctx.Done():
 			// preventing timeout leakage
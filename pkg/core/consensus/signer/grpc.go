@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer/signerpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+)
+
+// errInvalidStep is returned for a step which does not fit a consensus step.
+var errInvalidStep = errors.New("signer: invalid step")
+
+func voteRequest(kind Kind, h header.Header) *signerpb.VoteRequest {
+	return &signerpb.VoteRequest{
+		Kind:      signerpb.Kind(kind),
+		Round:     h.Round,
+		Step:      uint32(h.Step),
+		BlockHash: h.BlockHash,
+	}
+}
+
+func fromVoteRequest(req *signerpb.VoteRequest) (Kind, header.Header, error) {
+	if req.GetStep() > math.MaxUint8 {
+		return 0, header.Header{}, errInvalidStep
+	}
+
+	h := header.Header{
+		Round:     req.GetRound(),
+		Step:      uint8(req.GetStep()),
+		BlockHash: req.GetBlockHash(),
+	}
+
+	return Kind(req.GetKind()), h, nil
+}
+
+func evidenceRequest(e slashing.Evidence) *signerpb.Evidence {
+	pb := &signerpb.Evidence{
+		Round:  e.Round,
+		Step:   uint32(e.Step),
+		Pubkey: e.PubKeyBLS,
+		Votes:  make([]*signerpb.Vote, len(e.Votes)),
+	}
+
+	for i, v := range e.Votes {
+		pb.Votes[i] = &signerpb.Vote{
+			BlockHash: v.BlockHash,
+			Signature: v.Signature,
+		}
+	}
+
+	return pb
+}
+
+func fromEvidenceRequest(req *signerpb.Evidence) (*slashing.Evidence, error) {
+	if req.GetStep() > math.MaxUint8 {
+		return nil, errInvalidStep
+	}
+
+	if len(req.GetVotes()) != 2 {
+		return nil, errors.New("signer: an evidence holds two votes")
+	}
+
+	e := &slashing.Evidence{
+		Round:     req.GetRound(),
+		Step:      uint8(req.GetStep()),
+		PubKeyBLS: req.GetPubkey(),
+	}
+
+	for i, v := range req.GetVotes() {
+		e.Votes[i] = slashing.Vote{
+			BlockHash: v.GetBlockHash(),
+			Signature: v.GetSignature(),
+		}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// openTimeout bounds the wait for the file lock held by another process
+	// using the same history.
+	openTimeout = time.Second
+
+	// RoundsKept is the number of rounds, below the highest signed one, for
+	// which the history is retained. Older rounds are pruned, and signing
+	// for them is refused.
+	RoundsKept = 1000
+)
+
+var (
+	voteBucket = []byte("vote")
+	seedBucket = []byte("seed")
+	metaBucket = []byte("meta")
+
+	// highestRoundKey stores the highest round signed so far.
+	highestRoundKey = []byte("highestround")
+)
+
+// History is the persistent record of the signatures produced for a
+// provisioner. It enforces the slashing-protection rules: a signer never
+// signs two different hashes for the same round and step, nor two different
+// seeds for the same round. As the votes of all kinds share the same signed
+// preimage, a round and step is a single slot across kinds.
+//
+// The history is stored in a bbolt file. The file is locked while open, so
+// that two processes can not sign with the same history.
+type History struct {
+	db *bolt.DB
+}
+
+// OpenHistory opens the history stored at path, creating it if needed.
+func OpenHistory(path string) (*History, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("could not open signing history %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range historyBuckets() {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.CreateBucketIfNotExists(metaBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &History{db: db}, nil
+}
+
+// historyBuckets returns the buckets keyed by round.
+func historyBuckets() [][]byte {
+	return [][]byte{voteBucket, seedBucket}
+}
+
+// CheckVote records a vote, unless it conflicts with a recorded one of any
+// kind. Signing the same vote again is allowed, as BLS signatures are
+// deterministic.
+func (h *History) CheckVote(hdr header.Header) error {
+	key := make([]byte, 9)
+	binary.BigEndian.PutUint64(key, hdr.Round)
+	key[8] = hdr.Step
+
+	return h.check(voteBucket, hdr.Round, key, hdr.BlockHash)
+}
+
+// CheckSeed records the seed signed for a round, unless a different one was
+// already signed.
+func (h *History) CheckSeed(round uint64, seed []byte) error {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, round)
+
+	return h.check(seedBucket, round, key, seed)
+}
+
+func (h *History) check(bucket []byte, round uint64, key, value []byte) error {
+	return h.db.Update(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+
+		var highest uint64
+		if v := meta.Get(highestRoundKey); v != nil {
+			highest = binary.BigEndian.Uint64(v)
+		}
+
+		// Pruned rounds can not be checked anymore
+		if round+RoundsKept < highest {
+			return ErrStale
+		}
+
+		b := tx.Bucket(bucket)
+
+		if signed := b.Get(key); signed != nil {
+			if !bytes.Equal(signed, value) {
+				return ErrConflict
+			}
+
+			return nil
+		}
+
+		if err := b.Put(key, value); err != nil {
+			return err
+		}
+
+		if round <= highest {
+			return nil
+		}
+
+		v := make([]byte, 8)
+		binary.BigEndian.PutUint64(v, round)
+
+		if err := meta.Put(highestRoundKey, v); err != nil {
+			return err
+		}
+
+		if round > RoundsKept {
+			return prune(tx, round-RoundsKept)
+		}
+
+		return nil
+	})
+}
+
+// prune deletes the records of the rounds below the given one.
+func prune(tx *bolt.Tx, below uint64) error {
+	limit := make([]byte, 8)
+	binary.BigEndian.PutUint64(limit, below)
+
+	for _, name := range historyBuckets() {
+		b := tx.Bucket(name)
+		c := b.Cursor()
+
+		// Deleting while iterating a bbolt cursor may skip keys
+		var keys [][]byte
+		for k, _ := c.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close releases the history file.
+func (h *History) Close() error {
+	return h.db.Close()
+}
@@ -0,0 +1,196 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer/signerpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Setup is the configuration of the connection between a node and its remote
+// signer. Over a UNIX socket the connection is protected by the file
+// permissions. Over TCP, both ends authenticate with mutual TLS.
+type Setup struct {
+	// Network is either "unix" or "tcp".
+	Network string
+	// Address is the path of the UNIX socket, or the host:port to connect to.
+	Address string
+
+	// CertFile and KeyFile are the TLS certificate of this end.
+	CertFile string
+	KeyFile  string
+	// CAFile is the certificate authority of the other end.
+	CAFile string
+
+	// Timeout bounds each signing request.
+	Timeout time.Duration
+}
+
+// Remote is a Signer relying on a remote signer process.
+type Remote struct {
+	conn    *grpc.ClientConn
+	client  signerpb.SignerClient
+	pubKey  []byte
+	timeout time.Duration
+}
+
+// Dial connects to the remote signer, and fetches its public key.
+func Dial(ctx context.Context, s Setup) (*Remote, error) {
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	addr := s.Address
+
+	switch s.Network {
+	case "unix":
+		addr = "unix://" + s.Address
+		opts = append(opts, grpc.WithInsecure())
+	case "tcp":
+		creds, err := ClientCredentials(s.CertFile, s.KeyFile, s.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	default:
+		return nil, fmt.Errorf("signer: unsupported network %s", s.Network)
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Remote{
+		conn:    conn,
+		client:  signerpb.NewSignerClient(conn),
+		timeout: s.Timeout,
+	}
+
+	resp, err := r.client.PubKey(ctx, &signerpb.PubKeyRequest{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	r.pubKey = resp.GetPubkey()
+	return r, nil
+}
+
+// PubKey returns the BLS public key of the remote signer.
+func (r *Remote) PubKey() []byte {
+	return r.pubKey
+}
+
+// SignVote requests the signature of a vote to the remote signer.
+func (r *Remote) SignVote(kind Kind, h header.Header) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	resp, err := r.client.SignVote(ctx, voteRequest(kind, h))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetSignature(), nil
+}
+
+// SignSeed requests the signature of a seed to the remote signer.
+func (r *Remote) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	resp, err := r.client.SignSeed(ctx, &signerpb.SeedRequest{Round: round, Seed: seed})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetSignature(), nil
+}
+
+// SignEvidence requests the signature of an Evidence to the remote signer.
+func (r *Remote) SignEvidence(e *slashing.Evidence) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	resp, err := r.client.SignEvidence(ctx, evidenceRequest(*e))
+	if err != nil {
+		return err
+	}
+
+	e.Reporter = r.pubKey
+	e.Signature = resp.GetSignature()
+	return nil
+}
+
+// Close closes the connection to the remote signer.
+func (r *Remote) Close() error {
+	return r.conn.Close()
+}
+
+// ServerCredentials loads the TLS credentials of a remote signer, which only
+// accepts the clients with a certificate issued by the authority in caFile.
+func ServerCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, pool, err := loadTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// ClientCredentials loads the TLS credentials of a node, which only trusts the
+// remote signer with a certificate issued by the authority in caFile.
+func ClientCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, pool, err := loadTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+func loadTLSFiles(certFile, keyFile, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	if certFile == "" || keyFile == "" || caFile == "" {
+		return tls.Certificate{}, nil, errors.New("signer: mutual TLS requires a certificate, a key and a CA file")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, fmt.Errorf("signer: no certificate found in %s", caFile)
+	}
+
+	return cert, pool, nil
+}
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer/signerpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+)
+
+// votePreimageSize is the size of the signed preimage of a vote (round, step
+// and hash). See header.MarshalSignableVote.
+const votePreimageSize = 8 + 1 + 32
+
+// Server is the remote signer. It holds the BLS keys of a provisioner, and
+// signs the requests of its node which comply with the History.
+type Server struct {
+	keys    key.Keys
+	history *History
+}
+
+// NewServer creates a Server signing with the given keys.
+func NewServer(keys key.Keys, history *History) *Server {
+	return &Server{
+		keys:    keys,
+		history: history,
+	}
+}
+
+// PubKey returns the BLS public key of the signer.
+func (s *Server) PubKey(ctx context.Context, _ *signerpb.PubKeyRequest) (*signerpb.PubKeyResponse, error) {
+	return &signerpb.PubKeyResponse{Pubkey: s.keys.BLSPubKey}, nil
+}
+
+// SignVote signs a vote, unless it conflicts with a previously signed one.
+// The kind of the request is not trusted: all kinds sign the same preimage,
+// so the vote is checked against the votes of every kind for its round and
+// step.
+func (s *Server) SignVote(ctx context.Context, req *signerpb.VoteRequest) (*signerpb.Signature, error) {
+	kind, h, err := fromVoteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	log := lg.
+		WithField("kind", kind.String()).
+		WithField("round", h.Round).
+		WithField("step", h.Step).
+		WithField("hash", util.StringifyBytes(h.BlockHash))
+
+	// The vote is recorded before being signed, so that a signature never
+	// leaves the signer without a trace in its history
+	if err := s.history.CheckVote(h); err != nil {
+		log.WithError(err).Error("refusing to sign vote")
+		return nil, err
+	}
+
+	signature, err := signVote(s.keys, h)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("vote signed")
+	return &signerpb.Signature{Signature: signature}, nil
+}
+
+// SignSeed signs the seed of a candidate block, unless a different seed was
+// signed for the same round.
+func (s *Server) SignSeed(ctx context.Context, req *signerpb.SeedRequest) (*signerpb.Signature, error) {
+	round, seed := req.GetRound(), req.GetSeed()
+
+	log := lg.WithField("round", round)
+
+	if err := s.history.CheckSeed(round, seed); err != nil {
+		log.WithError(err).Error("refusing to sign seed")
+		return nil, err
+	}
+
+	// A seed is signed as is. If it reads as the preimage of a vote, its
+	// signature is valid for a vote of any kind, so it is checked against the
+	// History as the vote of its round and step
+	if h, ok := votePreimage(seed); ok {
+		if err := s.history.CheckVote(h); err != nil {
+			log.WithError(err).Error("refusing to sign seed")
+			return nil, err
+		}
+	}
+
+	signature, err := bls.Sign(s.keys.BLSSecretKey, s.keys.BLSPubKey, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("seed signed")
+	return &signerpb.Signature{Signature: signature}, nil
+}
+
+// votePreimage decodes the round, step and hash of a vote out of its signed
+// preimage. See header.MarshalSignableVote.
+func votePreimage(preimage []byte) (header.Header, bool) {
+	if len(preimage) != votePreimageSize {
+		return header.Header{}, false
+	}
+
+	var h header.Header
+	if err := header.UnmarshalFields(bytes.NewBuffer(preimage), &h); err != nil {
+		return header.Header{}, false
+	}
+
+	return h, true
+}
+
+// SignEvidence signs the Evidence of an equivocation as its reporter, provided
+// that it holds two conflicting votes, both correctly signed.
+func (s *Server) SignEvidence(ctx context.Context, req *signerpb.Evidence) (*signerpb.Signature, error) {
+	e, err := fromEvidenceRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	log := lg.
+		WithField("round", e.Round).
+		WithField("step", e.Step).
+		WithField("provisioner", util.StringifyBytes(e.PubKeyBLS))
+
+	if err := e.VerifyVotes(); err != nil {
+		log.WithError(err).Error("refusing to sign evidence")
+		return nil, err
+	}
+
+	preimage, err := e.Signable(s.keys.BLSPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := bls.Sign(s.keys.BLSSecretKey, s.keys.BLSPubKey, preimage)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("evidence signed")
+	return &signerpb.Signature{Signature: signature}, nil
+}
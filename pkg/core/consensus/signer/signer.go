@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package signer provides the BLS signatures produced by a provisioner during
+// consensus. Signatures can be produced locally, with the keys loaded from
+// the wallet, or by a remote signer process holding the keys on a different
+// host.
+package signer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "consensus").WithField("actor", "signer")
+
+// ErrConflict is returned when signing would produce a vote conflicting with
+// a previously signed one, i.e. an equivocation.
+var ErrConflict = errors.New("signer: conflicting with a previous signature")
+
+// ErrStale is returned when signing for a round older than the ones retained
+// by the History.
+var ErrStale = errors.New("signer: round is too old")
+
+// Kind is the kind of consensus message being signed. Votes of all kinds sign
+// the same preimage, so a provisioner signs a single hash for a round and
+// step, whatever the kind.
+type Kind uint8
+
+const (
+	// Candidate is the signature of a candidate block.
+	Candidate Kind = iota
+	// Reduction is the vote of a reduction step.
+	Reduction
+	// Agreement is the vote carried by an Agreement message.
+	Agreement
+
+	maxKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Candidate:
+		return "candidate"
+	case Reduction:
+		return "reduction"
+	case Agreement:
+		return "agreement"
+	default:
+		return "unknown"
+	}
+}
+
+// Signer signs the consensus messages on behalf of a provisioner.
+type Signer interface {
+	// PubKey returns the BLS public key of the provisioner.
+	PubKey() []byte
+	// SignVote signs the round, step and hash of a consensus message.
+	SignVote(kind Kind, h header.Header) ([]byte, error)
+	// SignSeed signs the seed of the previous block, for a candidate of the
+	// given round.
+	SignSeed(round uint64, seed []byte) ([]byte, error)
+	// SignEvidence signs the Evidence of an equivocation, as its reporter.
+	SignEvidence(e *slashing.Evidence) error
+}
+
+type local struct {
+	keys key.Keys
+}
+
+// NewLocal creates a Signer holding the keys in memory.
+func NewLocal(keys key.Keys) Signer {
+	return &local{keys: keys}
+}
+
+func (l *local) PubKey() []byte {
+	return l.keys.BLSPubKey
+}
+
+func (l *local) SignVote(kind Kind, h header.Header) ([]byte, error) {
+	return signVote(l.keys, h)
+}
+
+func (l *local) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	return bls.Sign(l.keys.BLSSecretKey, l.keys.BLSPubKey, seed)
+}
+
+func (l *local) SignEvidence(e *slashing.Evidence) error {
+	return e.Sign(l.keys)
+}
+
+func signVote(keys key.Keys, h header.Header) ([]byte, error) {
+	preimage := new(bytes.Buffer)
+	if err := header.MarshalSignableVote(preimage, h); err != nil {
+		return nil, err
+	}
+
+	return bls.Sign(keys.BLSSecretKey, keys.BLSPubKey, preimage.Bytes())
+}
@@ -0,0 +1,211 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer/signerpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	assert "github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc"
+)
+
+func mockHeader(round uint64, step uint8) header.Header {
+	hash, _ := crypto.RandEntropy(32)
+	return header.Header{Round: round, Step: step, BlockHash: hash}
+}
+
+func verifyVote(t *testing.T, pubKey, signature []byte, h header.Header) {
+	preimage := new(bytes.Buffer)
+	assert.NoError(t, header.MarshalSignableVote(preimage, h))
+	assert.NoError(t, msg.VerifyBLSSignature(pubKey, signature, preimage.Bytes()))
+}
+
+func TestHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "history.db")
+
+	h, err := OpenHistory(path)
+	assert.NoError(err)
+
+	vote := mockHeader(10, 2)
+	assert.NoError(h.CheckVote(vote))
+	assert.NoError(h.CheckVote(vote))
+
+	other := mockHeader(10, 2)
+	assert.Equal(ErrConflict, h.CheckVote(other))
+	assert.NoError(h.CheckVote(mockHeader(10, 3)))
+
+	assert.NoError(h.CheckSeed(10, []byte{1, 2, 3}))
+	assert.Equal(ErrConflict, h.CheckSeed(10, []byte{3, 2, 1}))
+
+	// The history survives a restart
+	assert.NoError(h.Close())
+
+	h, err = OpenHistory(path)
+	assert.NoError(err)
+
+	defer h.Close()
+
+	assert.NoError(h.CheckVote(vote))
+	assert.Equal(ErrConflict, h.CheckVote(other))
+	assert.Equal(ErrConflict, h.CheckSeed(10, []byte{3, 2, 1}))
+}
+
+func TestHistoryPruning(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	h, err := OpenHistory(filepath.Join(dir, "history.db"))
+	assert.NoError(err)
+
+	defer h.Close()
+
+	old := mockHeader(1, 1)
+	assert.NoError(h.CheckVote(old))
+	assert.NoError(h.CheckVote(mockHeader(1+RoundsKept, 1)))
+
+	// Still within the kept rounds
+	assert.Equal(ErrConflict, h.CheckVote(mockHeader(1, 1)))
+
+	// Moving further prunes the first round, which can not be signed anymore
+	assert.NoError(h.CheckVote(mockHeader(2+RoundsKept, 1)))
+	assert.Equal(ErrStale, h.CheckVote(old))
+	assert.Equal(ErrStale, h.CheckSeed(1, []byte{1}))
+
+	assert.NoError(h.db.View(func(tx *bolt.Tx) error {
+		assert.Nil(tx.Bucket(voteBucket).Get([]byte{0, 0, 0, 0, 0, 0, 0, 1, 1}))
+		return nil
+	}))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	history, err := OpenHistory(filepath.Join(dir, "history.db"))
+	assert.NoError(err)
+
+	defer history.Close()
+
+	keys := key.NewRandKeys()
+	socket := filepath.Join(dir, "signer.sock")
+
+	l, err := net.Listen("unix", socket)
+	assert.NoError(err)
+
+	srv := grpc.NewServer()
+	signerpb.RegisterSignerServer(srv, NewServer(keys, history))
+
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	r, err := Dial(ctx, Setup{Network: "unix", Address: socket, Timeout: time.Second})
+	assert.NoError(err)
+
+	defer r.Close()
+
+	assert.Equal(keys.BLSPubKey, r.PubKey())
+
+	// Same signature as the local signer
+	vote := mockHeader(3, 1)
+
+	signature, err := r.SignVote(Reduction, vote)
+	assert.NoError(err)
+	verifyVote(t, keys.BLSPubKey, signature, vote)
+
+	localSignature, err := NewLocal(keys).SignVote(Reduction, vote)
+	assert.NoError(err)
+	assert.Equal(localSignature, signature)
+
+	// Conflicting vote
+	_, err = r.SignVote(Reduction, mockHeader(3, 1))
+	assert.Error(err)
+
+	// Switching kind does not get a conflicting vote signed either
+	_, err = r.SignVote(Agreement, mockHeader(3, 1))
+	assert.Error(err)
+
+	_, err = r.SignVote(Candidate, mockHeader(3, 1))
+	assert.Error(err)
+
+	// while the same hash is signed for any kind
+	_, err = r.SignVote(Agreement, vote)
+	assert.NoError(err)
+
+	// Seeds
+	seed := make([]byte, 33)
+
+	_, err = r.SignSeed(3, seed)
+	assert.NoError(err)
+
+	_, err = r.SignSeed(3, make([]byte, 48))
+	assert.Error(err)
+
+	// A seed looking like a vote conflicting with a signed one is refused
+	preimage := new(bytes.Buffer)
+	assert.NoError(header.MarshalSignableVote(preimage, mockHeader(3, 1)))
+
+	_, err = r.SignSeed(4, preimage.Bytes())
+	assert.Error(err)
+
+	// Otherwise, it is recorded as the vote of its round and step
+	forged := mockHeader(5, 1)
+
+	preimage = new(bytes.Buffer)
+	assert.NoError(header.MarshalSignableVote(preimage, forged))
+
+	signature, err = r.SignSeed(5, preimage.Bytes())
+	assert.NoError(err)
+	verifyVote(t, keys.BLSPubKey, signature, forged)
+
+	_, err = r.SignVote(Agreement, mockHeader(5, 1))
+	assert.Error(err)
+
+	// Evidence is signed by the remote signer as reporter
+	e := slashing.MockEvidence(5, 1)
+	assert.NoError(r.SignEvidence(e))
+	assert.Equal(keys.BLSPubKey, e.Reporter)
+	assert.NoError(e.Verify())
+
+	// unless the votes do not conflict
+	e.Votes[1] = e.Votes[0]
+	assert.Error(r.SignEvidence(e))
+}
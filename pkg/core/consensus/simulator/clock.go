@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock is a virtual clock. Its time only moves forward when the Simulator
+// runs the next scheduled event, so that the step timers expire as soon as
+// nothing else can happen before them.
+type Clock struct {
+	lock   sync.Mutex
+	now    time.Time
+	seq    uint64
+	events eventQueue
+}
+
+// event is a function scheduled at a given time. Simultaneous events are run
+// in the order of their key, so that the order does not depend on the order
+// in which they were scheduled.
+type event struct {
+	at  time.Time
+	key string
+	seq uint64
+	// fn runs the event. It returns false if the event had no effect on the
+	// nodes.
+	fn func() bool
+}
+
+// NewClock creates a Clock starting at the given time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// Schedule runs fn once the duration has elapsed.
+func (c *Clock) Schedule(d time.Duration, key string, fn func() bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.seq++
+
+	heap.Push(&c.events, &event{
+		at:  c.now.Add(d),
+		key: key,
+		seq: c.seq,
+		fn:  fn,
+	})
+}
+
+// advance moves the time forward to the earliest scheduled event, and pops
+// all the events scheduled at that time. It returns false if no event is
+// scheduled.
+func (c *Clock) advance() ([]*event, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.events) == 0 {
+		return nil, false
+	}
+
+	first := heap.Pop(&c.events).(*event)
+	c.now = first.at
+
+	due := []*event{first}
+	for len(c.events) > 0 && c.events[0].at.Equal(first.at) {
+		due = append(due, heap.Pop(&c.events).(*event))
+	}
+
+	return due, true
+}
+
+// nodeClock is the consensus.Clock of a single node.
+type nodeClock struct {
+	*Clock
+	id int
+}
+
+// After schedules a timer for the node.
+func (n nodeClock) After(d time.Duration) <-chan time.Time {
+	// Buffered, so that expiring a timer nobody waits for anymore does not
+	// block the Simulator
+	c := make(chan time.Time, 1)
+
+	n.Schedule(d, fmt.Sprintf("timer/%04d", n.id), func() bool {
+		c <- n.Now()
+		return true
+	})
+
+	return c
+}
+
+// WithTimeout returns a context canceled by a timer of the node, so that the
+// deadlines of the consensus are driven by the virtual clock as well.
+func (n nodeClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	n.Schedule(d, fmt.Sprintf("timer/%04d", n.id), func() bool {
+		// A context canceled already has no effect on the node
+		effective := ctx.Err() == nil
+		cancel()
+		return effective
+	})
+
+	return ctx, cancel
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+
+	if q[i].key != q[j].key {
+		return q[i].key < q[j].key
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) {
+	*q = append(*q, x.(*event))
+}
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return e
+}
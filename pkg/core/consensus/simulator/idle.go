@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+)
+
+// blockedStates are the states of the goroutines waiting for another
+// goroutine. A goroutine in any other state (i.e. running, runnable, in a
+// syscall or a cgo call, or sleeping) still has work to do.
+var blockedStates = []string{
+	"chan receive",
+	"chan send",
+	"select",
+	"semacquire",
+	"sync.",
+	"IO wait",
+}
+
+// settle waits for the nodes to be done processing the events of the current
+// instant, i.e. for every goroutine but the Simulator to be blocked. Blocked
+// goroutines can only be woken up by the next events of the virtual clock, so
+// the outcome does not depend on the speed or the load of the machine.
+func (s *Simulator) settle(ctx context.Context) {
+	buf := make([]byte, 1<<16)
+
+	for ctx.Err() == nil {
+		n := runtime.Stack(buf, true)
+		if n == len(buf) {
+			buf = make([]byte, 2*len(buf))
+			continue
+		}
+
+		if idle(buf[:n]) {
+			return
+		}
+
+		runtime.Gosched()
+	}
+}
+
+// idle returns true if all the goroutines of a stack dump, but the calling
+// one, are blocked.
+func idle(dump []byte) bool {
+	// The calling goroutine comes first
+	for i, g := range bytes.Split(dump, []byte("\n\n")) {
+		if i == 0 {
+			continue
+		}
+
+		if !blocked(goroutineState(g)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// goroutineState extracts the state from the header of a goroutine trace,
+// i.e. "select" from "goroutine 7 [select, 2 minutes]:".
+func goroutineState(trace []byte) string {
+	start := bytes.IndexByte(trace, '[')
+	end := bytes.IndexByte(trace, ']')
+
+	if start < 0 || end < start {
+		return ""
+	}
+
+	state := trace[start+1 : end]
+	if i := bytes.IndexByte(state, ','); i >= 0 {
+		state = state[:i]
+	}
+
+	return string(state)
+}
+
+func blocked(state string) bool {
+	for _, s := range blockedStates {
+		if strings.HasPrefix(state, s) {
+			return true
+		}
+	}
+
+	return false
+}
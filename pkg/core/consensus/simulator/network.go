@@ -0,0 +1,269 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+)
+
+// Partition splits the network in groups of nodes for a period of time.
+// Messages between nodes of different groups are lost. Nodes which are not
+// part of any group are isolated.
+type Partition struct {
+	// Start and End delimit the partition, relative to the start of the
+	// simulation.
+	Start time.Duration
+	End   time.Duration
+	// Groups lists the indexes of the nodes of each group.
+	Groups [][]int
+}
+
+func (p Partition) separates(at time.Duration, from, to int) bool {
+	if at < p.Start || at >= p.End {
+		return false
+	}
+
+	for _, group := range p.Groups {
+		var hasFrom, hasTo bool
+
+		for _, id := range group {
+			hasFrom = hasFrom || id == from
+			hasTo = hasTo || id == to
+		}
+
+		if hasFrom || hasTo {
+			return !(hasFrom && hasTo)
+		}
+	}
+
+	return true
+}
+
+// routedTopics are the gossiped topics delivered to the nodes. Anything else
+// (i.e. the chain synchronization) is not simulated.
+var routedTopics = map[topics.Topic]struct{}{
+	topics.NewBlock:      {},
+	topics.Reduction:     {},
+	topics.Agreement:     {},
+	topics.AggrAgreement: {},
+	topics.GetCandidate:  {},
+}
+
+// network delivers the messages gossiped by the nodes, according to the link
+// conditions of the Config.
+type network struct {
+	cfg   Config
+	clock *Clock
+	start time.Time
+	nodes []*node
+
+	lock sync.Mutex
+	// seen lists the messages delivered to each node. Nodes only process the
+	// first copy of a gossiped message.
+	seen []map[string]struct{}
+	// occurrences counts the messages sent over each link, by identity.
+	occurrences map[string]int
+
+	sent, lost int
+}
+
+func newNetwork(cfg Config, clock *Clock, nodes []*node) *network {
+	seen := make([]map[string]struct{}, len(nodes))
+	for i := range seen {
+		seen[i] = make(map[string]struct{})
+	}
+
+	return &network{
+		cfg:   cfg,
+		clock: clock,
+		start: clock.Now(),
+		nodes: nodes,
+		seen:  seen,
+
+		occurrences: make(map[string]int),
+	}
+}
+
+// gossip sends a message from a node to all the nodes, itself included.
+func (n *network) gossip(from int, m message.Message) {
+	b := m.Payload().(message.SafeBuffer).Buffer
+	data := b.Bytes()
+
+	if len(data) == 0 {
+		return
+	}
+
+	if _, ok := routedTopics[topics.Topic(data[0])]; !ok {
+		return
+	}
+
+	id, err := identify(data)
+	if err != nil {
+		lg.WithError(err).Error("could not decode gossiped message")
+		return
+	}
+
+	for to := range n.nodes {
+		to := to
+
+		n.send(from, to, id, func() bool {
+			return n.deliver(from, to, data)
+		})
+	}
+}
+
+// identify returns a description of a message which does not depend on its
+// random content (i.e. the candidate block hashes), so that the network
+// conditions are the same across runs with the same Config.
+func identify(data []byte) (string, error) {
+	m, err := message.Unmarshal(bytes.NewBuffer(data), nil)
+	if err != nil {
+		return "", err
+	}
+
+	// The key in the header of an AggrAgreement depends on the order in
+	// which the Agreements were aggregated. Nodes send a single one per step.
+	if m.Category() == topics.AggrAgreement {
+		hdr := m.Payload().(message.AggrAgreement).State()
+		return fmt.Sprintf("%s/%d/%d", m.Category(), hdr.Round, hdr.Step), nil
+	}
+
+	if p, ok := m.Payload().(consensus.InternalPacket); ok {
+		hdr := p.State()
+		return fmt.Sprintf("%s/%d/%d/%s", m.Category(), hdr.Round, hdr.Step, util.StringifyBytes(hdr.PubKeyBLS)), nil
+	}
+
+	return m.Category().String(), nil
+}
+
+// send schedules the delivery of a message over a link. The latency and the
+// loss are drawn from a RNG seeded with the identity of the message and the
+// link, so that they do not depend on the order in which the nodes send their
+// messages.
+func (n *network) send(from, to int, id string, deliver func() bool) {
+	at := n.clock.Now().Sub(n.start)
+
+	n.lock.Lock()
+	n.sent++
+	// The same message can legitimately be sent more than once (i.e. a
+	// republished Agreement).
+	link := fmt.Sprintf("%04d/%04d/%s", to, from, id)
+	n.occurrences[link]++
+	key := fmt.Sprintf("msg/%s/%d", link, n.occurrences[link])
+	n.lock.Unlock()
+
+	// Loopback
+	if from == to {
+		n.clock.Schedule(0, key, deliver)
+		return
+	}
+
+	for _, p := range n.cfg.Partitions {
+		if p.separates(at, from, to) {
+			n.drop()
+			return
+		}
+	}
+
+	rng := n.linkRNG(key)
+
+	if rng.Float64() < n.cfg.Loss {
+		n.drop()
+		return
+	}
+
+	// Latencies are rounded to the millisecond, so that more deliveries
+	// happen at the same instant and the nodes settle fewer times.
+	latency := n.cfg.MinLatency
+	if spread := (n.cfg.MaxLatency - n.cfg.MinLatency) / time.Millisecond; spread > 0 {
+		latency += time.Duration(rng.Int63n(int64(spread)+1)) * time.Millisecond
+	}
+
+	n.clock.Schedule(latency, key, deliver)
+}
+
+func (n *network) drop() {
+	n.lock.Lock()
+	n.lost++
+	n.lock.Unlock()
+}
+
+func (n *network) linkRNG(key string) *rand.Rand {
+	h := fnv.New64a()
+
+	var seed [8]byte
+	binary.LittleEndian.PutUint64(seed[:], uint64(n.cfg.Seed))
+
+	_, _ = h.Write(seed[:])
+	_, _ = h.Write([]byte(key))
+
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// deliver hands a gossiped message to a node, unless it was already
+// delivered.
+func (n *network) deliver(from, to int, data []byte) bool {
+	n.lock.Lock()
+	if _, ok := n.seen[to][string(data)]; ok {
+		n.lock.Unlock()
+		return false
+	}
+
+	n.seen[to][string(data)] = struct{}{}
+	n.lock.Unlock()
+
+	m, err := message.Unmarshal(bytes.NewBuffer(data), nil)
+	if err != nil {
+		lg.WithError(err).Error("could not decode gossiped message")
+		return false
+	}
+
+	target := n.nodes[to]
+
+	if m.Category() == topics.GetCandidate {
+		n.provideCandidate(to, from, m)
+		return true
+	}
+
+	target.eventBus.Publish(m.Category(), m)
+	return true
+}
+
+// provideCandidate answers a GetCandidate request, sending the Candidate back
+// to the requesting node.
+func (n *network) provideCandidate(provider, requester int, m message.Message) {
+	resp, err := n.nodes[provider].broker.ProvideCandidate("", m)
+	if err != nil {
+		// The provider does not know the candidate
+		return
+	}
+
+	for _, buf := range resp {
+		data := buf.Bytes()
+
+		n.send(provider, requester, topics.Candidate.String(), func() bool {
+			c, err := message.Unmarshal(bytes.NewBuffer(data), nil)
+			if err != nil {
+				return false
+			}
+
+			_, err = n.nodes[requester].loop.ProcessCandidate("", c)
+			return err == nil
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/byzantine"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// node is a stripped-down Dusk node, running the chain and the consensus
+// loop. Every node has its own buses, so that the Simulator controls what
+// reaches it.
+type node struct {
+	id       int
+	eventBus *eventbus.EventBus
+	rpcBus   *rpcbus.RPCBus
+	db       database.DB
+	chain    *chain.Chain
+	loop     *loop.Consensus
+	broker   *responding.CandidateBroker
+}
+
+func newNode(ctx context.Context, id int, clock *Clock, k key.Keys, p user.Provisioners, b *byzantine.Faulty, t *consensus.Timeouts, onAccept func(int, block.Block)) (*node, error) {
+	eb, rb := eventbus.New(), rpcbus.New()
+
+	_, db := lite.CreateDBConnection()
+
+	genesis := config.DecodeGenesis()
+	l := chain.NewDBLoader(db, genesis)
+
+	if _, err := l.LoadTip(); err != nil {
+		return nil, err
+	}
+
+	if err := serveEmptyMempool(rb); err != nil {
+		return nil, err
+	}
+
+	pk := keys.PublicKey{
+		AG: make([]byte, 32),
+		BG: make([]byte, 32),
+	}
+
+	e := &consensus.Emitter{
+		EventBus:    eb,
+		RPCBus:      rb,
+		Keys:        k,
+		TimerLength: config.ConsensusTimeOut,
+		Detector:    equivocation.NewDetector(signer.NewLocal(k), db),
+		Clock:       nodeClock{Clock: clock, id: id},
+		Byzantine:   b,
+		Timeouts:    t,
+	}
+
+	lp := loop.New(e, &pk)
+
+	// Every node needs its own copy, as the chain updates the provisioners
+	// while accepting blocks.
+	provisioners := p.Copy()
+	proxy := transactions.MockProxy{
+		E: executor{&transactions.PermissiveExecutor{P: &provisioners}},
+	}
+
+	c, err := chain.New(ctx, db, eb, rb, l, l, nil, proxy, lp)
+	if err != nil {
+		return nil, err
+	}
+
+	eb.Subscribe(topics.AcceptedBlock, eventbus.NewCallbackListener(func(m message.Message) {
+		onAccept(id, m.Payload().(block.Block))
+	}))
+
+	return &node{
+		id:       id,
+		eventBus: eb,
+		rpcBus:   rb,
+		db:       db,
+		chain:    c,
+		loop:     lp,
+		broker:   responding.NewCandidateBroker(db),
+	}, nil
+}
+
+// executor is a transactions.PermissiveExecutor which does not simulate the
+// duration of the state transitions. Waiting in real time would only slow the
+// simulation down, as the clock does not move forward while a node is busy.
+type executor struct {
+	*transactions.PermissiveExecutor
+}
+
+func (executor) VerifyStateTransition(context.Context, []transactions.ContractCall, uint64, uint64) error {
+	return nil
+}
+
+func (executor) ExecuteStateTransition(ctx context.Context, cc []transactions.ContractCall, blockGasLimit uint64, blockHeight uint64) ([]transactions.ContractCall, []byte, error) {
+	return cc, make([]byte, 32), nil
+}
+
+// serveEmptyMempool answers the block generator with an empty set of
+// transactions.
+func serveEmptyMempool(rb *rpcbus.RPCBus) error {
+	c := make(chan rpcbus.Request, 20)
+	if err := rb.Register(topics.GetMempoolTxsBySize, c); err != nil {
+		return err
+	}
+
+	go func() {
+		for r := range c {
+			r.RespChan <- rpcbus.Response{
+				Resp: make([]transactions.ContractCall, 0),
+				Err:  nil,
+			}
+		}
+	}()
+
+	return nil
+}
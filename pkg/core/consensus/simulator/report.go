@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+)
+
+// RoundReport describes the outcome of a round.
+type RoundReport struct {
+	Round uint64
+	// Steps is the number of steps it took to reach agreement, according to
+	// the first block accepted for the round.
+	Steps uint8
+	// Accepted is the number of nodes which accepted a block for the round.
+	Accepted int
+	// Hashes lists the distinct blocks accepted for the round. More than one
+	// means that the nodes forked.
+	Hashes [][]byte
+	// Time is the virtual time, since the start of the simulation, at which
+	// the first node accepted a block for the round.
+	Time time.Duration
+}
+
+// Report is the outcome of a simulation.
+type Report struct {
+	Rounds []RoundReport
+	// Heights lists the height reached by each node.
+	Heights []uint64
+	// Forks is the number of rounds for which the nodes accepted different
+	// blocks.
+	Forks int
+	// Duration is the virtual duration of the simulation.
+	Duration time.Duration
+	// Completed is true if every node reached Config.Rounds.
+	Completed bool
+	// Sent is the number of messages sent over the network, and Lost the
+	// number of those lost or blocked by a partition.
+	Sent int
+	Lost int
+}
+
+func (s *Simulator) report(net *network) *Report {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r := &Report{
+		Heights:  make([]uint64, len(s.accepted)),
+		Duration: s.clock.Now().Sub(s.start),
+	}
+
+	rounds := make(map[uint64]*RoundReport)
+
+	var highest uint64
+
+	for id, blocks := range s.accepted {
+		for height, a := range blocks {
+			if height > r.Heights[id] {
+				r.Heights[id] = height
+			}
+
+			if height > highest {
+				highest = height
+			}
+
+			rr, ok := rounds[height]
+			if !ok {
+				rr = &RoundReport{Round: height, Steps: a.steps, Time: a.at}
+				rounds[height] = rr
+			}
+
+			// Keep the earliest acceptance
+			if a.at < rr.Time {
+				rr.Steps, rr.Time = a.steps, a.at
+			}
+
+			rr.Accepted++
+			rr.Hashes = appendHash(rr.Hashes, a.hash)
+		}
+	}
+
+	for height := uint64(1); height <= highest; height++ {
+		rr, ok := rounds[height]
+		if !ok {
+			continue
+		}
+
+		if len(rr.Hashes) > 1 {
+			r.Forks++
+		}
+
+		r.Rounds = append(r.Rounds, *rr)
+	}
+
+	r.Completed = true
+
+	for _, h := range r.Heights {
+		if h < s.cfg.Rounds {
+			r.Completed = false
+		}
+	}
+
+	net.lock.Lock()
+	r.Sent, r.Lost = net.sent, net.lost
+	net.lock.Unlock()
+
+	return r
+}
+
+func appendHash(hashes [][]byte, hash []byte) [][]byte {
+	for _, h := range hashes {
+		if bytes.Equal(h, hash) {
+			return hashes
+		}
+	}
+
+	return append(hashes, hash)
+}
+
+// Print writes a summary of the Report.
+func (r *Report) Print(w io.Writer) error {
+	for _, rr := range r.Rounds {
+		hashes := make([]string, len(rr.Hashes))
+		for i, h := range rr.Hashes {
+			hashes[i] = util.StringifyBytes(h)
+		}
+
+		if _, err := fmt.Fprintf(w, "round %d: steps %d, accepted by %d, at %s, blocks %v\n", rr.Round, rr.Steps, rr.Accepted, rr.Time, hashes); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "heights %v, forks %d, duration %s, completed %t, messages %d (%d lost)\n", r.Heights, r.Forks, r.Duration, r.Completed, r.Sent, r.Lost)
+	return err
+}
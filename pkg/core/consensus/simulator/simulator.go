@@ -0,0 +1,262 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package simulator runs several consensus nodes in a single process, over a
+// simulated network driven by a virtual clock. Latency, loss and partitions
+// are drawn from a seeded RNG, so that a simulation can be replayed.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/byzantine"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "simulator")
+
+// running serializes the simulations of a process. A simulation settles when
+// every other goroutine is blocked, which never happens while another one is
+// running.
+var running sync.Mutex
+
+const (
+	defaultMaxTime = 10 * time.Minute
+
+	// stake given to every node.
+	stake = 100000
+)
+
+// Config of a simulation.
+type Config struct {
+	// Nodes is the number of nodes. They are all provisioners, with the same
+	// stake.
+	Nodes int
+	// Rounds is the height the nodes should reach.
+	Rounds uint64
+	// Seed seeds the RNG drawing the network conditions.
+	Seed int64
+
+	// MinLatency and MaxLatency bound the latency of the links.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// Loss is the probability, between 0 and 1, for a message to be lost.
+	Loss float64
+	// Partitions split the network for a period of time.
+	Partitions []Partition
+
+	// Byzantine lists the misbehaving nodes, by index.
+	Byzantine map[int]byzantine.Behaviour
+
+	// MinTimeOut and MaxTimeOut bound the adaptive step timeouts. If
+	// MaxTimeOut is zero, the nodes use fixed timeouts.
+	MinTimeOut time.Duration
+	MaxTimeOut time.Duration
+
+	// MaxTime bounds the virtual duration of the simulation. Defaults to 10
+	// minutes.
+	MaxTime time.Duration
+
+	// Keys of the nodes. If nil, random keys are generated. As the keys
+	// determine the committees, they must be provided for a simulation to be
+	// reproducible.
+	Keys []key.Keys
+}
+
+// Simulator runs a simulation. A Simulator can only be run once.
+type Simulator struct {
+	cfg   Config
+	clock *Clock
+	start time.Time
+	p     user.Provisioners
+
+	lock sync.Mutex
+	// accepted lists the blocks accepted by each node, by height.
+	accepted []map[uint64]acceptance
+}
+
+type acceptance struct {
+	hash  []byte
+	steps uint8
+	at    time.Duration
+}
+
+// New creates a Simulator.
+func New(cfg Config) (*Simulator, error) {
+	if cfg.Nodes <= 0 {
+		return nil, errors.New("simulator: at least one node is needed")
+	}
+
+	if cfg.Keys != nil && len(cfg.Keys) != cfg.Nodes {
+		return nil, errors.New("simulator: the number of keys does not match the number of nodes")
+	}
+
+	if cfg.MaxLatency < cfg.MinLatency {
+		return nil, errors.New("simulator: the maximum latency is lower than the minimum")
+	}
+
+	if cfg.MaxTime == 0 {
+		cfg.MaxTime = defaultMaxTime
+	}
+
+	if cfg.Keys == nil {
+		cfg.Keys = make([]key.Keys, cfg.Nodes)
+		for i := range cfg.Keys {
+			cfg.Keys[i] = key.NewRandKeys()
+		}
+	}
+
+	p := user.NewProvisioners()
+	for _, k := range cfg.Keys {
+		if err := p.Add(k.BLSPubKey, stake, 0, 250000); err != nil {
+			return nil, err
+		}
+	}
+
+	// Blocks can not be older than the genesis
+	genesis := config.DecodeGenesis()
+	start := time.Unix(genesis.Header.Timestamp, 0)
+
+	accepted := make([]map[uint64]acceptance, cfg.Nodes)
+	for i := range accepted {
+		accepted[i] = make(map[uint64]acceptance)
+	}
+
+	return &Simulator{
+		cfg:      cfg,
+		clock:    NewClock(start),
+		start:    start,
+		p:        *p,
+		accepted: accepted,
+	}, nil
+}
+
+// Clock returns the virtual clock of the simulation.
+func (s *Simulator) Clock() *Clock {
+	return s.clock
+}
+
+// Run the simulation, until every node reaches Config.Rounds, nothing is
+// left to happen, or Config.MaxTime has elapsed.
+func (s *Simulator) Run(ctx context.Context) (*Report, error) {
+	running.Lock()
+	defer running.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	nodes := make([]*node, s.cfg.Nodes)
+
+	for i := range nodes {
+		var b *byzantine.Faulty
+		if behaviour, ok := s.cfg.Byzantine[i]; ok {
+			b = byzantine.New(behaviour)
+		}
+
+		var t *consensus.Timeouts
+		if s.cfg.MaxTimeOut > 0 {
+			t = consensus.NewTimeouts(config.ConsensusTimeOut, s.cfg.MinTimeOut, s.cfg.MaxTimeOut)
+		}
+
+		n, err := newNode(ctx, i, s.clock, s.cfg.Keys[i], s.p, b, t, s.accept)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[i] = n
+	}
+
+	net := newNetwork(s.cfg, s.clock, nodes)
+
+	for _, n := range nodes {
+		id := n.id
+
+		n.eventBus.Subscribe(topics.Gossip, eventbus.NewCallbackListener(func(m message.Message) {
+			net.gossip(id, m)
+		}))
+	}
+
+	for _, n := range nodes {
+		if err := n.chain.RestartConsensus(); err != nil {
+			return nil, err
+		}
+	}
+
+	effective := true
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Duplicated deliveries leave the nodes untouched, and need no
+		// settling.
+		if effective {
+			s.settle(ctx)
+		}
+
+		if s.reached(s.cfg.Rounds) {
+			break
+		}
+
+		events, ok := s.clock.advance()
+		if !ok || s.clock.Now().Sub(s.start) > s.cfg.MaxTime {
+			break
+		}
+
+		effective = false
+
+		for _, ev := range events {
+			if ev.fn() {
+				effective = true
+			}
+		}
+	}
+
+	return s.report(net), nil
+}
+
+// accept records a block accepted by a node.
+func (s *Simulator) accept(id int, blk block.Block) {
+	var steps uint8
+	if blk.Header.Certificate != nil {
+		steps = blk.Header.Certificate.Step
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.accepted[id][blk.Header.Height] = acceptance{
+		hash:  blk.Header.Hash,
+		steps: steps,
+		at:    s.clock.Now().Sub(s.start),
+	}
+}
+
+// reached returns true if every node accepted a block at the given height.
+func (s *Simulator) reached(height uint64) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, blocks := range s.accepted {
+		if _, ok := blocks[height]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,277 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/byzantine"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestClockOrdering(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClock(time.Unix(0, 0))
+
+	var order []string
+
+	record := func(s string) func() bool {
+		return func() bool {
+			order = append(order, s)
+			return true
+		}
+	}
+
+	c.Schedule(2*time.Second, "b", record("late"))
+	c.Schedule(time.Second, "b", record("b"))
+	c.Schedule(time.Second, "a", record("a"))
+
+	events, ok := c.advance()
+	assert.True(ok)
+	assert.Equal(time.Unix(1, 0), c.Now())
+
+	for _, ev := range events {
+		ev.fn()
+	}
+
+	// Simultaneous events run by key
+	assert.Equal([]string{"a", "b"}, order)
+
+	events, ok = c.advance()
+	assert.True(ok)
+	assert.Len(events, 1)
+	assert.Equal(time.Unix(2, 0), c.Now())
+
+	_, ok = c.advance()
+	assert.False(ok)
+}
+
+func TestClockTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	c := nodeClock{Clock: NewClock(time.Unix(0, 0))}
+
+	ctx, cancel := c.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// The deadline only passes with the virtual time
+	assert.NoError(ctx.Err())
+
+	events, ok := c.advance()
+	assert.True(ok)
+	assert.True(events[0].fn())
+	assert.Equal(context.Canceled, ctx.Err())
+
+	// A canceled context is left untouched
+	ctx, cancel = c.WithTimeout(context.Background(), time.Second)
+	cancel()
+
+	events, ok = c.advance()
+	assert.True(ok)
+	assert.False(events[0].fn())
+}
+
+func TestIdle(t *testing.T) {
+	assert := assert.New(t)
+
+	running := "goroutine 1 [running]:\nmain.main()"
+	blocked := "goroutine 7 [select, 2 minutes]:\nmain.loop()"
+	waiting := "goroutine 9 [chan receive]:\nmain.wait()"
+	runnable := "goroutine 8 [runnable]:\nmain.work()"
+	cgo := "goroutine 10 [syscall]:\nmain.verify()"
+
+	assert.True(idle([]byte(running + "\n\n" + blocked + "\n\n" + waiting)))
+	assert.False(idle([]byte(running + "\n\n" + blocked + "\n\n" + runnable)))
+	assert.False(idle([]byte(running + "\n\n" + cgo)))
+
+	// Once nothing else runs, the nodes are settled
+	s := &Simulator{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		<-done
+	}()
+
+	s.settle(ctx)
+	assert.NoError(ctx.Err())
+	close(done)
+}
+
+func TestSimulation(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := New(Config{
+		Nodes:      4,
+		Rounds:     3,
+		Seed:       1,
+		MinLatency: 10 * time.Millisecond,
+		MaxLatency: 100 * time.Millisecond,
+	})
+	assert.NoError(err)
+
+	r, err := s.Run(context.Background())
+	assert.NoError(err)
+
+	assert.True(r.Completed)
+	assert.Zero(r.Forks)
+	assert.Len(r.Rounds, 3)
+
+	for i, rr := range r.Rounds {
+		assert.Equal(uint64(i+1), rr.Round)
+		assert.Equal(4, rr.Accepted)
+		assert.NotZero(rr.Steps)
+	}
+}
+
+func TestReproducible(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := make([]key.Keys, 4)
+	for i := range keys {
+		keys[i] = key.NewRandKeys()
+	}
+
+	cfg := Config{
+		Nodes:      4,
+		Rounds:     2,
+		Seed:       42,
+		MinLatency: 10 * time.Millisecond,
+		MaxLatency: 200 * time.Millisecond,
+		Loss:       0.05,
+		Keys:       keys,
+	}
+
+	run := func() *Report {
+		s, err := New(cfg)
+		assert.NoError(err)
+
+		r, err := s.Run(context.Background())
+		assert.NoError(err)
+		return r
+	}
+
+	first, second := run(), run()
+
+	assert.Equal(len(first.Rounds), len(second.Rounds))
+
+	for i := range first.Rounds {
+		assert.Equal(first.Rounds[i].Steps, second.Rounds[i].Steps)
+		assert.Equal(first.Rounds[i].Time, second.Rounds[i].Time)
+	}
+
+	assert.Equal(first.Sent, second.Sent)
+	assert.Equal(first.Lost, second.Lost)
+}
+
+func TestPartition(t *testing.T) {
+	assert := assert.New(t)
+
+	// Neither side has a quorum until the partition heals
+	heal := 30 * time.Second
+
+	s, err := New(Config{
+		Nodes:      4,
+		Rounds:     1,
+		Seed:       7,
+		MinLatency: 10 * time.Millisecond,
+		MaxLatency: 50 * time.Millisecond,
+		Partitions: []Partition{{
+			Start:  0,
+			End:    heal,
+			Groups: [][]int{{0, 1}, {2, 3}},
+		}},
+	})
+	assert.NoError(err)
+
+	r, err := s.Run(context.Background())
+	assert.NoError(err)
+
+	assert.True(r.Completed)
+	assert.NotZero(r.Lost)
+	assert.True(r.Rounds[0].Time >= heal)
+	assert.True(r.Rounds[0].Steps > 3)
+}
+
+// Consensus should finalize with one faulty provisioner out of four, albeit
+// possibly in more steps.
+func TestByzantine(t *testing.T) {
+	behaviours := map[string]byzantine.Behaviour{
+		"withhold":           {Faults: byzantine.Withhold},
+		"randomvote":         {Faults: byzantine.RandomVote},
+		"latecandidate":      {Faults: byzantine.LateCandidate, CandidateDelay: 10 * time.Second},
+		"equivocate":         {Faults: byzantine.Equivocate},
+		"floodaggragreement": {Faults: byzantine.FloodAggrAgreement, Flood: 5},
+	}
+
+	for name, b := range behaviours {
+		b := b
+
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			s, err := New(Config{
+				Nodes:      4,
+				Rounds:     2,
+				Seed:       3,
+				MinLatency: 10 * time.Millisecond,
+				MaxLatency: 100 * time.Millisecond,
+				Byzantine:  map[int]byzantine.Behaviour{0: b},
+			})
+			assert.NoError(err)
+
+			r, err := s.Run(context.Background())
+			assert.NoError(err)
+
+			assert.True(r.Completed)
+			assert.Zero(r.Forks)
+
+			for _, rr := range r.Rounds {
+				t.Logf("round %d: %d steps, %s", rr.Round, rr.Steps, rr.Time)
+			}
+		})
+	}
+}
+
+func TestAdaptiveTimeouts(t *testing.T) {
+	assert := assert.New(t)
+
+	heal := 30 * time.Second
+
+	s, err := New(Config{
+		Nodes:      4,
+		Rounds:     3,
+		Seed:       7,
+		MinLatency: 10 * time.Millisecond,
+		MaxLatency: 50 * time.Millisecond,
+		Partitions: []Partition{{
+			Start:  0,
+			End:    heal,
+			Groups: [][]int{{0, 1}, {2, 3}},
+		}},
+		MinTimeOut: 500 * time.Millisecond,
+		MaxTimeOut: 20 * time.Second,
+	})
+	assert.NoError(err)
+
+	r, err := s.Run(context.Background())
+	assert.NoError(err)
+
+	assert.True(r.Completed)
+	assert.Zero(r.Forks)
+	assert.True(r.Rounds[0].Time >= heal)
+
+	for _, rr := range r.Rounds {
+		t.Logf("round %d: %d steps, %s", rr.Round, rr.Steps, rr.Time)
+	}
+}
@@ -0,0 +1,253 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package stakeautomaton
+
+import (
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+)
+
+// Kinds of Action.
+const (
+	// ActionIdle is planned while the automation is not enabled.
+	ActionIdle = "idle"
+	// ActionStake sends a stake transaction.
+	ActionStake = "stake"
+	// ActionWait postpones the next stake.
+	ActionWait = "wait"
+	// ActionHalt suspends the automation until the balance is above the
+	// threshold.
+	ActionHalt = "halt"
+)
+
+// How many blocks away from expiration the transactions should be
+// renewed, unless configured otherwise.
+const renewalOffset = 100
+
+// How many blocks a stake transaction is given to show up among the
+// provisioners, before being sent again.
+const confirmationWindow = 20
+
+// Policy drives the stakes of the StakeAutomaton. Amounts are expressed in
+// atomic units.
+type Policy struct {
+	// TargetAmount is the amount kept at stake.
+	TargetAmount uint64
+	// Reserve is the part of the balance which is never staked.
+	Reserve uint64
+	// StopBelow halts the automation while the balance is below it.
+	StopBelow uint64
+	// RequireSync only stakes once the node is fully synced.
+	RequireSync bool
+	// RenewalOffset is how many blocks before their expiry the stakes are
+	// renewed.
+	RenewalOffset uint64
+	// LockTime is the amount of blocks the stakes are locked up for.
+	LockTime uint64
+}
+
+// PolicyFromConfig returns the Policy set in the consensus configuration.
+func PolicyFromConfig() Policy {
+	settings := config.Get().Consensus
+	conf := settings.StakeAutomaton
+
+	p := Policy{
+		TargetAmount:  conf.TargetAmount * wallet.DUSK,
+		Reserve:       conf.Reserve * wallet.DUSK,
+		StopBelow:     conf.StopBelow * wallet.DUSK,
+		RequireSync:   conf.RequireSync,
+		RenewalOffset: conf.RenewalOffset,
+		LockTime:      settings.DefaultLockTime,
+	}
+
+	if p.TargetAmount == 0 {
+		p.TargetAmount = settings.DefaultAmount * wallet.DUSK
+	}
+
+	if p.RenewalOffset == 0 {
+		p.RenewalOffset = renewalOffset
+	}
+
+	if p.LockTime > config.MaxLockTime {
+		l.Warnf("default locktime exceeds maximum (%v) - defaulting to %v", p.LockTime, config.MaxLockTime)
+		p.LockTime = config.MaxLockTime
+	}
+
+	return p
+}
+
+// Action planned by the StakeAutomaton.
+type Action struct {
+	Kind string `json:"kind"`
+	// Height at which the action is planned.
+	Height uint64 `json:"height"`
+	// Amount to stake, in atomic units.
+	Amount uint64 `json:"amount,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// plan the action to take at height, according to the policy.
+func (m *StakeAutomaton) plan(height uint64) Action {
+	if !m.state.Running {
+		return Action{Kind: ActionIdle, Height: height, Reason: "stake automation is not enabled"}
+	}
+
+	stakes, err := m.stakes(height)
+	if err != nil {
+		return Action{Kind: ActionWait, Height: height + 1, Reason: "stakes unknown: " + err.Error()}
+	}
+
+	sent := m.state.Sent
+	if sent.Height > 0 && height < sent.Height+confirmationWindow && !includes(stakes, sent) {
+		return Action{
+			Kind:   ActionWait,
+			Height: sent.Height + confirmationWindow,
+			Reason: fmt.Sprintf("stake sent at height %d is not confirmed yet", sent.Height),
+		}
+	}
+
+	// Only the stakes lasting beyond the renewal offset count towards the
+	// target
+	var lasting, expiry uint64
+
+	for _, s := range stakes {
+		if s.EndHeight <= height+m.policy.RenewalOffset {
+			continue
+		}
+
+		lasting += s.Amount
+
+		if expiry == 0 || s.EndHeight < expiry {
+			expiry = s.EndHeight
+		}
+	}
+
+	if lasting >= m.policy.TargetAmount {
+		renewal := expiry - m.policy.RenewalOffset
+
+		return Action{
+			Kind:   ActionWait,
+			Height: renewal,
+			Reason: fmt.Sprintf("%d at stake until height %d, renewal planned at height %d", lasting, expiry, renewal),
+		}
+	}
+
+	amount := m.policy.TargetAmount - lasting
+
+	if m.env.Balance != nil && (m.policy.StopBelow > 0 || m.policy.Reserve > 0) {
+		balance, err := m.env.Balance()
+		if err != nil {
+			return Action{Kind: ActionWait, Height: height + 1, Reason: "balance unknown: " + err.Error()}
+		}
+
+		if balance < m.policy.StopBelow {
+			return Action{
+				Kind:   ActionHalt,
+				Height: height + 1,
+				Reason: fmt.Sprintf("balance of %d below the threshold of %d", balance, m.policy.StopBelow),
+			}
+		}
+
+		if balance <= m.policy.Reserve {
+			return Action{
+				Kind:   ActionWait,
+				Height: height + 1,
+				Reason: fmt.Sprintf("balance of %d within the reserve of %d", balance, m.policy.Reserve),
+			}
+		}
+
+		if available := balance - m.policy.Reserve; amount > available {
+			amount = available
+		}
+	}
+
+	if m.policy.RequireSync && m.env.SyncProgress != nil {
+		if progress := m.env.SyncProgress(); progress < 100 {
+			return Action{
+				Kind:   ActionWait,
+				Height: height + 1,
+				Reason: fmt.Sprintf("node is not synced (%.2f%%)", progress),
+			}
+		}
+	}
+
+	return Action{
+		Kind:   ActionStake,
+		Height: height,
+		Amount: amount,
+		Reason: fmt.Sprintf("%d at stake, below the target of %d", lasting, m.policy.TargetAmount),
+	}
+}
+
+// stakes returns the stakes of the provisioner which have not expired at
+// height.
+func (m *StakeAutomaton) stakes(height uint64) ([]user.Stake, error) {
+	sent := m.state.Sent
+
+	if m.env.DB == nil {
+		// Without the chain, the stake sent last is assumed to be accepted
+		if sent.Height == 0 || sent.EndHeight < height {
+			return nil, nil
+		}
+
+		return []user.Stake{{Amount: sent.Amount, StartHeight: sent.Height, EndHeight: sent.EndHeight}}, nil
+	}
+
+	// The provisioners at height are the ones in effect after the previous
+	// block. No block precedes the genesis one: the genesis provisioners are
+	// in effect.
+	prev := height
+	if prev > 0 {
+		prev--
+	}
+
+	var p *user.Provisioners
+
+	err := m.env.DB.View(func(t database.Transaction) error {
+		var err error
+		p, err = t.FetchProvisioners(prev)
+		return err
+	})
+	if err == database.ErrProvisionersNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	member := p.GetMember(m.env.PubKeyBLS)
+	if member == nil {
+		return nil, nil
+	}
+
+	stakes := make([]user.Stake, 0, len(member.Stakes))
+
+	for _, s := range member.Stakes {
+		if s.EndHeight >= height {
+			stakes = append(stakes, s)
+		}
+	}
+
+	return stakes, nil
+}
+
+// includes checks if a stake created by the transaction sent is among the
+// stakes.
+func includes(stakes []user.Stake, sent Stake) bool {
+	for _, s := range stakes {
+		if s.StartHeight >= sent.Height {
+			return true
+		}
+	}
+
+	return false
+}
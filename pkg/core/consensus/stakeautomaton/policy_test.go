@@ -0,0 +1,201 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package stakeautomaton
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakeautomaton/stakeautomatonpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	data []byte
+}
+
+func (s *memStore) PutStakeAutomatonState(data []byte) error {
+	s.data = data
+	return nil
+}
+
+func (s *memStore) FetchStakeAutomatonState() ([]byte, error) {
+	return s.data, nil
+}
+
+func testPolicy() Policy {
+	return Policy{
+		TargetAmount:  1000,
+		Reserve:       100,
+		StopBelow:     50,
+		RequireSync:   true,
+		RenewalOffset: 10,
+		LockTime:      500,
+	}
+}
+
+func balance(amount uint64) func() (uint64, error) {
+	return func() (uint64, error) {
+		return amount, nil
+	}
+}
+
+func TestPlan(t *testing.T) {
+	synced := func() float64 { return 100 }
+
+	tests := []struct {
+		name   string
+		env    Env
+		sent   Stake
+		height uint64
+		kind   string
+		amount uint64
+	}{
+		{"top-up", Env{Balance: balance(5000), SyncProgress: synced}, Stake{}, 1, ActionStake, 1000},
+		{"reserve caps the amount", Env{Balance: balance(600), SyncProgress: synced}, Stake{}, 1, ActionStake, 500},
+		{"balance within the reserve", Env{Balance: balance(80), SyncProgress: synced}, Stake{}, 1, ActionWait, 0},
+		{"balance below the threshold", Env{Balance: balance(20), SyncProgress: synced}, Stake{}, 1, ActionHalt, 0},
+		{"balance unknown", Env{Balance: func() (uint64, error) { return 0, errors.New("no wallet") }}, Stake{}, 1, ActionWait, 0},
+		{"node not synced", Env{SyncProgress: func() float64 { return 99.5 }}, Stake{}, 1, ActionWait, 0},
+		{"target reached", Env{}, Stake{Height: 1, Amount: 1000, EndHeight: 501}, 100, ActionWait, 0},
+		{"renewal", Env{}, Stake{Height: 1, Amount: 1000, EndHeight: 501}, 491, ActionStake, 1000},
+	}
+
+	for _, tt := range tests {
+		m := &StakeAutomaton{
+			env:    tt.env,
+			policy: testPolicy(),
+			state:  state{Running: true, Sent: tt.sent},
+		}
+
+		a := m.plan(tt.height)
+		assert.Equal(t, tt.kind, a.Kind, tt.name)
+		assert.Equal(t, tt.amount, a.Amount, tt.name)
+		assert.NotEmpty(t, a.Reason, tt.name)
+	}
+
+	// The renewal of a stake is planned ahead of its expiry
+	m := &StakeAutomaton{policy: testPolicy(), state: state{Running: true, Sent: Stake{Height: 1, Amount: 1000, EndHeight: 501}}}
+	assert.Equal(t, uint64(491), m.plan(100).Height)
+
+	// Nothing is planned while the automation is not enabled
+	m = &StakeAutomaton{policy: testPolicy()}
+	assert.Equal(t, ActionIdle, m.plan(1).Kind)
+}
+
+func TestPlanFromChain(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	k := key.NewRandKeys()
+
+	p := user.NewProvisioners()
+	assert.NoError(p.Add(k.BLSPubKey, 400, 1, 501))
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreProvisioners(9, p)
+	}))
+
+	m := &StakeAutomaton{
+		env:    Env{DB: db, PubKeyBLS: k.BLSPubKey},
+		policy: testPolicy(),
+		state:  state{Running: true},
+	}
+
+	// The stake on the chain is topped up to the target
+	a := m.plan(10)
+	assert.Equal(ActionStake, a.Kind)
+	assert.Equal(uint64(600), a.Amount)
+
+	// A stake sent is waited for, until it shows up among the provisioners
+	m.state.Sent = Stake{Height: 10, Amount: 600, EndHeight: 510}
+
+	a = m.plan(11)
+	assert.Equal(ActionWait, a.Kind)
+	assert.Equal(uint64(30), a.Height)
+
+	assert.NoError(p.Add(k.BLSPubKey, 600, 12, 512))
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreProvisioners(12, p)
+	}))
+
+	a = m.plan(13)
+	assert.Equal(ActionWait, a.Kind)
+	assert.Equal(uint64(491), a.Height)
+}
+
+func TestPlanAtGenesis(t *testing.T) {
+	assert := assert.New(t)
+
+	_, db := lite.CreateDBConnection()
+	k := key.NewRandKeys()
+
+	// The genesis provisioners, and a later set which does not include the
+	// provisioner anymore
+	genesis := user.NewProvisioners()
+	assert.NoError(genesis.Add(k.BLSPubKey, 400, 0, 500))
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		if err := t.StoreProvisioners(0, genesis); err != nil {
+			return err
+		}
+
+		return t.StoreProvisioners(600, user.NewProvisioners())
+	}))
+
+	m := &StakeAutomaton{
+		env:    Env{DB: db, PubKeyBLS: k.BLSPubKey},
+		policy: testPolicy(),
+		state:  state{Running: true},
+	}
+
+	stakes, err := m.stakes(0)
+	assert.NoError(err)
+	assert.Len(stakes, 1)
+	assert.Equal(uint64(400), stakes[0].Amount)
+
+	// The genesis stake is topped up to the target
+	a := m.plan(0)
+	assert.Equal(ActionStake, a.Kind)
+	assert.Equal(uint64(600), a.Amount)
+}
+
+func TestStatePersistence(t *testing.T) {
+	assert := assert.New(t)
+
+	store := new(memStore)
+	bus := eventbus.New()
+
+	m := New(bus, nil, nil, Env{Store: store})
+	assert.False(m.Status().Running)
+	assert.Equal(ActionIdle, m.Status().Next.Kind)
+
+	m.lock.Lock()
+	m.state = state{Running: true, Sent: Stake{Height: 10, Amount: 1000, EndHeight: 510}}
+	m.save()
+	m.lock.Unlock()
+
+	// The automation resumes after a restart
+	m = New(bus, nil, nil, Env{Store: store})
+	s := m.Status()
+
+	assert.True(s.Running)
+	assert.Equal(uint64(10), s.Height)
+	assert.Equal(&Stake{Height: 10, Amount: 1000, EndHeight: 510}, s.Sent)
+	assert.NotEqual(ActionIdle, s.Next.Kind)
+
+	st, err := m.GetStakeStatus(context.Background(), &stakeautomatonpb.StakeStatusRequest{})
+	assert.NoError(err)
+	assert.True(st.GetRunning())
+	assert.Equal(uint64(510), st.GetSent().GetEndHeight())
+	assert.Equal(s.Next.Kind, st.GetNext().GetKind())
+	assert.NotEmpty(st.GetNext().GetReason())
+}
@@ -8,13 +8,15 @@ package stakeautomaton
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakeautomaton/stakeautomatonpb"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
@@ -26,36 +28,96 @@ import (
 var l = log.WithField("process", "consensus").
 	WithField("actor", "StakeAutomaton")
 
+// Store persists the state of the StakeAutomaton, e.g. in the wallet
+// database.
+type Store interface {
+	PutStakeAutomatonState([]byte) error
+	FetchStakeAutomatonState() ([]byte, error)
+}
+
+// Env gathers what the policies of a StakeAutomaton rely on. All fields are
+// optional.
+type Env struct {
+	// Store persists the state across restarts.
+	Store Store
+	// DB is the chain database, where the stakes of PubKeyBLS are looked up.
+	// If nil, the stakes sent are assumed to be accepted.
+	DB        database.DB
+	PubKeyBLS []byte
+	// Balance returns the wallet balance, in atomic units. If nil, the
+	// reserve and the threshold are not enforced.
+	Balance func() (uint64, error)
+	// SyncProgress returns the sync progress of the node, in percent. If
+	// nil, the sync is not required.
+	SyncProgress func() float64
+}
+
+// Stake sent by the StakeAutomaton.
+type Stake struct {
+	Height    uint64 `json:"height"`
+	Amount    uint64 `json:"amount"`
+	EndHeight uint64 `json:"end_height"`
+}
+
+// state of the StakeAutomaton, persisted in the Store.
+type state struct {
+	Running bool  `json:"running"`
+	Sent    Stake `json:"sent"`
+}
+
+// Status of the StakeAutomaton.
+type Status struct {
+	Running bool   `json:"running"`
+	Height  uint64 `json:"height"`
+	// Sent is the last stake sent, if any.
+	Sent *Stake `json:"sent,omitempty"`
+	// Next is the next planned action.
+	Next Action `json:"next"`
+}
+
 // StakeAutomaton is a process that keeps note of when certain consensus transactions
 // expire, and makes sure the node remains within the bidlist/committee, when those
-// transactions are close to expiring.
+// transactions are close to expiring. It follows a Policy, and its state
+// survives restarts.
 type StakeAutomaton struct {
 	eventBroker eventbus.Broker
 	rpcBus      *rpcbus.RPCBus
 	blockChan   <-chan block.Block
 
-	height         uint64
-	stakeEndHeight uint64
-	running        bool
-}
+	env    Env
+	policy Policy
 
-// How many blocks away from expiration the transactions should be
-// renewed.
-const renewalOffset = 100
+	lock   sync.RWMutex
+	state  state
+	height uint64
+	next   Action
+}
 
 // New creates a new instance of StakeAutomaton that is used to automate the
 // resending of stakes and alleviate the burden for a user to having to
-// manually manage restaking.
-func New(eventBroker eventbus.Broker, rpcBus *rpcbus.RPCBus, srv *grpc.Server) *StakeAutomaton {
+// manually manage restaking. The automation resumes if it was enabled before
+// a restart.
+func New(eventBroker eventbus.Broker, rpcBus *rpcbus.RPCBus, srv *grpc.Server, env Env) *StakeAutomaton {
 	a := &StakeAutomaton{
-		eventBroker:    eventBroker,
-		rpcBus:         rpcBus,
-		stakeEndHeight: 1,
-		running:        false,
+		eventBroker: eventBroker,
+		rpcBus:      rpcBus,
+		env:         env,
+		policy:      PolicyFromConfig(),
+	}
+
+	if err := a.load(); err != nil {
+		l.WithError(err).Error("could not load the stake automation state")
+	}
+
+	a.next = a.plan(a.height)
+
+	if a.state.Running {
+		a.start()
 	}
 
 	if srv != nil {
 		node.RegisterProvisionerServer(srv, a)
+		stakeautomatonpb.RegisterStakeAutomatonServer(srv, a)
 	}
 
 	return a
@@ -63,47 +125,115 @@ func New(eventBroker eventbus.Broker, rpcBus *rpcbus.RPCBus, srv *grpc.Server) *
 
 // AutomateStakes will automate the sending of stakes.
 func (m *StakeAutomaton) AutomateStakes(ctx context.Context, e *node.EmptyRequest) (*node.GenericResponse, error) {
-	if !m.running {
-		// We only initialize the `roundChan` here so that we don't clog the channel with
-		// blocks while the maintainer is not actually running yet.
-		m.blockChan, _ = consensus.InitAcceptedBlockUpdate(m.eventBroker)
-		m.running = true
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
-		go m.Listen()
+	if !m.state.Running {
+		m.state.Running = true
+		m.save()
+
+		m.start()
 	}
 
 	return &node.GenericResponse{Response: "stake transactions are now being automated"}, nil
 }
 
+// GetStakeStatus returns the next action planned by the automation, and its
+// reason.
+func (m *StakeAutomaton) GetStakeStatus(ctx context.Context, req *stakeautomatonpb.StakeStatusRequest) (*stakeautomatonpb.StakeStatus, error) {
+	st := m.Status()
+
+	resp := &stakeautomatonpb.StakeStatus{
+		Running: st.Running,
+		Height:  st.Height,
+		Next: &stakeautomatonpb.StakeAction{
+			Kind:   st.Next.Kind,
+			Height: st.Next.Height,
+			Amount: st.Next.Amount,
+			Reason: st.Next.Reason,
+		},
+	}
+
+	if st.Sent != nil {
+		resp.Sent = &stakeautomatonpb.Stake{
+			Height:    st.Sent.Height,
+			Amount:    st.Sent.Amount,
+			EndHeight: st.Sent.EndHeight,
+		}
+	}
+
+	return resp, nil
+}
+
+// Status returns the Status of the automation.
+func (m *StakeAutomaton) Status() Status {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	s := Status{
+		Running: m.state.Running,
+		Height:  m.height,
+		Next:    m.next,
+	}
+
+	if m.state.Sent.Height > 0 {
+		sent := m.state.Sent
+		s.Sent = &sent
+	}
+
+	return s
+}
+
+// start listening to the accepted blocks. It must be called with the lock
+// held.
+func (m *StakeAutomaton) start() {
+	// We only initialize the `blockChan` here so that we don't clog the channel with
+	// blocks while the maintainer is not actually running yet.
+	m.blockChan, _ = consensus.InitAcceptedBlockUpdate(m.eventBroker)
+	m.next = m.plan(m.height)
+
+	go m.Listen()
+}
+
 // Listen to round updates and takes the proper decision Stake-wise.
 func (m *StakeAutomaton) Listen() {
 	for blk := range m.blockChan {
+		m.lock.Lock()
+
 		m.height = blk.Header.Height + 1
+		m.next = m.plan(m.height)
+		action := m.next
+
+		m.lock.Unlock()
 
-		if m.height+renewalOffset >= m.stakeEndHeight {
-			if err := m.sendStake(); err != nil {
-				l.WithError(err).Error("could not send stake tx")
-				continue
-			}
+		if action.Kind != ActionStake {
+			l.WithFields(log.Fields{
+				"height": action.Height,
+				"action": action.Kind,
+				"reason": action.Reason,
+			}).Trace("Stake postponed")
+
+			continue
 		}
-	}
-}
 
-func (m *StakeAutomaton) sendStake() error {
-	amount, lockTime := m.getTxSettings()
-	if amount == 0 || lockTime == 0 {
-		return fmt.Errorf("invalid settings: amount: %v / locktime: %v", amount, lockTime)
+		if err := m.sendStake(action); err != nil {
+			l.WithError(err).Error("could not send stake tx")
+			continue
+		}
 	}
+}
 
+func (m *StakeAutomaton) sendStake(action Action) error {
 	l.WithFields(log.Fields{
-		"amount":   amount,
-		"locktime": lockTime,
+		"amount":   action.Amount,
+		"locktime": m.policy.LockTime,
+		"reason":   action.Reason,
 	}).Trace("Sending stake tx")
 
 	req := &node.StakeRequest{
-		Amount:   amount,
+		Amount:   action.Amount,
 		Fee:      config.MinFee,
-		Locktime: lockTime,
+		Locktime: m.policy.LockTime,
 	}
 
 	timeoutSendStakeTX := time.Duration(config.Get().Timeout.TimeoutSendStakeTX) * time.Second
@@ -111,29 +241,59 @@ func (m *StakeAutomaton) sendStake() error {
 	_, err := m.rpcBus.Call(topics.SendStakeTx, rpcbus.NewRequest(req), timeoutSendStakeTX)
 	if err != nil {
 		l.WithFields(log.Fields{
-			"amount":   amount,
-			"locktime": lockTime,
+			"amount":   action.Amount,
+			"locktime": m.policy.LockTime,
 			"err":      err,
 		}).Error("error sending stake tx")
 		return err
 	}
 
-	m.stakeEndHeight = lockTime + m.height
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.state.Sent = Stake{
+		Height:    action.Height,
+		Amount:    action.Amount,
+		EndHeight: action.Height + m.policy.LockTime,
+	}
+
+	m.save()
+
+	m.next = m.plan(m.height)
 	return nil
 }
 
-func (m *StakeAutomaton) getTxSettings() (uint64, uint64) {
-	settings := config.Get().Consensus
-	amount := settings.DefaultAmount
-	lockTime := settings.DefaultLockTime
+// load the state from the Store, if any.
+func (m *StakeAutomaton) load() error {
+	if m.env.Store == nil {
+		return nil
+	}
 
-	if lockTime > config.MaxLockTime {
-		l.Warnf("default locktime exceeds maximum (%v) - defaulting to %v", lockTime, config.MaxLockTime)
-		lockTime = config.MaxLockTime
+	data, err := m.env.Store.FetchStakeAutomatonState()
+	if err != nil || data == nil {
+		return err
 	}
 
-	// Convert amount from atomic units to whole units of DUSK
-	amount = amount * wallet.DUSK
+	if err := json.Unmarshal(data, &m.state); err != nil {
+		return err
+	}
 
-	return amount, lockTime
+	m.height = m.state.Sent.Height
+	return nil
+}
+
+// save the state in the Store, if any. It must be called with the lock held.
+func (m *StakeAutomaton) save() {
+	if m.env.Store == nil {
+		return
+	}
+
+	data, err := json.Marshal(m.state)
+	if err == nil {
+		err = m.env.Store.PutStakeAutomatonState(data)
+	}
+
+	if err != nil {
+		l.WithError(err).Error("could not save the stake automation state")
+	}
 }
@@ -83,7 +83,7 @@ func setupMaintainerTest(t *testing.T) (*eventbus.EventBus, *rpcbus.RPCBus) {
 	bus := eventbus.New()
 	rpcBus := rpcbus.New()
 
-	m := stakeautomaton.New(bus, rpcBus, nil)
+	m := stakeautomaton.New(bus, rpcBus, nil, stakeautomaton.Env{})
 	_, err := m.AutomateStakes(context.Background(), &node.EmptyRequest{})
 	require.Nil(t, err)
 
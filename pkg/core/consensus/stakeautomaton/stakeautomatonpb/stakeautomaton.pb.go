@@ -0,0 +1,520 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        (unknown)
+// source: stakeautomaton.proto
+
+package stakeautomatonpb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type StakeStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StakeStatusRequest) Reset() {
+	*x = StakeStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stakeautomaton_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StakeStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakeStatusRequest) ProtoMessage() {}
+
+func (x *StakeStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stakeautomaton_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakeStatusRequest.ProtoReflect.Descriptor instead.
+func (*StakeStatusRequest) Descriptor() ([]byte, []int) {
+	return file_stakeautomaton_proto_rawDescGZIP(), []int{0}
+}
+
+// Stake sent by the stake automation.
+type Stake struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height    uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Amount    uint64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	EndHeight uint64 `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+}
+
+func (x *Stake) Reset() {
+	*x = Stake{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stakeautomaton_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stake) ProtoMessage() {}
+
+func (x *Stake) ProtoReflect() protoreflect.Message {
+	mi := &file_stakeautomaton_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stake.ProtoReflect.Descriptor instead.
+func (*Stake) Descriptor() ([]byte, []int) {
+	return file_stakeautomaton_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Stake) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Stake) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Stake) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+// StakeAction planned by the stake automation.
+type StakeAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Height at which the action is planned.
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Amount to stake, in atomic units.
+	Amount uint64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *StakeAction) Reset() {
+	*x = StakeAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stakeautomaton_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StakeAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakeAction) ProtoMessage() {}
+
+func (x *StakeAction) ProtoReflect() protoreflect.Message {
+	mi := &file_stakeautomaton_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakeAction.ProtoReflect.Descriptor instead.
+func (*StakeAction) Descriptor() ([]byte, []int) {
+	return file_stakeautomaton_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StakeAction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *StakeAction) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *StakeAction) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StakeAction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// StakeStatus of the stake automation.
+type StakeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Running bool   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Height  uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Sent is the last stake sent, if any.
+	Sent *Stake `protobuf:"bytes,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	// Next is the next planned action.
+	Next *StakeAction `protobuf:"bytes,4,opt,name=next,proto3" json:"next,omitempty"`
+}
+
+func (x *StakeStatus) Reset() {
+	*x = StakeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stakeautomaton_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StakeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakeStatus) ProtoMessage() {}
+
+func (x *StakeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_stakeautomaton_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakeStatus.ProtoReflect.Descriptor instead.
+func (*StakeStatus) Descriptor() ([]byte, []int) {
+	return file_stakeautomaton_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StakeStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *StakeStatus) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *StakeStatus) GetSent() *Stake {
+	if x != nil {
+		return x.Sent
+	}
+	return nil
+}
+
+func (x *StakeStatus) GetNext() *StakeAction {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+var File_stakeautomaton_proto protoreflect.FileDescriptor
+
+var file_stakeautomaton_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74,
+	0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x56, 0x0a, 0x05,
+	0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0x69, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x9b, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e,
+	0x2e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x04,
+	0x6e, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x74, 0x61,
+	0x6b, 0x65, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x6b,
+	0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x32, 0x65, 0x0a,
+	0x0e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e, 0x12,
+	0x53, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x22, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74,
+	0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74,
+	0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x00, 0x42, 0x5c, 0x5a, 0x5a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x64, 0x75, 0x73, 0x6b, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f,
+	0x64, 0x75, 0x73, 0x6b, 0x2d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73,
+	0x75, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x6f,
+	0x6e, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x6f, 0x6e,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_stakeautomaton_proto_rawDescOnce sync.Once
+	file_stakeautomaton_proto_rawDescData = file_stakeautomaton_proto_rawDesc
+)
+
+func file_stakeautomaton_proto_rawDescGZIP() []byte {
+	file_stakeautomaton_proto_rawDescOnce.Do(func() {
+		file_stakeautomaton_proto_rawDescData = protoimpl.X.CompressGZIP(file_stakeautomaton_proto_rawDescData)
+	})
+	return file_stakeautomaton_proto_rawDescData
+}
+
+var file_stakeautomaton_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stakeautomaton_proto_goTypes = []interface{}{
+	(*StakeStatusRequest)(nil), // 0: stakeautomaton.StakeStatusRequest
+	(*Stake)(nil),              // 1: stakeautomaton.Stake
+	(*StakeAction)(nil),        // 2: stakeautomaton.StakeAction
+	(*StakeStatus)(nil),        // 3: stakeautomaton.StakeStatus
+}
+var file_stakeautomaton_proto_depIdxs = []int32{
+	1, // 0: stakeautomaton.StakeStatus.sent:type_name -> stakeautomaton.Stake
+	2, // 1: stakeautomaton.StakeStatus.next:type_name -> stakeautomaton.StakeAction
+	0, // 2: stakeautomaton.StakeAutomaton.GetStakeStatus:input_type -> stakeautomaton.StakeStatusRequest
+	3, // 3: stakeautomaton.StakeAutomaton.GetStakeStatus:output_type -> stakeautomaton.StakeStatus
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_stakeautomaton_proto_init() }
+func file_stakeautomaton_proto_init() {
+	if File_stakeautomaton_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_stakeautomaton_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StakeStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stakeautomaton_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stakeautomaton_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StakeAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stakeautomaton_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StakeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_stakeautomaton_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stakeautomaton_proto_goTypes,
+		DependencyIndexes: file_stakeautomaton_proto_depIdxs,
+		MessageInfos:      file_stakeautomaton_proto_msgTypes,
+	}.Build()
+	File_stakeautomaton_proto = out.File
+	file_stakeautomaton_proto_rawDesc = nil
+	file_stakeautomaton_proto_goTypes = nil
+	file_stakeautomaton_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// StakeAutomatonClient is the client API for StakeAutomaton service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StakeAutomatonClient interface {
+	// GetStakeStatus returns the next action planned by the stake
+	// automation, and its reason.
+	GetStakeStatus(ctx context.Context, in *StakeStatusRequest, opts ...grpc.CallOption) (*StakeStatus, error)
+}
+
+type stakeAutomatonClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStakeAutomatonClient(cc grpc.ClientConnInterface) StakeAutomatonClient {
+	return &stakeAutomatonClient{cc}
+}
+
+func (c *stakeAutomatonClient) GetStakeStatus(ctx context.Context, in *StakeStatusRequest, opts ...grpc.CallOption) (*StakeStatus, error) {
+	out := new(StakeStatus)
+	err := c.cc.Invoke(ctx, "/stakeautomaton.StakeAutomaton/GetStakeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StakeAutomatonServer is the server API for StakeAutomaton service.
+type StakeAutomatonServer interface {
+	// GetStakeStatus returns the next action planned by the stake
+	// automation, and its reason.
+	GetStakeStatus(context.Context, *StakeStatusRequest) (*StakeStatus, error)
+}
+
+// UnimplementedStakeAutomatonServer can be embedded to have forward compatible implementations.
+type UnimplementedStakeAutomatonServer struct {
+}
+
+func (*UnimplementedStakeAutomatonServer) GetStakeStatus(context.Context, *StakeStatusRequest) (*StakeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStakeStatus not implemented")
+}
+
+func RegisterStakeAutomatonServer(s *grpc.Server, srv StakeAutomatonServer) {
+	s.RegisterService(&_StakeAutomaton_serviceDesc, srv)
+}
+
+func _StakeAutomaton_GetStakeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StakeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeAutomatonServer).GetStakeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stakeautomaton.StakeAutomaton/GetStakeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeAutomatonServer).GetStakeStatus(ctx, req.(*StakeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _StakeAutomaton_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "stakeautomaton.StakeAutomaton",
+	HandlerType: (*StakeAutomatonServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStakeStatus",
+			Handler:    _StakeAutomaton_GetStakeStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stakeautomaton.proto",
+}
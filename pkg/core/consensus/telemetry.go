@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+)
+
+// phases names the steps of an iteration.
+var phases = []string{"selection", "reduction1", "reduction2"}
+
+// Telemetry collects the record of the round run by the consensus loop. The
+// steps report to it what happened within them. All methods are no-ops on a
+// nil Telemetry, and reports for another round than the current one are
+// ignored.
+type Telemetry struct {
+	lock   sync.Mutex
+	record *capi.RoundRecordJSON
+}
+
+// NewTelemetry creates a Telemetry.
+func NewTelemetry() *Telemetry {
+	return &Telemetry{}
+}
+
+// StartRound starts the record of a round.
+func (t *Telemetry) StartRound(round uint64, at time.Time) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.record = &capi.RoundRecordJSON{
+		Round: round,
+		Start: at,
+		Steps: make([]capi.StepRecordJSON, 0),
+	}
+}
+
+// StartStep starts the record of a step.
+func (t *Telemetry) StartStep(round uint64, step uint8, at time.Time) {
+	if t == nil || step == 0 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.record == nil || t.record.Round != round {
+		return
+	}
+
+	t.record.Steps = append(t.record.Steps, capi.StepRecordJSON{
+		Step:  step,
+		Phase: phases[(step-1)%3],
+		Start: at,
+	})
+}
+
+// EndStep ends the record of a step.
+func (t *Telemetry) EndStep(round uint64, step uint8, at time.Time) {
+	t.update(round, step, func(s *capi.StepRecordJSON) {
+		s.End = at
+		s.Duration = at.Sub(s.Start)
+	})
+}
+
+// VoteReceived counts a valid message received from the committee.
+func (t *Telemetry) VoteReceived(round uint64, step uint8) {
+	t.update(round, step, func(s *capi.StepRecordJSON) {
+		s.Votes++
+	})
+}
+
+// Voted records that this node cast its vote, or its candidate.
+func (t *Telemetry) Voted(round uint64, step uint8) {
+	t.update(round, step, func(s *capi.StepRecordJSON) {
+		s.Voted = true
+	})
+}
+
+// Quorum records that the committee reached a quorum.
+func (t *Telemetry) Quorum(round uint64, step uint8) {
+	t.update(round, step, func(s *capi.StepRecordJSON) {
+		s.Quorum = true
+	})
+}
+
+// Candidate records the candidate block selected by a step.
+func (t *Telemetry) Candidate(round uint64, step uint8, hash, generator []byte) {
+	t.update(round, step, func(s *capi.StepRecordJSON) {
+		s.Candidate = hash
+		s.Generator = generator
+	})
+}
+
+// Failed records why a step ended without a result.
+func (t *Telemetry) Failed(round uint64, step uint8, reason string) {
+	t.update(round, step, func(s *capi.StepRecordJSON) {
+		s.Reason = reason
+	})
+}
+
+// EndRound completes and returns the record of a round. The hash and the
+// step of the agreed block are nil and zero if the round failed. It returns
+// nil if the round is not being recorded.
+func (t *Telemetry) EndRound(round uint64, at time.Time, hash []byte, step uint8, err error) *capi.RoundRecordJSON {
+	if t == nil {
+		return nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	r := t.record
+	if r == nil || r.Round != round {
+		return nil
+	}
+
+	t.record = nil
+
+	r.End = at
+	r.Duration = at.Sub(r.Start)
+	r.QuorumStep = step
+	r.Candidate = hash
+
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	for _, s := range r.Steps {
+		r.Voted = r.Voted || s.Voted
+
+		if len(hash) > 0 && len(s.Generator) > 0 && bytes.Equal(s.Candidate, hash) {
+			r.Generator = s.Generator
+		}
+	}
+
+	return r
+}
+
+func (t *Telemetry) update(round uint64, step uint8, fn func(*capi.StepRecordJSON)) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.record == nil || t.record.Round != round {
+		return
+	}
+
+	// The latest record of the step is updated
+	for i := len(t.record.Steps) - 1; i >= 0; i-- {
+		if t.record.Steps[i].Step == step {
+			fn(&t.record.Steps[i])
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryRecordsRound(t *testing.T) {
+	tl := NewTelemetry()
+	start := time.Unix(0, 0)
+	hash, generator := []byte{1}, []byte{2}
+
+	tl.StartRound(1, start)
+
+	tl.StartStep(1, 1, start)
+	tl.VoteReceived(1, 1)
+	tl.Candidate(1, 1, hash, generator)
+	tl.EndStep(1, 1, start.Add(time.Second))
+
+	tl.StartStep(1, 2, start.Add(time.Second))
+	tl.Voted(1, 2)
+	tl.VoteReceived(1, 2)
+	tl.VoteReceived(1, 2)
+	tl.Quorum(1, 2)
+	tl.EndStep(1, 2, start.Add(3*time.Second))
+
+	tl.StartStep(1, 3, start.Add(3*time.Second))
+	tl.Failed(1, 3, "timed out")
+	tl.EndStep(1, 3, start.Add(4*time.Second))
+
+	// Reports of other rounds are ignored
+	tl.Voted(2, 3)
+
+	rec := tl.EndRound(1, start.Add(5*time.Second), hash, 3, nil)
+
+	assert.Equal(t, uint64(1), rec.Round)
+	assert.Equal(t, 5*time.Second, rec.Duration)
+	assert.Equal(t, uint8(3), rec.QuorumStep)
+	assert.Equal(t, generator, rec.Generator)
+	assert.True(t, rec.Voted)
+
+	assert.Len(t, rec.Steps, 3)
+	assert.Equal(t, "reduction1", rec.Steps[1].Phase)
+	assert.Equal(t, 2*time.Second, rec.Steps[1].Duration)
+	assert.Equal(t, 2, rec.Steps[1].Votes)
+	assert.True(t, rec.Steps[1].Quorum)
+	assert.False(t, rec.Steps[2].Voted)
+	assert.Equal(t, "timed out", rec.Steps[2].Reason)
+
+	// The record is handed over once
+	assert.Nil(t, tl.EndRound(1, start, nil, 0, nil))
+}
+
+func TestTelemetryRecordsFailure(t *testing.T) {
+	tl := NewTelemetry()
+
+	tl.StartRound(1, time.Now())
+	rec := tl.EndRound(1, time.Now(), nil, 0, errors.New("canceled"))
+
+	assert.Equal(t, "canceled", rec.Error)
+	assert.Zero(t, rec.QuorumStep)
+
+	// A nil Telemetry records nothing
+	var none *Telemetry
+
+	none.StartRound(1, time.Now())
+	none.StartStep(1, 1, time.Now())
+	assert.Nil(t, none.EndRound(1, time.Now(), nil, 0, nil))
+}
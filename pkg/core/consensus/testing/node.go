@@ -13,7 +13,9 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/equivocation"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
@@ -50,6 +52,7 @@ func newNode(ctx context.Context, assert *assert.Assertions, eb *eventbus.EventB
 		RPCBus:      rb,
 		Keys:        BLSKeys,
 		TimerLength: 5 * time.Second,
+		Detector:    equivocation.NewDetector(signer.NewLocal(BLSKeys), db),
 	}
 	lp := loop.New(e, &pk)
 
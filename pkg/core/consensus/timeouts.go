@@ -0,0 +1,119 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// stepsPerIteration is the number of steps of an iteration of the
+	// consensus: selection, first and second reduction.
+	stepsPerIteration = 3
+
+	// timeoutMargin is the ratio between the timeout of a step and the
+	// observed duration of the steps.
+	timeoutMargin = 4
+
+	// latencyWeight is the inverse of the weight of a new observation in the
+	// moving average of the step duration.
+	latencyWeight = 4
+)
+
+// Timeouts computes the timeouts of the consensus steps. Within a round, the
+// timeout doubles at each iteration. The timeout of the first iteration
+// adapts to the step duration observed in the previous rounds. All timeouts
+// are bounded by a minimum and a maximum.
+type Timeouts struct {
+	base, min, max time.Duration
+
+	lock sync.RWMutex
+	// stepDuration is the moving average of the step duration. It is zero
+	// until a round is observed.
+	stepDuration time.Duration
+}
+
+// NewTimeouts creates Timeouts starting from the base timeout, until rounds
+// are observed.
+func NewTimeouts(base, min, max time.Duration) *Timeouts {
+	if max < min {
+		max = min
+	}
+
+	return &Timeouts{base: base, min: min, max: max}
+}
+
+// Base returns the timeout of the first iteration of a round.
+func (t *Timeouts) Base() time.Duration {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.stepDuration == 0 {
+		return t.bound(t.base)
+	}
+
+	return t.bound(t.stepDuration * timeoutMargin)
+}
+
+// Step returns the timeout of a step.
+func (t *Timeouts) Step(step uint8) time.Duration {
+	timeout := t.Base()
+
+	iteration := 0
+	if step > 0 {
+		iteration = int(step-1) / stepsPerIteration
+	}
+
+	for i := 0; i < iteration && timeout < t.max; i++ {
+		timeout *= 2
+	}
+
+	return t.bound(timeout)
+}
+
+// Observe records the duration of a round, and the number of steps it took
+// to reach agreement.
+func (t *Timeouts) Observe(d time.Duration, steps uint8) {
+	if steps == 0 || d <= 0 {
+		return
+	}
+
+	sample := d / time.Duration(steps)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.stepDuration == 0 {
+		t.stepDuration = sample
+		return
+	}
+
+	t.stepDuration += (sample - t.stepDuration) / latencyWeight
+}
+
+func (t *Timeouts) bound(d time.Duration) time.Duration {
+	if d < t.min {
+		return t.min
+	}
+
+	if d > t.max {
+		return t.max
+	}
+
+	return d
+}
+
+// StepTimeout returns the timeout of a step. Without Timeouts, the timeout
+// managed by the step is used.
+func (e *Emitter) StepTimeout(step uint8, timeout time.Duration) time.Duration {
+	if e.Timeouts == nil {
+		return timeout
+	}
+
+	return e.Timeouts.Step(step)
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutsGrowWithSteps(t *testing.T) {
+	to := NewTimeouts(5*time.Second, time.Second, 30*time.Second)
+
+	// Steps of the same iteration share the timeout
+	assert.Equal(t, 5*time.Second, to.Step(1))
+	assert.Equal(t, 5*time.Second, to.Step(3))
+	assert.Equal(t, 10*time.Second, to.Step(4))
+	assert.Equal(t, 20*time.Second, to.Step(7))
+
+	// Bounded by the maximum
+	assert.Equal(t, 30*time.Second, to.Step(10))
+	assert.Equal(t, 30*time.Second, to.Step(213))
+}
+
+func TestTimeoutsAdapt(t *testing.T) {
+	to := NewTimeouts(5*time.Second, time.Second, 30*time.Second)
+
+	// A round agreed upon in 3 steps of 500ms
+	to.Observe(1500*time.Millisecond, 3)
+	assert.Equal(t, 2*time.Second, to.Base())
+	assert.Equal(t, 4*time.Second, to.Step(4))
+
+	// Slower rounds move the average up progressively
+	to.Observe(6*time.Second, 3)
+	assert.Equal(t, 3500*time.Millisecond, to.Base())
+
+	// Bounded by the minimum
+	fast := NewTimeouts(5*time.Second, time.Second, 30*time.Second)
+	fast.Observe(30*time.Millisecond, 3)
+	assert.Equal(t, time.Second, fast.Base())
+
+	// Ignored observations
+	fast.Observe(0, 3)
+	fast.Observe(time.Second, 0)
+	assert.Equal(t, time.Second, fast.Base())
+}
+
+func TestStepTimeout(t *testing.T) {
+	e := &Emitter{}
+	assert.Equal(t, 5*time.Second, e.StepTimeout(4, 5*time.Second))
+
+	e.Timeouts = NewTimeouts(time.Second, time.Second, time.Minute)
+	assert.Equal(t, 2*time.Second, e.StepTimeout(4, 5*time.Second))
+}
@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package user
+
+import (
+	"bytes"
+	"math"
+)
+
+// DutyForecast estimates how often a provisioner is drawn for a duty.
+type DutyForecast struct {
+	// Probability of being drawn at least once.
+	Probability float64 `json:"probability"`
+	// Expected is the expected number of draws.
+	Expected float64 `json:"expected"`
+}
+
+// Forecast estimates the duties of a provisioner over a range of rounds,
+// assuming that each of them concludes within its first iteration.
+type Forecast struct {
+	From   uint64 `json:"from"`
+	Rounds uint64 `json:"rounds"`
+	// Generator is the forecast of the selection steps, which draw a single
+	// block generator.
+	Generator DutyForecast `json:"generator"`
+	// Committee is the forecast of the reduction steps. Each draw is a vote.
+	Committee DutyForecast `json:"committee"`
+}
+
+// Slots returns the indexes of the sortition draws of a step which extract
+// pubKeyBLS. Each of them is a vote of the provisioner in the committee.
+func (p Provisioners) Slots(pubKeyBLS []byte, seed []byte, round uint64, step uint8, size int) []int {
+	slots := make([]int, 0)
+
+	for i, blsPk := range p.draw(seed, round, step, size) {
+		if bytes.Equal(blsPk, pubKeyBLS) {
+			slots = append(slots, i)
+		}
+	}
+
+	return slots
+}
+
+// ForecastDuties estimates the duties of pubKeyBLS over the rounds starting
+// at from. The committees of the reduction steps are capped to maxSize votes.
+//
+// The seeds of the upcoming rounds are unknown, but the sortition score of a
+// draw is a hash reduced modulo the total active stake. A draw therefore
+// extracts a provisioner with a probability equal to its share of the active
+// stake. The subtraction of one DUSK per draw is neglected.
+func (p Provisioners) ForecastDuties(pubKeyBLS []byte, from, rounds uint64, maxSize int) Forecast {
+	f := Forecast{From: from, Rounds: rounds}
+
+	m := p.GetMember(pubKeyBLS)
+	if m == nil {
+		return f
+	}
+
+	// Probabilities of never being drawn
+	missGenerator, missCommittee := 1.0, 1.0
+
+	for round := from; round < from+rounds; round++ {
+		stake := activeStake(m, round)
+
+		var total uint64
+		for _, member := range p.Members {
+			total += activeStake(member, round)
+		}
+
+		if stake == 0 || total == 0 {
+			continue
+		}
+
+		share := float64(stake) / float64(total)
+
+		size := p.SubsetSizeAt(round)
+		if size > maxSize {
+			size = maxSize
+		}
+
+		// One selection step and two reduction steps per iteration
+		f.Generator.Expected += share
+		missGenerator *= 1 - share
+
+		f.Committee.Expected += 2 * float64(size) * share
+		missCommittee *= math.Pow(1-share, float64(2*size))
+	}
+
+	f.Generator.Probability = 1 - missGenerator
+	f.Committee.Probability = 1 - missCommittee
+
+	return f
+}
+
+// activeStake returns the amount of the stakes of m active at round.
+func activeStake(m *Member, round uint64) uint64 {
+	var amount uint64
+
+	for _, stake := range m.Stakes {
+		if stake.StartHeight <= round && round <= stake.EndHeight {
+			amount += stake.Amount
+		}
+	}
+
+	return amount
+}
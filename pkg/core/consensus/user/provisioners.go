@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
@@ -230,13 +231,23 @@ func (p Provisioners) GetRawPublicKeyBLS(pubKeyBLS []byte) []byte {
 	return m.RawPublicKeyBLS
 }
 
-// MarshalProvisioners ...
+// MarshalProvisioners encodes the provisioners ordered by BLS public key, so
+// that the same set always has the same encoding.
 func MarshalProvisioners(r *bytes.Buffer, p *Provisioners) error {
 	if err := encoding.WriteVarInt(r, uint64(len(p.Members))); err != nil {
 		return err
 	}
 
+	members := make([]*Member, 0, len(p.Members))
 	for _, member := range p.Members {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return bytes.Compare(members[i].PublicKeyBLS, members[j].PublicKeyBLS) < 0
+	})
+
+	for _, member := range members {
 		if err := marshalMember(r, *member); err != nil {
 			return err
 		}
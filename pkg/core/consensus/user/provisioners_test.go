@@ -100,3 +100,16 @@ func TestMarshalProvisioners(t *testing.T) {
 
 	assert.True(t, p.Set.Equal(p2.Set))
 }
+
+func TestMarshalProvisionersDeterministic(t *testing.T) {
+	p, _ := consensus.MockProvisioners(10)
+
+	first := bytes.Buffer{}
+	assert.NoError(t, user.MarshalProvisioners(&first, p))
+
+	for i := 0; i < 10; i++ {
+		buf := bytes.Buffer{}
+		assert.NoError(t, user.MarshalProvisioners(&buf, p))
+		assert.Equal(t, first.Bytes(), buf.Bytes())
+	}
+}
@@ -100,6 +100,18 @@ func generateSortitionScore(hash []byte, W *big.Int) uint64 {
 // TODO: running this with weird setup causes infinite looping (to reproduce, hardcode `3` on MockProvisioners when calling agreement.NewHelper in the agreement tests).
 func (p Provisioners) CreateVotingCommittee(seed []byte, round uint64, step uint8, size int) VotingCommittee {
 	votingCommittee := newCommittee()
+
+	for _, blsPk := range p.draw(seed, round, step, size) {
+		votingCommittee.Insert(blsPk)
+	}
+
+	return *votingCommittee
+}
+
+// draw runs the sortition of a step, and returns the public key extracted by
+// each draw. The result is shorter than size if the active stakes run out.
+func (p Provisioners) draw(seed []byte, round uint64, step uint8, size int) [][]byte {
+	draws := make([][]byte, 0, size)
 	W := new(big.Int).SetUint64(p.TotalWeight())
 
 	// Deep copy the Members map, to avoid mutating the original set.
@@ -125,7 +137,7 @@ func (p Provisioners) CreateVotingCommittee(seed []byte, round uint64, step uint
 		}
 	}
 
-	for i := 0; votingCommittee.Size() < size; i++ {
+	for i := 0; len(draws) < size; i++ {
 		if W.Uint64() == 0 {
 			// We ran out of staked DUSK, so we return the result prematurely
 			break
@@ -139,7 +151,7 @@ func (p Provisioners) CreateVotingCommittee(seed []byte, round uint64, step uint
 		score := generateSortitionScore(hashSort, W)
 
 		blsPk := p.extractCommitteeMember(score)
-		votingCommittee.Insert(blsPk)
+		draws = append(draws, blsPk)
 
 		// Subtract up to one DUSK from the extracted committee member.
 		m := p.GetMember(blsPk)
@@ -150,7 +162,7 @@ func (p Provisioners) CreateVotingCommittee(seed []byte, round uint64, step uint
 		subtractFromTotalWeight(W, subtracted)
 	}
 
-	return *votingCommittee
+	return draws
 }
 
 // extractCommitteeMember walks through the committee set, while deducting
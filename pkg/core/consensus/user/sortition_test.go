@@ -8,6 +8,7 @@ package user_test
 
 import (
 	"bytes"
+	"math"
 	"math/big"
 	"sort"
 	"testing"
@@ -117,3 +118,42 @@ func TestRemoveStake(t *testing.T) {
 
 	assert.NotPanics(t, func() { p.CreateVotingCommittee(seed, 1, 1, 10) })
 }
+
+// Test that the slots of the provisioners add up to the voting committee.
+func TestSlots(t *testing.T) {
+	p, ks := consensus.MockProvisioners(5)
+
+	seed := []byte{0, 0, 0, 0}
+	v := p.CreateVotingCommittee(seed, 1, 2, 5)
+
+	var votes int
+
+	for _, k := range ks {
+		slots := p.Slots(k.BLSPubKey, seed, 1, 2, 5)
+		assert.Equal(t, v.OccurrencesOf(k.BLSPubKey), len(slots))
+
+		votes += len(slots)
+	}
+
+	assert.Equal(t, 5, votes)
+}
+
+// Test the duty forecast of a provisioner holding a fifth of the stakes.
+func TestForecastDuties(t *testing.T) {
+	p, ks := consensus.MockProvisioners(5)
+
+	f := p.ForecastDuties(ks[0].BLSPubKey, 1, 10, 64)
+	assert.InDelta(t, 2, f.Generator.Expected, 1e-9)
+	assert.InDelta(t, 1-math.Pow(0.8, 10), f.Generator.Probability, 1e-9)
+	assert.InDelta(t, 20, f.Committee.Expected, 1e-9)
+	assert.InDelta(t, 1-math.Pow(0.8, 100), f.Committee.Probability, 1e-9)
+
+	// The stakes of the mock provisioners expire at height 10000
+	f = p.ForecastDuties(ks[0].BLSPubKey, 9996, 10, 64)
+	assert.InDelta(t, 1, f.Generator.Expected, 1e-9)
+
+	// Unknown provisioners have no duty
+	f = p.ForecastDuties(key.NewRandKeys().BLSPubKey, 1, 10, 64)
+	assert.Zero(t, f.Generator.Expected)
+	assert.Zero(t, f.Committee.Probability)
+}
@@ -24,6 +24,8 @@ type DB struct {
 
 var txRecordPrefix = []byte{0x00} // writeOptions = &opt.WriteOptions{NoWriteMerge: false, Sync: true}
 
+var stakeAutomatonKey = []byte{0x01}
+
 // New creates an instance of DB.
 func New(path string) (*DB, error) {
 	db, err := leveldb.OpenFile(path, nil)
@@ -101,6 +103,26 @@ func (db *DB) PutTxRecord(tx transactions.ContractCall, height uint64, direction
 	return db.Put(key, value)
 }
 
+// PutStakeAutomatonState saves the encoded state of the StakeAutomaton.
+func (db *DB) PutStakeAutomatonState(state []byte) error {
+	// Schema
+	//
+	// key: stakeAutomatonKey
+	// value: state
+	return db.Put(stakeAutomatonKey, state)
+}
+
+// FetchStakeAutomatonState returns the encoded state of the StakeAutomaton,
+// or nil if none was saved.
+func (db *DB) FetchStakeAutomatonState() ([]byte, error) {
+	state, err := db.Get(stakeAutomatonKey)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+
+	return state, err
+}
+
 // Clear all information from the database.
 func (db *DB) Clear() error {
 	iter := db.storage.NewIterator(nil, nil)
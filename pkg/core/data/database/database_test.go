@@ -55,6 +55,27 @@ func TestPutGet(t *testing.T) {
 	assert.True(bytes.Equal(val, []byte{}))
 }
 
+func TestStakeAutomatonState(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := New(path)
+	assert.NoError(err)
+
+	defer os.RemoveAll(path)
+
+	state, err := db.FetchStakeAutomatonState()
+	assert.NoError(err)
+	assert.Nil(state)
+
+	assert.NoError(db.PutStakeAutomatonState([]byte("state")))
+
+	state, err = db.FetchStakeAutomatonState()
+	assert.NoError(err)
+	assert.Equal([]byte("state"), state)
+
+	assert.NoError(db.Close())
+}
+
 func TestPutFetchTxRecord(t *testing.T) {
 	// FIXME: 459
 }
@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package transactions
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"google.golang.org/protobuf/proto"
+)
+
+// Balance of a wallet, both in the current state and once its txs pending in
+// the mempool are accepted.
+type Balance struct {
+	Confirmed   uint64
+	Unconfirmed uint64
+}
+
+// CalculateBalance returns the confirmed balance of a view key: the value of
+// the notes that Rusk reports as owned by the key.
+//
+// Rusk has no call to value the notes, and the node cannot open the
+// obfuscated ones: their value is encrypted for the secret key of the wallet.
+// Only the transparent notes are counted.
+func (v *verifier) CalculateBalance(ctx context.Context, vk []byte) (uint64, error) {
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(v.timeout))
+	defer cancel()
+
+	res, err := v.stateClient.GetNotesOwnedBy(ctx, &rusk.GetNotesOwnedByRequest{Vk: vk})
+	if err != nil {
+		return 0, err
+	}
+
+	var balance uint64
+
+	for _, data := range res.Notes {
+		note := new(rusk.Note)
+		if err := proto.Unmarshal(data, note); err != nil {
+			return 0, err
+		}
+
+		balance += NoteValue(note)
+	}
+
+	return balance, nil
+}
+
+// NoteValue returns the value of a note. The value of a transparent note is
+// stored in clear at the beginning of its encrypted data. Obfuscated notes are
+// worth zero here.
+func NoteValue(n *rusk.Note) uint64 {
+	if n.NoteType != rusk.Note_TRANSPARENT || len(n.EncryptedData) < 8 {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(n.EncryptedData[:8])
+}
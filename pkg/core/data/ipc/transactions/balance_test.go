@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// notesClient is a rusk.StateClient holding the notes of a view key.
+type notesClient struct {
+	rusk.StateClient
+
+	vk    []byte
+	notes []*rusk.Note
+}
+
+func (c *notesClient) GetNotesOwnedBy(ctx context.Context, req *rusk.GetNotesOwnedByRequest, opts ...grpc.CallOption) (*rusk.GetNotesOwnedByResponse, error) {
+	res := new(rusk.GetNotesOwnedByResponse)
+	if !bytes.Equal(req.Vk, c.vk) {
+		return res, nil
+	}
+
+	for _, note := range c.notes {
+		data, err := proto.Marshal(note)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Notes = append(res.Notes, data)
+	}
+
+	return res, nil
+}
+
+// transparentNote mocks a transparent note of a value.
+func transparentNote(value uint64) *rusk.Note {
+	data := make([]byte, 96)
+	binary.LittleEndian.PutUint64(data, value)
+
+	return &rusk.Note{NoteType: rusk.Note_TRANSPARENT, EncryptedData: data}
+}
+
+func TestCalculateBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	vk := Rand32Bytes()
+	state := &notesClient{
+		vk: vk,
+		notes: []*rusk.Note{
+			transparentNote(1000000),
+			transparentNote(500000),
+			// Obfuscated notes are not valued by the node
+			{NoteType: rusk.Note_OBFUSCATED, EncryptedData: Rand32Bytes()},
+		},
+	}
+
+	v := newVerifier(&proxy{stateClient: state, timeout: time.Second})
+
+	balance, err := v.CalculateBalance(context.Background(), vk)
+	assert.NoError(err)
+	assert.Equal(uint64(1500000), balance)
+
+	// Other keys own nothing
+	balance, err = v.CalculateBalance(context.Background(), Rand32Bytes())
+	assert.NoError(err)
+	assert.Zero(balance)
+}
@@ -11,7 +11,6 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
-	"errors"
 	"math/big"
 	"time"
 
@@ -88,7 +87,7 @@ type mockVerifier struct {
 
 func (v *mockVerifier) VerifyTransaction(ctx context.Context, cc ContractCall) error {
 	if IsMockInvalid(cc) {
-		return errors.New("invalid transaction")
+		return ErrVerificationFailed
 	}
 
 	if v.verifyTransactionLatency > 0 {
@@ -98,7 +97,13 @@ func (v *mockVerifier) VerifyTransaction(ctx context.Context, cc ContractCall) e
 	return nil
 }
 
-func (v *mockVerifier) CalculateBalance(ctx context.Context, vkBytes []byte, txs []ContractCall) (uint64, error) {
+func (v *mockVerifier) Refresh(ctx context.Context, height uint64) error {
+	return nil
+}
+
+func (v *mockVerifier) Forget(txID []byte) {}
+
+func (v *mockVerifier) CalculateBalance(ctx context.Context, vkBytes []byte) (uint64, error) {
 	return uint64(0), nil
 }
 
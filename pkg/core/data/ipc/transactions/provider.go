@@ -43,10 +43,15 @@ func MakeTxRequest(sk keys.SecretKey, pk keys.PublicKey, amount uint64, fee uint
 type UnconfirmedTxProber interface {
 	// VerifyTransaction verifies a contract call transaction.
 	VerifyTransaction(context.Context, ContractCall) error
-	// CalculateBalance for transactions on demand. This functionality is used
-	// primarily by the mempool which can order RUSK to calculate balance for
-	// transactions even if they are unconfirmed.
-	CalculateBalance(context.Context, []byte, []ContractCall) (uint64, error)
+	// Refresh notifies the prober of the block accepted at a height, so
+	// that the verifications outdated by the new state are dropped.
+	Refresh(context.Context, uint64) error
+	// Forget drops the verification of a transaction which left the
+	// mempool, i.e. accepted into a block or evicted.
+	Forget(txID []byte)
+	// CalculateBalance returns the confirmed balance of a view key, from the
+	// notes that RUSK reports as owned by it.
+	CalculateBalance(context.Context, []byte) (uint64, error)
 }
 
 // Provider encapsulates the common Wallet and transaction operations.
@@ -58,6 +63,10 @@ type Provider interface {
 	// It accepts the PublicKey of the recipient, a value, a fee and whether
 	// the transaction should be obfuscated or otherwise.
 	NewTransfer(context.Context, uint64, *keys.StealthAddress) (*Transaction, error)
+
+	// NewSlash creates a slashing transaction carrying the encoded evidence of
+	// an equivocation.
+	NewSlash(context.Context, []byte) (*Transaction, error)
 }
 
 // KeyMaster Encapsulates the Key creation and retrieval operations.
@@ -105,24 +114,32 @@ type proxy struct {
 	stakeClient    rusk.StakeServiceClient
 	txTimeout      time.Duration
 	timeout        time.Duration
+	blockGasLimit  uint64
+
+	prober *verifier
 }
 
 // NewProxy creates a new Proxy.
 func NewProxy(stateClient rusk.StateClient, keysClient rusk.KeysClient, transferClient rusk.TransferClient,
-	stakeClient rusk.StakeServiceClient, txTimeout, defaultTimeout time.Duration) Proxy {
-	return &proxy{
+	stakeClient rusk.StakeServiceClient, txTimeout, defaultTimeout time.Duration, blockGasLimit uint64) Proxy {
+	p := &proxy{
 		stateClient:    stateClient,
 		keysClient:     keysClient,
 		transferClient: transferClient,
 		stakeClient:    stakeClient,
 		txTimeout:      txTimeout,
 		timeout:        defaultTimeout,
+		blockGasLimit:  blockGasLimit,
 	}
+
+	p.prober = newVerifier(p)
+	return p
 }
 
-// Prober returned by the Proxy.
+// Prober returned by the Proxy. It is shared by all callers, so that they
+// benefit from the same verification cache.
 func (p *proxy) Prober() UnconfirmedTxProber {
-	return &verifier{p}
+	return p.prober
 }
 
 // KeyMaster returned by the Proxy.
@@ -140,33 +157,6 @@ func (p *proxy) Provider() Provider {
 	return &provider{p}
 }
 
-type verifier struct {
-	*proxy
-}
-
-// VerifyTransaction verifies a contract call transaction.
-func (v *verifier) VerifyTransaction(ctx context.Context, cc ContractCall) error {
-	/*
-		ccTx, err := EncodeContractCall(cc)
-		if err != nil {
-			return err
-		}
-
-		if res, err := v.client.VerifyTransaction(ctx, ccTx); err != nil {
-			return err
-		} else if !res.Verified {
-			return errors.New("verification failed")
-		}
-
-	*/
-	return nil
-}
-
-// TODO: implement.
-func (v *verifier) CalculateBalance(ctx context.Context, b []byte, calls []ContractCall) (uint64, error) {
-	return 0, nil
-}
-
 type provider struct {
 	*proxy
 }
@@ -222,6 +212,24 @@ func (p *provider) NewTransfer(ctx context.Context, value uint64, sa *keys.Steal
 	return trans, err
 }
 
+// NewSlash creates a Slash transaction. As Rusk does not expose a dedicated
+// call yet, the transaction is built locally: it carries the evidence as call
+// data, and neither spends notes nor pays a fee. The node verifies the
+// evidence, both in the mempool and in the blocks, before Rusk executes the
+// transaction.
+//
+// Rusk does not slash the accused provisioner yet, hence the Slash
+// transactions are refused unless consensus.slashing is enabled.
+func (p *provider) NewSlash(ctx context.Context, evidence []byte) (*Transaction, error) {
+	trans := NewTransaction()
+	trans.TxType = Slash
+
+	trans.Payload.CallData = make([]byte, len(evidence))
+	copy(trans.Payload.CallData, evidence)
+
+	return trans, nil
+}
+
 type keymaster struct {
 	*proxy
 }
@@ -278,7 +286,7 @@ func (e *executor) VerifyStateTransition(ctx context.Context, calls []ContractCa
 	}
 
 	if !res.Success {
-		return errors.New("verification failed")
+		return ErrVerificationFailed
 	}
 
 	return nil
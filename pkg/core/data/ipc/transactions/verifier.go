@@ -0,0 +1,263 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package transactions
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+)
+
+const (
+	// verificationCacheSize is the amount of verification results kept.
+	verificationCacheSize = 10000
+	// batchWindow is how long transactions are collected before being
+	// verified together.
+	batchWindow = 20 * time.Millisecond
+	// maxBatchSize is the amount of transactions after which a batch is
+	// verified without waiting for the window to end.
+	maxBatchSize = 128
+)
+
+// ErrVerificationFailed is returned for the transactions rejected by Rusk.
+var ErrVerificationFailed = errors.New("verification failed")
+
+// verification of a transaction, pending until done is closed.
+type verification struct {
+	tx   ContractCall
+	hash string
+	err  error
+	done chan struct{}
+
+	// elem is the position of the result in the cache, once done.
+	elem *list.Element
+}
+
+// verifier verifies the transactions through the state transition of Rusk.
+// The transactions submitted together are verified in batches, and the
+// results are cached by transaction hash, so that a transaction relayed by
+// several peers is verified once. The cached results are dropped when an
+// accepted block changes the anchor, and the ones of the txs leaving the
+// mempool are dropped as they leave. The mempool verifies its pending txs again
+// after each block.
+type verifier struct {
+	*proxy
+
+	lock sync.Mutex
+	// height of the next block, which the transactions are verified against.
+	height uint64
+	anchor []byte
+
+	// results holds both the pending verifications and the cached results.
+	results map[string]*verification
+	// order of the cached results, least recently used first.
+	order *list.List
+
+	queue []*verification
+	timer *time.Timer
+}
+
+func newVerifier(p *proxy) *verifier {
+	return &verifier{
+		proxy:   p,
+		results: make(map[string]*verification),
+		order:   list.New(),
+	}
+}
+
+// VerifyTransaction verifies a contract call transaction.
+func (v *verifier) VerifyTransaction(ctx context.Context, cc ContractCall) error {
+	hash, err := cc.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+
+	r, ok := v.results[string(hash)]
+
+	switch {
+	case !ok:
+		r = &verification{
+			tx:   cc,
+			hash: string(hash),
+			done: make(chan struct{}),
+		}
+
+		v.results[r.hash] = r
+		v.enqueue(r)
+	case r.elem != nil:
+		v.order.MoveToBack(r.elem)
+	}
+
+	v.lock.Unlock()
+
+	select {
+	case <-r.done:
+		return r.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Refresh fetches the anchor of the state. If it changed, the cached results
+// are dropped, as they are outdated.
+func (v *verifier) Refresh(ctx context.Context, height uint64) error {
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(v.timeout))
+	defer cancel()
+
+	res, err := v.stateClient.GetAnchor(ctx, &rusk.GetAnchorRequest{})
+	if err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.height = height + 1
+
+	if bytes.Equal(res.Anchor, v.anchor) {
+		return nil
+	}
+
+	v.anchor = res.Anchor
+
+	// The pending verifications are not cached once done, as they are
+	// performed on the previous anchor
+	for e := v.order.Front(); e != nil; e = e.Next() {
+		delete(v.results, e.Value.(*verification).hash)
+	}
+
+	v.order.Init()
+	return nil
+}
+
+// Forget drops the cached result of a transaction, once it left the mempool.
+func (v *verifier) Forget(txID []byte) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	r, ok := v.results[string(txID)]
+	if !ok {
+		return
+	}
+
+	// A pending verification is not cached once done
+	delete(v.results, r.hash)
+
+	if r.elem != nil {
+		v.order.Remove(r.elem)
+		r.elem = nil
+	}
+}
+
+// enqueue a verification in the next batch. It must be called with the lock
+// held.
+func (v *verifier) enqueue(r *verification) {
+	v.queue = append(v.queue, r)
+
+	if len(v.queue) >= maxBatchSize {
+		v.flush()
+		return
+	}
+
+	if v.timer == nil {
+		v.timer = time.AfterFunc(batchWindow, func() {
+			v.lock.Lock()
+			defer v.lock.Unlock()
+
+			v.flush()
+		})
+	}
+}
+
+// flush starts the verification of the queued batch. It must be called with
+// the lock held.
+func (v *verifier) flush() {
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+
+	if len(v.queue) == 0 {
+		return
+	}
+
+	batch := v.queue
+	v.queue = nil
+
+	go v.verifyBatch(batch, v.height, v.anchor)
+}
+
+func (v *verifier) verifyBatch(batch []*verification, height uint64, anchor []byte) {
+	calls := make([]ContractCall, len(batch))
+	for i, r := range batch {
+		calls[i] = r.tx
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(v.txTimeout))
+	defer cancel()
+
+	errs := v.verify(ctx, calls, height)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for i, r := range batch {
+		r.err = errs[i]
+
+		// Only the results of Rusk on the current state are cached. Other
+		// errors, like timeouts, are not.
+		cached := v.results[r.hash] == r && bytes.Equal(anchor, v.anchor) &&
+			(r.err == nil || r.err == ErrVerificationFailed)
+
+		switch {
+		case cached:
+			r.elem = v.order.PushBack(r)
+		case v.results[r.hash] == r:
+			delete(v.results, r.hash)
+		}
+
+		close(r.done)
+	}
+
+	for v.order.Len() > verificationCacheSize {
+		r := v.order.Remove(v.order.Front()).(*verification)
+		delete(v.results, r.hash)
+	}
+}
+
+// verify the calls at once. If Rusk rejects them, the batch is split to
+// isolate the rejected calls. Conflicts between calls of different halves,
+// like a double spending, are left to the caller.
+func (v *verifier) verify(ctx context.Context, calls []ContractCall, height uint64) []error {
+	errs := make([]error, len(calls))
+
+	err := (&executor{v.proxy}).VerifyStateTransition(ctx, calls, v.blockGasLimit, height)
+	if err == nil {
+		return errs
+	}
+
+	if err != ErrVerificationFailed || len(calls) == 1 {
+		for i := range errs {
+			errs[i] = err
+		}
+
+		return errs
+	}
+
+	half := len(calls) / 2
+	copy(errs, v.verify(ctx, calls[:half], height))
+	copy(errs[half:], v.verify(ctx, calls[half:], height))
+
+	return errs
+}
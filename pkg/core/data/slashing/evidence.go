@@ -0,0 +1,266 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package slashing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// Vote is a block hash signed by a provisioner for a given round and step.
+type Vote struct {
+	BlockHash []byte `json:"hash"`
+	Signature []byte `json:"signature"`
+}
+
+// Evidence proves that a provisioner signed two different block hashes for
+// the same round and step of the consensus. Reduction and Agreement messages
+// sign the same preimage, so the votes may be carried by messages of either
+// kind: an honest provisioner signs a single hash per round and step. The
+// Evidence is signed by the reporting node.
+type Evidence struct {
+	Round     uint64 `json:"round"`
+	Step      uint8  `json:"step"`
+	PubKeyBLS []byte `json:"pubkey"`
+
+	// Votes are sorted by block hash, so that the evidence of an
+	// equivocation does not depend on the order the votes were received.
+	Votes [2]Vote `json:"votes"`
+
+	Reporter  []byte `json:"reporter"`
+	Signature []byte `json:"signature"`
+}
+
+// New creates an unsigned Evidence out of two conflicting votes.
+func New(first, second header.Header, firstSig, secondSig []byte) *Evidence {
+	e := &Evidence{
+		Round:     first.Round,
+		Step:      first.Step,
+		PubKeyBLS: first.PubKeyBLS,
+		Votes: [2]Vote{
+			{BlockHash: first.BlockHash, Signature: firstSig},
+			{BlockHash: second.BlockHash, Signature: secondSig},
+		},
+	}
+
+	if bytes.Compare(e.Votes[0].BlockHash, e.Votes[1].BlockHash) > 0 {
+		e.Votes[0], e.Votes[1] = e.Votes[1], e.Votes[0]
+	}
+
+	return e
+}
+
+// Hash identifies the equivocation. Being computed over the conflicting votes
+// only, it is the same regardless of the node reporting them.
+func (e Evidence) Hash() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := marshalVotes(buf, e); err != nil {
+		return nil, err
+	}
+
+	return hash.Sha3256(buf.Bytes())
+}
+
+// Sign the Evidence with the BLS keys of the reporting node.
+func (e *Evidence) Sign(keys key.Keys) error {
+	preimage, err := e.Signable(keys.BLSPubKey)
+	if err != nil {
+		return err
+	}
+
+	signature, err := bls.Sign(keys.BLSSecretKey, keys.BLSPubKey, preimage)
+	if err != nil {
+		return err
+	}
+
+	e.Reporter = keys.BLSPubKey
+	e.Signature = signature
+	return nil
+}
+
+// Signable returns the preimage of the Evidence signed by the given reporter.
+func (e Evidence) Signable(reporter []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := marshalSignable(buf, e, reporter); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Verify checks that the votes conflict and that both of them, along with the
+// Evidence itself, are correctly signed.
+func (e Evidence) Verify() error {
+	if err := e.VerifyVotes(); err != nil {
+		return err
+	}
+
+	preimage, err := e.Signable(e.Reporter)
+	if err != nil {
+		return err
+	}
+
+	if err := msg.VerifyBLSSignature(e.Reporter, e.Signature, preimage); err != nil {
+		return fmt.Errorf("invalid reporter signature: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyVotes checks that the votes conflict and that both of them are
+// correctly signed. The signature of the reporter is not checked.
+func (e Evidence) VerifyVotes() error {
+	if bytes.Equal(e.Votes[0].BlockHash, e.Votes[1].BlockHash) {
+		return errors.New("votes do not conflict")
+	}
+
+	for _, vote := range e.Votes {
+		preimage := new(bytes.Buffer)
+
+		hdr := header.Header{Round: e.Round, Step: e.Step, BlockHash: vote.BlockHash}
+		if err := header.MarshalSignableVote(preimage, hdr); err != nil {
+			return err
+		}
+
+		if err := msg.VerifyBLSSignature(e.PubKeyBLS, vote.Signature, preimage.Bytes()); err != nil {
+			return fmt.Errorf("invalid vote for %s: %w", util.StringifyBytes(vote.BlockHash), err)
+		}
+	}
+
+	return nil
+}
+
+// Equal checks if two Evidence carry the same conflicting votes.
+func (e Evidence) Equal(other Evidence) bool {
+	if e.Round != other.Round || e.Step != other.Step {
+		return false
+	}
+
+	if !bytes.Equal(e.PubKeyBLS, other.PubKeyBLS) {
+		return false
+	}
+
+	for i := range e.Votes {
+		if !bytes.Equal(e.Votes[i].BlockHash, other.Votes[i].BlockHash) ||
+			!bytes.Equal(e.Votes[i].Signature, other.Votes[i].Signature) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e Evidence) String() string {
+	return fmt.Sprintf("equivocation round: %d step: %d provisioner: %s hashes: %s %s",
+		e.Round, e.Step, util.StringifyBytes(e.PubKeyBLS),
+		util.StringifyBytes(e.Votes[0].BlockHash), util.StringifyBytes(e.Votes[1].BlockHash))
+}
+
+// Marshal an Evidence into a buffer.
+func Marshal(r *bytes.Buffer, e Evidence) error {
+	if err := marshalVotes(r, e); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, e.Reporter); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(r, e.Signature)
+}
+
+// Unmarshal an Evidence from a buffer.
+func Unmarshal(r *bytes.Buffer, e *Evidence) error {
+	if err := encoding.ReadUint64LE(r, &e.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadUint8(r, &e.Step); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadVarBytes(r, &e.PubKeyBLS); err != nil {
+		return err
+	}
+
+	for i := range e.Votes {
+		e.Votes[i].BlockHash = make([]byte, 32)
+		if err := encoding.Read256(r, e.Votes[i].BlockHash); err != nil {
+			return err
+		}
+
+		if err := encoding.ReadVarBytes(r, &e.Votes[i].Signature); err != nil {
+			return err
+		}
+	}
+
+	if err := encoding.ReadVarBytes(r, &e.Reporter); err != nil {
+		return err
+	}
+
+	return encoding.ReadVarBytes(r, &e.Signature)
+}
+
+// Decode the Evidence carried by a Slash tx.
+func Decode(tx transactions.ContractCall) (*Evidence, error) {
+	if tx.Type() != transactions.Slash {
+		return nil, errors.New("not a slash transaction")
+	}
+
+	e := new(Evidence)
+	if err := Unmarshal(bytes.NewBuffer(tx.StandardTx().CallData), e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// marshalVotes marshals the fields identifying the equivocation.
+func marshalVotes(r *bytes.Buffer, e Evidence) error {
+	if err := encoding.WriteUint64LE(r, e.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(r, e.Step); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, e.PubKeyBLS); err != nil {
+		return err
+	}
+
+	for _, vote := range e.Votes {
+		if err := encoding.Write256(r, vote.BlockHash); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteVarBytes(r, vote.Signature); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshalSignable marshals the preimage signed by the reporter.
+func marshalSignable(r *bytes.Buffer, e Evidence, reporter []byte) error {
+	if err := marshalVotes(r, e); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(r, reporter)
+}
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package slashing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEvidenceVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	e := MockEvidence(10, 2)
+	assert.NoError(e.Verify())
+
+	// Votes for the same hash do not conflict
+	keys := key.NewRandKeys()
+	hdr, sig := MockVote(keys, 10, 2)
+	same := New(hdr, hdr, sig, sig)
+	assert.NoError(same.Sign(keys))
+	assert.Error(same.Verify())
+
+	// Votes must be signed by the provisioner
+	forged := *e
+	forged.PubKeyBLS = keys.BLSPubKey
+	assert.Error(forged.Verify())
+
+	// The evidence must be signed by the reporter
+	tampered := *e
+	tampered.Reporter = keys.BLSPubKey
+	assert.Error(tampered.Verify())
+}
+
+func TestEvidenceHash(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := key.NewRandKeys()
+	first, firstSig := MockVote(keys, 5, 1)
+	second, secondSig := MockVote(keys, 5, 1)
+
+	// The hash depends neither on the vote order nor on the reporter
+	e1 := New(first, second, firstSig, secondSig)
+	e2 := New(second, first, secondSig, firstSig)
+
+	assert.NoError(e1.Sign(key.NewRandKeys()))
+	assert.NoError(e2.Sign(key.NewRandKeys()))
+	assert.NoError(e1.Verify())
+	assert.NoError(e2.Verify())
+
+	h1, err := e1.Hash()
+	assert.NoError(err)
+
+	h2, err := e2.Hash()
+	assert.NoError(err)
+
+	assert.Equal(h1, h2)
+	assert.True(e1.Equal(*e2))
+}
+
+func TestEvidenceMarshalling(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, e := range []*Evidence{MockEvidence(1, 1), MockEvidence(2, 3)} {
+		buf := new(bytes.Buffer)
+		assert.NoError(Marshal(buf, *e))
+
+		decoded := new(Evidence)
+		assert.NoError(Unmarshal(buf, decoded))
+
+		assert.True(e.Equal(*decoded))
+		assert.Equal(e.Reporter, decoded.Reporter)
+		assert.Equal(e.Signature, decoded.Signature)
+		assert.NoError(decoded.Verify())
+	}
+}
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package slashing
+
+import (
+	"bytes"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+)
+
+// MockVote returns a header for a random block hash, signed by keys.
+func MockVote(keys key.Keys, round uint64, step uint8) (header.Header, []byte) {
+	hash, _ := crypto.RandEntropy(32)
+	hdr := header.Header{
+		PubKeyBLS: keys.BLSPubKey,
+		Round:     round,
+		Step:      step,
+		BlockHash: hash,
+	}
+
+	preimage := new(bytes.Buffer)
+	_ = header.MarshalSignableVote(preimage, hdr)
+
+	signature, err := bls.Sign(keys.BLSSecretKey, keys.BLSPubKey, preimage.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	return hdr, signature
+}
+
+// MockEvidence returns a valid Evidence of a random provisioner equivocating at
+// the given round and step.
+func MockEvidence(round uint64, step uint8) *Evidence {
+	return MockEvidenceOf(key.NewRandKeys(), round, step)
+}
+
+// MockEvidenceOf returns a valid Evidence of the provisioner identified by
+// keys equivocating at the given round and step.
+func MockEvidenceOf(keys key.Keys, round uint64, step uint8) *Evidence {
+	first, firstSig := MockVote(keys, round, step)
+	second, secondSig := MockVote(keys, round, step)
+
+	e := New(first, second, firstSig, secondSig)
+	if err := e.Sign(key.NewRandKeys()); err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// MockSlashTx returns a Slash tx carrying the evidence.
+func MockSlashTx(e *Evidence) *transactions.Transaction {
+	buf := new(bytes.Buffer)
+	if err := Marshal(buf, *e); err != nil {
+		panic(err)
+	}
+
+	tx := transactions.NewTransaction()
+	tx.TxType = transactions.Slash
+	tx.Payload.CallData = buf.Bytes()
+
+	return tx
+}
@@ -26,6 +26,10 @@ const DUSK = uint64(10000000000)
 // ErrSeedFileExists is returned if the seed file already exists.
 var ErrSeedFileExists = fmt.Errorf("wallet seed file already exists")
 
+// ErrConsensusSecretKey is returned if a wallet delegating its consensus
+// signatures to a remote signer holds the BLS secret key.
+var ErrConsensusSecretKey = errors.New("wallet holds the BLS secret key, which must be kept on the remote signer only")
+
 // Wallet encapsulates the wallet.
 type Wallet struct {
 	db        *database.DB
@@ -155,6 +159,58 @@ func (w *Wallet) Keys() consensuskey.Keys {
 	return *w.consensusKeys
 }
 
+// DelegateConsensusKeys binds the wallet to the BLS public key of a remote
+// signer. The wallet must not hold the BLS secret key, which is kept on the
+// signer host only (see StripConsensusSecretKey).
+func (w *Wallet) DelegateConsensusKeys(pubKey []byte) error {
+	if len(w.consensusKeys.BLSSecretKey) > 0 {
+		return ErrConsensusSecretKey
+	}
+
+	if len(w.consensusKeys.BLSPubKey) > 0 && !bytes.Equal(w.consensusKeys.BLSPubKey, pubKey) {
+		return errors.New("the BLS public key of the wallet differs from the one of the remote signer")
+	}
+
+	w.consensusKeys = &consensuskey.Keys{BLSPubKey: pubKey}
+	return nil
+}
+
+// StripConsensusSecretKey copies a wallet file without its BLS secret key, for
+// a node delegating its consensus signatures to a remote signer. The copy is
+// encrypted with the same password.
+func StripConsensusSecretKey(password, seedFile, strippedFile string) error {
+	keysJSONArr, err := fetchEncrypted(password, seedFile)
+	if err != nil {
+		return err
+	}
+
+	keysJSON := new(KeysJSON)
+	if err = json.Unmarshal(keysJSONArr, keysJSON); err != nil {
+		return err
+	}
+
+	keysJSON.SecretKeyBLS = nil
+
+	data, err := json.Marshal(keysJSON)
+	if err != nil {
+		return err
+	}
+
+	return saveEncrypted(data, password, strippedFile)
+}
+
+// PutStakeAutomatonState saves the state of the StakeAutomaton in the wallet
+// database.
+func (w *Wallet) PutStakeAutomatonState(state []byte) error {
+	return w.db.PutStakeAutomatonState(state)
+}
+
+// FetchStakeAutomatonState returns the state of the StakeAutomaton saved in
+// the wallet database, if any.
+func (w *Wallet) FetchStakeAutomatonState() ([]byte, error) {
+	return w.db.FetchStakeAutomatonState()
+}
+
 // ClearDatabase will remove all info from the database.
 func (w *Wallet) ClearDatabase() error {
 	return w.db.Clear()
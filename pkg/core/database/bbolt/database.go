@@ -0,0 +1,505 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package bbolt
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// dataFile is the bbolt file, within the store directory.
+	dataFile = "chain.db"
+	// writerLockFile is held by the process which opened the store in
+	// read-write mode.
+	writerLockFile = "writer.lock"
+	// txLockFile synchronizes the commits of the writer process with the
+	// transactions of the read-only processes. See txLock.
+	txLockFile = "tx.lock"
+
+	// openTimeout bounds the wait for the bbolt file lock on opening.
+	openTimeout = 10 * time.Second
+
+	// schemaVersion is the version of the store layout supported by this
+	// driver. A store with a newer version is refused.
+	schemaVersion uint32 = 2
+)
+
+var (
+	// See openStore for detailed explanation.
+	_stores   = make(map[string]*store)
+	_storesMu sync.Mutex
+)
+
+// txLock is a process-wide lock on txLockFile.
+//
+// The writer process holds it exclusively while committing, as bbolt reuses
+// freed pages without knowledge of the transactions running in other
+// processes. Read-only processes hold it shared for the duration of each
+// transaction, so that they never read a page being overwritten.
+type txLock struct {
+	file *os.File
+
+	// flock is bound to the file, not to the goroutine. The shared lock is
+	// acquired by the first reader and released by the last one.
+	mu      sync.Mutex
+	readers int
+}
+
+func (l *txLock) rlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.readers == 0 {
+		if err := lockFile(l.file, false); err != nil {
+			return err
+		}
+	}
+
+	l.readers++
+	return nil
+}
+
+func (l *txLock) runlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.readers--
+	if l.readers == 0 {
+		return unlockFile(l.file)
+	}
+
+	return nil
+}
+
+func (l *txLock) lock() error {
+	return lockFile(l.file, true)
+}
+
+func (l *txLock) unlock() error {
+	return unlockFile(l.file)
+}
+
+// store is a bbolt handle shared by all DB instances opened on the same path
+// within the process.
+type store struct {
+	path     string
+	readOnly bool
+	refs     int
+
+	// mu guards db, which is replaced on remapping a read-only store.
+	mu   sync.RWMutex
+	db   *bolt.DB
+	size int64
+
+	writerLock *os.File
+	txLock     *txLock
+}
+
+// openStore returns the store located at path, opening it if it is not open
+// yet within the process.
+//
+// bbolt acquires a file lock for the lifetime of the handle, exclusive in
+// read-write mode and shared in read-only mode, so that the node would
+// prevent any other process from reading the chain. Instead, the bbolt lock is
+// released once the file is mapped: a single writer is ensured by
+// writerLockFile, while txLockFile keeps the read-only processes consistent
+// with the commits of the writer.
+func openStore(path string, readonly bool) (*store, error) {
+	_storesMu.Lock()
+	defer _storesMu.Unlock()
+
+	path = filepath.Clean(path)
+
+	if s, ok := _stores[path]; ok {
+		// Read-only mode of a store opened in read-write mode is provided at
+		// DB level
+		if s.readOnly && !readonly {
+			return nil, errors.New("store is already opened in read-only mode")
+		}
+
+		s.refs++
+		return s, nil
+	}
+
+	s := &store{path: path, readOnly: readonly}
+	if err := s.open(); err != nil {
+		_ = s.close()
+		return nil, err
+	}
+
+	s.refs = 1
+	_stores[path] = s
+
+	return s, nil
+}
+
+func (s *store) open() error {
+	if s.readOnly {
+		info, err := os.Stat(filepath.Join(s.path, dataFile))
+		if err != nil {
+			return err
+		}
+
+		if info.Size() == 0 {
+			return errors.New("store is not initialized")
+		}
+	} else {
+		if err := os.MkdirAll(s.path, 0o700); err != nil {
+			return err
+		}
+
+		lock, err := os.OpenFile(filepath.Join(s.path, writerLockFile), os.O_RDWR|os.O_CREATE, 0o600)
+		if err != nil {
+			return err
+		}
+
+		s.writerLock = lock
+
+		if err := tryLockFile(lock); err != nil {
+			return fmt.Errorf("store is already opened by another process: %w", err)
+		}
+	}
+
+	flag := os.O_RDWR | os.O_CREATE
+	if s.readOnly {
+		flag = os.O_RDONLY | os.O_CREATE
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.path, txLockFile), flag, 0o600)
+	if err != nil {
+		return err
+	}
+
+	s.txLock = &txLock{file: file}
+
+	if s.readOnly {
+		if err := s.txLock.rlock(); err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = s.txLock.runlock()
+		}()
+
+		if err := s.mmap(); err != nil {
+			return err
+		}
+
+		return s.db.View(func(tx *bolt.Tx) error {
+			return checkSchema(tx)
+		})
+	}
+
+	// Opening may initialize or grow the data file
+	if err := s.txLock.lock(); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = s.txLock.unlock()
+	}()
+
+	if err := s.mmap(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		return checkSchema(tx)
+	})
+}
+
+// mmap opens the bbolt handle and releases the bbolt file lock. See
+// openStore.
+func (s *store) mmap() error {
+	var file *os.File
+
+	opts := &bolt.Options{
+		Timeout:  openTimeout,
+		ReadOnly: s.readOnly,
+		OpenFile: func(name string, flag int, perm os.FileMode) (*os.File, error) {
+			var err error
+			file, err = os.OpenFile(name, flag, perm)
+			return file, err
+		},
+	}
+
+	db, err := bolt.Open(filepath.Join(s.path, dataFile), 0o600, opts)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err == nil {
+		err = unlockFile(file)
+	}
+
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	s.db = db
+	s.size = info.Size()
+
+	return nil
+}
+
+// refresh remaps a read-only store, if its data file has been grown by the
+// writer process. It must be called with the txLock held.
+func (s *store) refresh() error {
+	info, err := os.Stat(filepath.Join(s.path, dataFile))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil || info.Size() <= s.size {
+		return nil
+	}
+
+	// Close waits for the transactions running on the previous handle
+	prev := s.db
+	if err := s.mmap(); err != nil {
+		return err
+	}
+
+	return prev.Close()
+}
+
+// close releases the bbolt handle along with the lock files.
+func (s *store) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+
+	if s.db != nil {
+		err = s.db.Close()
+		s.db = nil
+	}
+
+	if s.txLock != nil {
+		_ = s.txLock.file.Close()
+	}
+
+	if s.writerLock != nil {
+		_ = s.writerLock.Close()
+	}
+
+	return err
+}
+
+// closeStores closes all the stores opened within the process.
+func closeStores() error {
+	_storesMu.Lock()
+	defer _storesMu.Unlock()
+
+	var err error
+
+	for path, s := range _stores {
+		if e := s.close(); e != nil {
+			err = e
+		}
+
+		delete(_stores, path)
+	}
+
+	return err
+}
+
+// checkSchema records the schema version of a new store, upgrades an older
+// one if writable, and refuses a store newer than the driver.
+func checkSchema(tx *bolt.Tx) error {
+	meta := tx.Bucket(metaBucket)
+	if meta == nil {
+		return errors.New("store is not initialized")
+	}
+
+	value := meta.Get(schemaKey)
+	if value == nil {
+		if !tx.Writable() {
+			return errors.New("schema version not found")
+		}
+
+		buf := make([]byte, 4)
+		binary.LittleEndian.PutUint32(buf, schemaVersion)
+
+		return meta.Put(schemaKey, buf)
+	}
+
+	if len(value) != 4 {
+		return errors.New("schema version malformed")
+	}
+
+	version := binary.LittleEndian.Uint32(value)
+	if version > schemaVersion {
+		return fmt.Errorf("%w: store version %d, supported version %d", database.ErrSchemaTooNew, version, schemaVersion)
+	}
+
+	if version == schemaVersion || !tx.Writable() {
+		return nil
+	}
+
+	// Version 2 indexes the Slash txs, in the slashes bucket
+	t := &transaction{writable: true, tx: tx}
+	if err := t.indexSlashes(); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, schemaVersion)
+
+	return meta.Put(schemaKey, buf)
+}
+
+// DB on top of underlying storage etcd-io/bbolt.
+type DB struct {
+	store *store
+
+	// Read-only mode provided at bbolt.DB level. If true, accepts read-only
+	// Transaction.
+	readOnly bool
+	closed   bool
+}
+
+// NewDatabase creates or opens the bbolt store located at the specified path.
+//
+// Unlike heavy driver, a read-only DB can be opened by a second process while
+// the node is running. The store must have been created beforehand by a
+// read-write open.
+func NewDatabase(path string, network protocol.Magic, readonly bool) (database.DB, error) {
+	s, err := openStore(path, readonly)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{store: s, readOnly: readonly}, nil
+}
+
+// Begin builds read-only or read-write Transaction.
+func (db *DB) Begin(writable bool) (database.Transaction, error) {
+	// If the database was opened with DB.readonly flag true, we cannot create
+	// a writable transaction
+	if db.readOnly && writable {
+		return nil, errors.New("database is read-only")
+	}
+
+	s := db.store
+
+	// A read-only process must not read while the writer commits
+	if s.readOnly {
+		if err := s.txLock.rlock(); err != nil {
+			return nil, err
+		}
+
+		if err := s.refresh(); err != nil {
+			_ = s.txLock.runlock()
+			return nil, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		tx  *bolt.Tx
+		err = errors.New("database is not open")
+	)
+
+	if s.db != nil {
+		tx, err = s.db.Begin(writable)
+	}
+
+	if err != nil {
+		if s.readOnly {
+			_ = s.txLock.runlock()
+		}
+
+		return nil, err
+	}
+
+	// Mind Transaction.Close() must be called when Transaction is done
+	t := &transaction{
+		writable: writable,
+		db:       db,
+		tx:       tx,
+		locked:   s.readOnly,
+	}
+
+	return t, nil
+}
+
+// Update a record within a transaction.
+func (db *DB) Update(fn func(database.Transaction) error) error {
+	// Create a writable transaction for atomic update.
+	t, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+
+	defer t.Close()
+
+	// If an error is returned from the function then rollback and return error.
+	// rollback here simply means to skip the commit step
+	if err := fn(t); err != nil {
+		return err
+	}
+
+	return t.Commit()
+}
+
+// View is the equivalent of a Select SQL statement.
+func (db *DB) View(fn func(database.Transaction) error) error {
+	t, err := db.Begin(false)
+	if err != nil {
+		return err
+	}
+
+	defer t.Close()
+	return fn(t)
+}
+
+// Close releases the DB instance. The underlying storage is closed once all
+// the DB instances opened on it are closed, or on closing the driver.
+func (db *DB) Close() error {
+	_storesMu.Lock()
+	defer _storesMu.Unlock()
+
+	if db.closed {
+		return nil
+	}
+
+	db.closed = true
+
+	s := db.store
+	s.refs--
+
+	if s.refs > 0 || _stores[s.path] != s {
+		return nil
+	}
+
+	delete(_stores, s.path)
+	return s.close()
+}
@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package bbolt
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	assert "github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// TestReadOnlyProcess opens the store bypassing the process-wide registry, as
+// a second process would do. flock locks are bound to the open file, so that
+// they conflict within the same process too.
+func TestReadOnlyProcess(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "bbolt_readonly_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	assert.NoError(storeBlocks(db, 0, 5))
+
+	// A second writer is refused
+	writer := &store{path: dir}
+	assert.Error(writer.open())
+	assert.NoError(writer.close())
+
+	reader := &store{path: dir, readOnly: true}
+	assert.NoError(reader.open())
+
+	defer func() {
+		_ = reader.close()
+	}()
+
+	rdb := &DB{store: reader, readOnly: true}
+	assert.Equal(uint64(4), tipHeight(t, rdb))
+
+	_, err = rdb.Begin(true)
+	assert.Error(err)
+
+	// Blocks stored after opening are visible, even if the data file grew
+	assert.NoError(storeBlocks(db, 5, 200))
+	assert.Equal(uint64(204), tipHeight(t, rdb))
+
+	assert.NoError(rdb.View(func(t database.Transaction) error {
+		_, err := t.FetchBlockHashByHeight(150)
+		return err
+	}))
+}
+
+func TestSchemaUpgrade(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "bbolt_schema_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	e := slashing.MockEvidence(2, 3)
+	blk := helper.RandomBlock(0, 1)
+	blk.Txs = append([]transactions.ContractCall{slashing.MockSlashTx(e)}, blk.Txs...)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(blk)
+	}))
+
+	// A store written at version 1 lacks the slashes bucket
+	assert.NoError(db.(*DB).store.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(slashesBucket); err != nil {
+			return err
+		}
+
+		return tx.Bucket(metaBucket).Put(schemaKey, []byte{1, 0, 0, 0})
+	}))
+
+	assert.NoError(db.Close())
+
+	// The Slash txs are indexed on opening it in read-write mode
+	db, err = NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		hash, err := t.FetchSlashBlock(e.PubKeyBLS, e.Round, e.Step)
+		assert.NoError(err)
+		assert.Equal(blk.Header.Hash, hash)
+		return nil
+	}))
+}
+
+func storeBlocks(db database.DB, from, count uint64) error {
+	return db.Update(func(t database.Transaction) error {
+		for height := from; height < from+count; height++ {
+			blk := helper.RandomBlock(height, 1)
+			if err := t.StoreBlock(blk); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func tipHeight(t *testing.T, db database.DB) uint64 {
+	var height uint64
+
+	err := db.View(func(tx database.Transaction) error {
+		var err error
+		height, err = tx.FetchCurrentHeight()
+		return err
+	})
+	assert.NoError(t, err)
+
+	return height
+}
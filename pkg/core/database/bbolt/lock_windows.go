@@ -0,0 +1,25 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package bbolt
+
+import "os"
+
+// Advisory locks are not implemented on Windows. As bbolt keeps the data file
+// locked for the lifetime of the handle, a store can not be opened by a second
+// process while the node is running.
+
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+func tryLockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
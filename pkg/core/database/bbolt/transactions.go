@@ -0,0 +1,936 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package bbolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// Buckets of the store. Refer to bbolt.md for an overview of the schema.
+
+	headersBucket      = []byte("headers")
+	txsBucket          = []byte("txs")
+	heightsBucket      = []byte("heights")
+	txIDsBucket        = []byte("txids")
+	keyImagesBucket    = []byte("keyimages")
+	outputsBucket      = []byte("outputs")
+	candidatesBucket   = []byte("candidates")
+	timestampsBucket   = []byte("timestamps")
+	txTypesBucket      = []byte("txtypes")
+	evidenceBucket     = []byte("evidence")
+	roundsBucket       = []byte("evidencerounds")
+	provisionersBucket = []byte("provisioners")
+	slashesBucket      = []byte("slashes")
+	metaBucket         = []byte("meta")
+
+	buckets = [][]byte{
+		headersBucket, txsBucket, heightsBucket, txIDsBucket, keyImagesBucket,
+		outputsBucket, candidatesBucket, timestampsBucket, txTypesBucket,
+		evidenceBucket, roundsBucket, provisionersBucket, slashesBucket,
+		metaBucket,
+	}
+
+	// Keys of the meta bucket.
+	stateKey  = []byte("state")
+	schemaKey = []byte("schema")
+)
+
+type transaction struct {
+	writable bool
+	db       *DB
+	tx       *bolt.Tx
+
+	// locked is set if the txLock is held by the transaction. See
+	// DB.Begin.
+	locked bool
+	closed bool
+}
+
+// heightKey builds the key of the heights bucket. Unlike heavy driver, all
+// integer keys are big endian encoded, so that the bbolt key ordering matches
+// the numerical one.
+func heightKey(height uint64) []byte {
+	return appendUint64(make([]byte, 0, 8), height)
+}
+
+// blockTxKey builds the key of the txs bucket.
+//
+// Key = block.header.hash + txID
+// Value = index + block.transaction[index]
+func blockTxKey(hash, txID []byte) []byte {
+	key := make([]byte, 0, len(hash)+len(txID))
+	key = append(key, hash...)
+	return append(key, txID...)
+}
+
+// timestampKey builds the key of the timestamps bucket.
+//
+// Key = timestamp + height
+// Value = block.header.hash
+func timestampKey(timestamp int64, height uint64) []byte {
+	if timestamp < 0 {
+		timestamp = 0
+	}
+
+	key := make([]byte, 0, 16)
+	key = appendUint64(key, uint64(timestamp))
+	return appendUint64(key, height)
+}
+
+// txTypeKey builds the key of the txtypes bucket.
+//
+// Key = tx.type + height + tx.index
+// Value = txID
+func txTypeKey(txType transactions.TxType, height uint64, index uint32) []byte {
+	key := make([]byte, 0, 16)
+	key = appendUint32(key, uint32(txType))
+	key = appendUint64(key, height)
+	return appendUint32(key, index)
+}
+
+// evidenceRoundKey builds the key of the evidencerounds bucket.
+//
+// Key = round + evidence.hash
+// Value = empty
+func evidenceRoundKey(round uint64, hash []byte) []byte {
+	key := make([]byte, 0, 8+len(hash))
+	key = appendUint64(key, round)
+	return append(key, hash...)
+}
+
+// slashKey builds the key of the slashes bucket.
+//
+// Key = round + step + pubkey
+// Value = block.header.hash
+func slashKey(round uint64, step uint8, pubKey []byte) []byte {
+	key := make([]byte, 0, 9+len(pubKey))
+	key = appendUint64(key, round)
+	key = append(key, step)
+	return append(key, pubKey...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// StoreBlock stores the entire block data into storage. No validations are
+// applied. As bbolt transactions are atomic, storage state changes only when
+// Commit() is called on Transaction completion.
+func (t *transaction) StoreBlock(b *block.Block) error {
+	if !t.writable {
+		return errors.New("StoreBlock cannot be called on read-only transaction")
+	}
+
+	if len(b.Header.Hash) != block.HeaderHashSize {
+		return fmt.Errorf("header hash size is %d but it must be %d", len(b.Header.Hash), block.HeaderHashSize)
+	}
+
+	if uint64(len(b.Txs)) > math.MaxUint32 {
+		return errors.New("too many transactions")
+	}
+
+	blockHeaderFields := new(bytes.Buffer)
+	if err := message.MarshalHeader(blockHeaderFields, b.Header); err != nil {
+		return err
+	}
+
+	if err := t.put(headersBucket, b.Header.Hash, blockHeaderFields.Bytes()); err != nil {
+		return err
+	}
+
+	for i, tx := range b.Txs {
+		txID, err := tx.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		if len(txID) == 0 {
+			return fmt.Errorf("empty chain tx id")
+		}
+
+		entry, err := utils.EncodeBlockTx(tx, uint32(i))
+		if err != nil {
+			return err
+		}
+
+		if err := t.put(txsBucket, blockTxKey(b.Header.Hash, txID), entry); err != nil {
+			return err
+		}
+
+		if err := t.put(txIDsBucket, txID, b.Header.Hash); err != nil {
+			return err
+		}
+
+		if err := t.put(txTypesBucket, txTypeKey(tx.Type(), b.Header.Height, uint32(i)), txID); err != nil {
+			return err
+		}
+
+		if err := t.storeSlash(tx, b.Header.Hash); err != nil {
+			return err
+		}
+	}
+
+	if err := t.put(heightsBucket, heightKey(b.Header.Height), b.Header.Hash); err != nil {
+		return err
+	}
+
+	if err := t.put(timestampsBucket, timestampKey(b.Header.Timestamp, b.Header.Height), b.Header.Hash); err != nil {
+		return err
+	}
+
+	return t.put(metaBucket, stateKey, b.Header.Hash)
+}
+
+// DeleteBlock removes the block data stored by StoreBlock and resets the chain
+// tip to the block parent.
+//
+// Index entries are removed only if they still point at this block, as a tx or
+// a height could be already claimed by a competing block. Key images are not
+// written by StoreBlock, so there are none to remove.
+func (t *transaction) DeleteBlock(hash []byte) error {
+	if !t.writable {
+		return errors.New("DeleteBlock cannot be called on read-only transaction")
+	}
+
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	if header.Height == 0 {
+		return errors.New("genesis block cannot be deleted")
+	}
+
+	// Keys are collected first, as deleting while iterating a bbolt cursor
+	// may skip entries
+	txKeys := make([][]byte, 0)
+
+	c := t.tx.Bucket(txsBucket).Cursor()
+	for k, v := c.Seek(hash); k != nil && bytes.HasPrefix(k, hash); k, v = c.Next() {
+		key := append([]byte{}, k...)
+		txID := key[len(hash):]
+
+		txKeys = append(txKeys, key)
+
+		tx, txIndex, err := utils.DecodeBlockTx(v, database.AnyTxType)
+		if err != nil {
+			return err
+		}
+
+		if err := t.removeIfEqual(txIDsBucket, txID, hash); err != nil {
+			return err
+		}
+
+		if err := t.removeIfEqual(txTypesBucket, txTypeKey(tx.Type(), header.Height, txIndex), txID); err != nil {
+			return err
+		}
+
+		if err := t.deleteSlash(tx, hash); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range txKeys {
+		if err := t.remove(txsBucket, k); err != nil {
+			return err
+		}
+	}
+
+	if err := t.removeIfEqual(timestampsBucket, timestampKey(header.Timestamp, header.Height), hash); err != nil {
+		return err
+	}
+
+	if err := t.removeIfEqual(heightsBucket, heightKey(header.Height), hash); err != nil {
+		return err
+	}
+
+	if err := t.remove(headersBucket, hash); err != nil {
+		return err
+	}
+
+	// Chain tip moves back to the parent block
+	return t.put(metaBucket, stateKey, header.PrevBlockHash)
+}
+
+// RevertToHeight deletes all blocks above height, one by one starting from the
+// chain tip, along with the provisioner sets stored above height, and resets
+// the chain tip to the block at height.
+func (t *transaction) RevertToHeight(height uint64) error {
+	if !t.writable {
+		return errors.New("RevertToHeight cannot be called on read-only transaction")
+	}
+
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	if height > tip {
+		return fmt.Errorf("cannot revert to height %d above the chain tip %d", height, tip)
+	}
+
+	hash, err := t.FetchBlockHashByHeight(height)
+	if err != nil {
+		return err
+	}
+
+	for h := tip; h > height; h-- {
+		blockHash, err := t.FetchBlockHashByHeight(h)
+		if err != nil {
+			return err
+		}
+
+		if err := t.DeleteBlock(blockHash); err != nil {
+			return err
+		}
+	}
+
+	// The provisioner sets of the deleted blocks are not in effect anymore
+	if err := t.deleteProvisionersAbove(height); err != nil {
+		return err
+	}
+
+	return t.put(metaBucket, stateKey, hash)
+}
+
+// Commit writes the transaction changes to the bbolt file. Read-only processes
+// are held off meanwhile, see txLock.
+func (t *transaction) Commit() error {
+	if !t.writable {
+		return errors.New("read-only transaction cannot commit changes")
+	}
+
+	if t.closed {
+		return errors.New("already closed transaction cannot commit changes")
+	}
+
+	txLock := t.db.store.txLock
+	if err := txLock.lock(); err != nil {
+		return err
+	}
+
+	err := t.tx.Commit()
+	t.closed = true
+
+	if unlockErr := txLock.unlock(); err == nil {
+		err = unlockErr
+	}
+
+	return err
+}
+
+// Rollback discards the transaction changes.
+func (t *transaction) Rollback() error {
+	if t.closed {
+		return nil
+	}
+
+	t.closed = true
+	return t.tx.Rollback()
+}
+
+// Close rolls back the transaction, if not committed, and releases the
+// txLock. It must be called explicitly when a transaction is run in a
+// unmanaged way.
+func (t *transaction) Close() {
+	_ = t.Rollback()
+
+	if t.locked {
+		t.locked = false
+		_ = t.db.store.txLock.runlock()
+	}
+}
+
+// get returns a copy of the value stored at key, or nil if not found. Values
+// returned by bbolt are valid only for the life of the transaction.
+func (t *transaction) get(bucket, key []byte) []byte {
+	b := t.tx.Bucket(bucket)
+	if b == nil {
+		return nil
+	}
+
+	v := b.Get(key)
+	if v == nil {
+		return nil
+	}
+
+	value := make([]byte, len(v))
+	copy(value, v)
+
+	return value
+}
+
+func (t *transaction) put(bucket, key, value []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	return t.tx.Bucket(bucket).Put(key, value)
+}
+
+func (t *transaction) remove(bucket, key []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	return t.tx.Bucket(bucket).Delete(key)
+}
+
+// removeIfEqual deletes a key only if its currently stored value is equal to
+// the expected one.
+func (t *transaction) removeIfEqual(bucket, key, expected []byte) error {
+	if value := t.get(bucket, key); value != nil && bytes.Equal(value, expected) {
+		return t.remove(bucket, key)
+	}
+
+	return nil
+}
+
+func (t *transaction) FetchBlockExists(hash []byte) (bool, error) {
+	if t.get(headersBucket, hash) == nil {
+		return false, database.ErrBlockNotFound
+	}
+
+	return true, nil
+}
+
+// FetchOutputExists checks if an output exists in the db.
+func (t *transaction) FetchOutputExists(destkey []byte) (bool, error) {
+	if t.get(outputsBucket, destkey) == nil {
+		return false, database.ErrOutputNotFound
+	}
+
+	return true, nil
+}
+
+// FetchOutputUnlockHeight returns the unlockheight of an output.
+func (t *transaction) FetchOutputUnlockHeight(destkey []byte) (uint64, error) {
+	value := t.get(outputsBucket, destkey)
+	if value == nil {
+		return 0, database.ErrOutputNotFound
+	}
+
+	if len(value) != 8 {
+		return 0, errors.New("unlock height malformed")
+	}
+
+	return binary.LittleEndian.Uint64(value), nil
+}
+
+func (t *transaction) FetchBlockHeader(hash []byte) (*block.Header, error) {
+	value := t.get(headersBucket, hash)
+	if value == nil {
+		return nil, database.ErrBlockNotFound
+	}
+
+	header := block.NewHeader()
+	if err := message.UnmarshalHeader(bytes.NewBuffer(value), header); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (t *transaction) FetchBlockTxs(hash []byte) ([]transactions.ContractCall, error) {
+	tempTxs := make(map[uint32]transactions.ContractCall)
+
+	// Read all the transactions that belong to a single block
+	c := t.tx.Bucket(txsBucket).Cursor()
+	for k, v := c.Seek(hash); k != nil && bytes.HasPrefix(k, hash); k, v = c.Next() {
+		tx, txIndex, err := utils.DecodeBlockTx(v, database.AnyTxType)
+		if err != nil {
+			return nil, err
+		}
+
+		// If we don't fetch the correct indexes (tx positions), merkle tree
+		// changes and as result we've got new block hash
+		if _, ok := tempTxs[txIndex]; ok {
+			return nil, errors.New("duplicated tx index")
+		}
+
+		tempTxs[txIndex] = tx
+	}
+
+	// Reorder Tx slice as per retrieved indexes
+	resultTxs := make([]transactions.ContractCall, len(tempTxs))
+	for k, v := range tempTxs {
+		if int(k) >= len(resultTxs) {
+			return nil, errors.New("missing tx index")
+		}
+
+		resultTxs[k] = v
+	}
+
+	// Let's ensure coinbase tx is here
+	if len(resultTxs) > 0 {
+		// NOTE: coinbase is the last tx in the block
+		if resultTxs[len(resultTxs)-1].Type() != transactions.Distribute {
+			return resultTxs, errors.New("missing coinbase tx")
+		}
+	}
+
+	return resultTxs, nil
+}
+
+func (t *transaction) FetchBlockHashByHeight(height uint64) ([]byte, error) {
+	hash := t.get(heightsBucket, heightKey(height))
+	if hash == nil {
+		return nil, database.ErrBlockNotFound
+	}
+
+	return hash, nil
+}
+
+func (t *transaction) FetchBlockTxByHash(txID []byte) (transactions.ContractCall, uint32, []byte, error) {
+	txIndex := uint32(math.MaxUint32)
+
+	// Fetch the block header hash that this Tx belongs to
+	hash := t.get(txIDsBucket, txID)
+	if hash == nil {
+		return nil, txIndex, nil, database.ErrTxNotFound
+	}
+
+	value := t.get(txsBucket, blockTxKey(hash, txID))
+	if value == nil {
+		return nil, txIndex, nil, errors.New("block tx is available but fetching it fails")
+	}
+
+	tx, idx, err := utils.DecodeBlockTx(value, database.AnyTxType)
+	if err != nil {
+		return nil, idx, hash, err
+	}
+
+	return tx, idx, hash, nil
+}
+
+// FetchKeyImageExists checks if the KeyImage exists. If so, it also returns the
+// hash of its corresponding tx.
+func (t *transaction) FetchKeyImageExists(keyImage []byte) (bool, []byte, error) {
+	txID := t.get(keyImagesBucket, keyImage)
+	if txID == nil {
+		return false, nil, database.ErrKeyImageNotFound
+	}
+
+	return true, txID, nil
+}
+
+func (t *transaction) FetchBlock(hash []byte) (*block.Block, error) {
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := t.FetchBlockTxs(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.Block{
+		Header: header,
+		Txs:    txs,
+	}, nil
+}
+
+func (t *transaction) FetchState() (*database.State, error) {
+	value := t.get(metaBucket, stateKey)
+	if len(value) == 0 {
+		return nil, database.ErrStateNotFound
+	}
+
+	return &database.State{TipHash: value}, nil
+}
+
+func (t *transaction) FetchCurrentHeight() (uint64, error) {
+	state, err := t.FetchState()
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := t.FetchBlockHeader(state.TipHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Height, nil
+}
+
+// FetchBlockHeightSince looks up the timestamps bucket for a block height.
+func (t *transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (uint64, error) {
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	n := uint64(math.Min(float64(tip), float64(offset)))
+
+	height, err := t.FetchBlockHeightByTimestamp(sinceUnixTime)
+	if err == database.ErrBlockNotFound {
+		return tip, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if height < tip-n {
+		height = tip - n
+	}
+
+	return height, nil
+}
+
+// FetchPrunedHeight always returns 0, as bbolt driver does not support
+// pruning.
+func (t *transaction) FetchPrunedHeight() (uint64, error) {
+	return 0, nil
+}
+
+// FetchBlockHeightByTimestamp returns the height of the first block whose
+// timestamp is greater than or equal to unixTime.
+func (t *transaction) FetchBlockHeightByTimestamp(unixTime int64) (uint64, error) {
+	k, _ := t.tx.Bucket(timestampsBucket).Cursor().Seek(timestampKey(unixTime, 0))
+	if k == nil {
+		return 0, database.ErrBlockNotFound
+	}
+
+	return binary.BigEndian.Uint64(k[len(k)-8:]), nil
+}
+
+// FetchTxIDsByType returns the IDs of the txs of txType, stored within the
+// [fromHeight, toHeight] range. Most recent txs are returned first.
+func (t *transaction) FetchTxIDsByType(txType transactions.TxType, fromHeight, toHeight uint64, limit int) ([][]byte, error) {
+	if fromHeight > toHeight {
+		return nil, errors.New("invalid height range")
+	}
+
+	first := txTypeKey(txType, fromHeight, 0)
+	last := txTypeKey(txType, toHeight, math.MaxUint32)
+
+	// Position the cursor on the last key within the range
+	c := t.tx.Bucket(txTypesBucket).Cursor()
+
+	k, v := c.Seek(last)
+
+	switch {
+	case k == nil:
+		k, v = c.Last()
+	case bytes.Compare(k, last) > 0:
+		k, v = c.Prev()
+	}
+
+	txIDs := make([][]byte, 0)
+
+	for ; k != nil && bytes.Compare(k, first) >= 0; k, v = c.Prev() {
+		txID := make([]byte, len(v))
+		copy(txID, v)
+
+		txIDs = append(txIDs, txID)
+
+		if limit > 0 && len(txIDs) >= limit {
+			break
+		}
+	}
+
+	return txIDs, nil
+}
+
+func (t *transaction) StoreCandidateMessage(cm block.Block) error {
+	buf := new(bytes.Buffer)
+	if err := message.MarshalBlock(buf, &cm); err != nil {
+		return err
+	}
+
+	return t.put(candidatesBucket, cm.Header.Hash, buf.Bytes())
+}
+
+func (t *transaction) FetchCandidateMessage(hash []byte) (block.Block, error) {
+	value := t.get(candidatesBucket, hash)
+	if value == nil {
+		return block.Block{}, database.ErrBlockNotFound
+	}
+
+	cm := block.NewBlock()
+	if err := message.UnmarshalBlock(bytes.NewBuffer(value), cm); err != nil {
+		return block.Block{}, err
+	}
+
+	return *cm, nil
+}
+
+func (t *transaction) ClearCandidateMessages() error {
+	return t.clearBucket(candidatesBucket)
+}
+
+// StoreEvidence stores the evidence of an equivocation by hash.
+func (t *transaction) StoreEvidence(e *slashing.Evidence) error {
+	hash, err := e.Hash()
+	if err != nil {
+		return err
+	}
+
+	if t.get(evidenceBucket, hash) != nil {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := slashing.Marshal(buf, *e); err != nil {
+		return err
+	}
+
+	if err := t.put(evidenceBucket, hash, buf.Bytes()); err != nil {
+		return err
+	}
+
+	return t.put(roundsBucket, evidenceRoundKey(e.Round, hash), []byte{})
+}
+
+// FetchEvidence returns the evidence of an equivocation by hash.
+func (t *transaction) FetchEvidence(hash []byte) (*slashing.Evidence, error) {
+	value := t.get(evidenceBucket, hash)
+	if value == nil {
+		return nil, database.ErrEvidenceNotFound
+	}
+
+	e := new(slashing.Evidence)
+	if err := slashing.Unmarshal(bytes.NewBuffer(value), e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FetchEvidenceByRound returns the evidence of the equivocations committed
+// within the [fromRound, toRound] range.
+func (t *transaction) FetchEvidenceByRound(fromRound, toRound uint64) ([]slashing.Evidence, error) {
+	if fromRound > toRound {
+		return nil, errors.New("invalid round range")
+	}
+
+	list := make([]slashing.Evidence, 0)
+
+	// A store written by a previous version may lack the bucket, if opened
+	// in read-only mode
+	b := t.tx.Bucket(roundsBucket)
+	if b == nil {
+		return list, nil
+	}
+
+	c := b.Cursor()
+
+	for k, _ := c.Seek(appendUint64(nil, fromRound)); k != nil; k, _ = c.Next() {
+		if binary.BigEndian.Uint64(k[:8]) > toRound {
+			break
+		}
+
+		e, err := t.FetchEvidence(k[8:])
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, *e)
+	}
+
+	return list, nil
+}
+
+// storeSlash indexes the equivocation reported by a Slash tx of the block. A
+// Slash tx carrying a malformed evidence reports nothing.
+func (t *transaction) storeSlash(tx transactions.ContractCall, hash []byte) error {
+	if tx.Type() != transactions.Slash {
+		return nil
+	}
+
+	e, err := slashing.Decode(tx)
+	if err != nil {
+		return nil
+	}
+
+	return t.put(slashesBucket, slashKey(e.Round, e.Step, e.PubKeyBLS), hash)
+}
+
+// deleteSlash removes the index entry of a Slash tx of the block, if it still
+// points at it.
+func (t *transaction) deleteSlash(tx transactions.ContractCall, hash []byte) error {
+	if tx.Type() != transactions.Slash {
+		return nil
+	}
+
+	e, err := slashing.Decode(tx)
+	if err != nil {
+		return nil
+	}
+
+	return t.removeIfEqual(slashesBucket, slashKey(e.Round, e.Step, e.PubKeyBLS), hash)
+}
+
+// indexSlashes indexes the Slash txs of the stored blocks, out of the txtypes
+// bucket.
+func (t *transaction) indexSlashes() error {
+	prefix := appendUint32(nil, uint32(transactions.Slash))
+
+	c := t.tx.Bucket(txTypesBucket).Cursor()
+	for k, txID := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, txID = c.Next() {
+		hash := t.get(heightsBucket, k[len(prefix):len(prefix)+8])
+		if hash == nil {
+			continue
+		}
+
+		entry := t.get(txsBucket, blockTxKey(hash, txID))
+		if entry == nil {
+			continue
+		}
+
+		tx, _, err := utils.DecodeBlockTx(entry, transactions.Slash)
+		if err != nil {
+			return err
+		}
+
+		if err := t.storeSlash(tx, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FetchSlashBlock returns the hash of the block whose Slash tx reported the
+// equivocation of the provisioner at round and step.
+func (t *transaction) FetchSlashBlock(pubKey []byte, round uint64, step uint8) ([]byte, error) {
+	// A store written by a previous version may lack the bucket, if opened
+	// in read-only mode
+	hash := t.get(slashesBucket, slashKey(round, step, pubKey))
+	if hash == nil {
+		return nil, database.ErrSlashNotFound
+	}
+
+	return hash, nil
+}
+
+// StoreProvisioners records the provisioner set in effect after the block at
+// height.
+func (t *transaction) StoreProvisioners(height uint64, p *user.Provisioners) error {
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, p); err != nil {
+		return err
+	}
+
+	return t.put(provisionersBucket, heightKey(height), buf.Bytes())
+}
+
+// FetchProvisioners returns the most recent provisioner set stored at or
+// below height.
+func (t *transaction) FetchProvisioners(height uint64) (*user.Provisioners, error) {
+	// A store written by a previous version may lack the bucket, if opened
+	// in read-only mode
+	b := t.tx.Bucket(provisionersBucket)
+	if b == nil {
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	c := b.Cursor()
+
+	var k, v []byte
+	if height < math.MaxUint64 {
+		// Step back from the first set above height
+		if k, _ = c.Seek(heightKey(height + 1)); k != nil {
+			k, v = c.Prev()
+		} else {
+			k, v = c.Last()
+		}
+	} else {
+		k, v = c.Last()
+	}
+
+	if k == nil {
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	p, err := user.UnmarshalProvisioners(bytes.NewBuffer(append([]byte(nil), v...)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// deleteProvisionersAbove deletes the provisioner sets stored above height.
+func (t *transaction) deleteProvisionersAbove(height uint64) error {
+	b := t.tx.Bucket(provisionersBucket)
+	if b == nil || height == math.MaxUint64 {
+		return nil
+	}
+
+	// Keys are collected first, as deleting moves the cursor
+	var keys [][]byte
+
+	c := b.Cursor()
+	for k, _ := c.Seek(heightKey(height + 1)); k != nil; k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+
+	for _, k := range keys {
+		if err := t.remove(provisionersBucket, k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClearDatabase will wipe all of the data currently in the database. The schema
+// version is kept, as the layout stays the same.
+func (t *transaction) ClearDatabase() error {
+	for _, name := range buckets {
+		if bytes.Equal(name, metaBucket) {
+			continue
+		}
+
+		if err := t.clearBucket(name); err != nil {
+			return err
+		}
+	}
+
+	return t.remove(metaBucket, stateKey)
+}
+
+// clearBucket drops all entries of a bucket by recreating it.
+func (t *transaction) clearBucket(name []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	if err := t.tx.DeleteBucket(name); err != nil {
+		return err
+	}
+
+	_, err := t.tx.CreateBucket(name)
+	return err
+}
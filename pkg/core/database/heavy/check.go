@@ -0,0 +1,612 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Kinds of the issues reported by Check.
+const (
+	IssueMissingState    = "missing_state"
+	IssueMissingHeight   = "missing_height_index"
+	IssueDanglingHeight  = "dangling_height_index"
+	IssueMissingHeader   = "missing_header"
+	IssueMalformedHeader = "malformed_header"
+	IssueHeightMismatch  = "height_mismatch"
+	IssueHashMismatch    = "hash_mismatch"
+	IssueBrokenLinkage   = "broken_linkage"
+	IssueTxRootMismatch  = "txroot_mismatch"
+	IssueMalformedTx     = "malformed_tx"
+	IssueMissingTxID     = "missing_txid_index"
+	IssueDanglingTxID    = "dangling_txid_index"
+	IssueOrphanHeader    = "orphan_header"
+	IssueMissingIndexes  = "missing_secondary_indexes"
+)
+
+const (
+	// maxIssuesPerKind limits the size of the report on a badly corrupted
+	// store.
+	maxIssuesPerKind = 1000
+
+	checkProgressInterval = 10000
+)
+
+// Issue is a single inconsistency found by Check.
+type Issue struct {
+	Kind   string `json:"kind"`
+	Height uint64 `json:"height"`
+	Hash   string `json:"hash,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// CheckReport is the machine-readable outcome of Check.
+type CheckReport struct {
+	Tip          uint64  `json:"tip"`
+	TipHash      string  `json:"tiphash"`
+	PrunedHeight uint64  `json:"prunedheight"`
+	Blocks       uint64  `json:"blocks"`
+	Txs          uint64  `json:"txs"`
+	Issues       []Issue `json:"issues"`
+	// Truncated is set if some issues were not reported, as there were too
+	// many of the same kind.
+	Truncated bool `json:"truncated"`
+	// HistoryHeight is the height from which the evidence and the
+	// provisioner sets are stored, if the store predates them. They can not
+	// be repaired, only rebuilt by syncing the chain again.
+	HistoryHeight uint64 `json:"historyheight,omitempty"`
+	// Repaired is set if Repair was run. Remaining lists the issues found
+	// again after repairing.
+	Repaired  bool    `json:"repaired"`
+	Remaining []Issue `json:"remaining,omitempty"`
+
+	counts map[string]int
+}
+
+// OK returns true if no issue was found.
+func (r *CheckReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *CheckReport) add(kind string, height uint64, hash []byte, format string, args ...interface{}) {
+	if r.counts == nil {
+		r.counts = make(map[string]int)
+	}
+
+	r.counts[kind]++
+	if r.counts[kind] > maxIssuesPerKind {
+		r.Truncated = true
+		return
+	}
+
+	r.Issues = append(r.Issues, Issue{
+		Kind:   kind,
+		Height: height,
+		Hash:   hex.EncodeToString(hash),
+		Detail: fmt.Sprintf(format, args...),
+	})
+}
+
+// Check walks the entire store and verifies the consistency of the chain
+// data: header hashes and linkage, TxRoot of the block bodies, the
+// height<->hash index, the TxID->block back-references and the chain tip
+// state. It does not modify the store.
+func Check(db database.DB) (*CheckReport, error) {
+	heavyDB, ok := db.(DB)
+	if !ok {
+		return nil, errNotHeavy
+	}
+
+	snapshot, err := heavyDB.storage.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	defer snapshot.Release()
+
+	t := transaction{db: &heavyDB, snapshot: snapshot}
+	r := &CheckReport{Issues: make([]Issue, 0)}
+
+	if r.PrunedHeight, err = t.FetchPrunedHeight(); err != nil {
+		return nil, err
+	}
+
+	if r.HistoryHeight, err = fetchHistoryHeight(snapshot); err != nil {
+		return nil, err
+	}
+
+	// Chain tip
+	state, err := t.FetchState()
+	if err != nil {
+		r.add(IssueMissingState, 0, nil, "%v", err)
+		r.Tip = t.highestIndexedHeight()
+	} else {
+		r.TipHash = hex.EncodeToString(state.TipHash)
+
+		header, err := t.FetchBlockHeader(state.TipHash)
+		if err != nil {
+			r.add(IssueMissingState, 0, state.TipHash, "chain tip header: %v", err)
+			r.Tip = t.highestIndexedHeight()
+		} else {
+			r.Tip = header.Height
+		}
+	}
+
+	mainChain, err := t.checkChain(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.checkOrphans(r, mainChain); err != nil {
+		return nil, err
+	}
+
+	if heavyDB.indexes {
+		built, err := snapshot.Has(IndexedPrefix, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if !built {
+			r.add(IssueMissingIndexes, 0, nil, "secondary indexes are enabled but not built")
+		}
+	}
+
+	return r, nil
+}
+
+// checkChain walks the chain from genesis up to the tip. It returns the set of
+// block hashes found on the height index.
+func (t transaction) checkChain(r *CheckReport) (map[string]struct{}, error) {
+	mainChain := make(map[string]struct{})
+
+	var prevHash []byte
+
+	for height := uint64(0); height <= r.Tip; height++ {
+		if height > 0 && height%checkProgressInterval == 0 {
+			log.WithField("height", height).WithField("tip", r.Tip).Info("checking chain")
+		}
+
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			r.add(IssueMissingHeight, height, nil, "%v", err)
+			prevHash = nil
+			continue
+		}
+
+		mainChain[string(hash)] = struct{}{}
+
+		header, err := t.FetchBlockHeader(hash)
+		if err == database.ErrBlockNotFound {
+			r.add(IssueMissingHeader, height, hash, "header not found")
+			prevHash = nil
+			continue
+		}
+
+		if err != nil {
+			r.add(IssueMalformedHeader, height, hash, "%v", err)
+			prevHash = nil
+			continue
+		}
+
+		r.Blocks++
+
+		if header.Height != height {
+			r.add(IssueHeightMismatch, height, hash, "header height is %d", header.Height)
+		}
+
+		calculated, err := header.CalculateHash()
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(calculated, hash) {
+			r.add(IssueHashMismatch, height, hash, "calculated hash %s", hex.EncodeToString(calculated))
+		}
+
+		if height > 0 && prevHash != nil && !bytes.Equal(header.PrevBlockHash, prevHash) {
+			r.add(IssueBrokenLinkage, height, hash, "previous block hash %s does not match %s",
+				hex.EncodeToString(header.PrevBlockHash), hex.EncodeToString(prevHash))
+		}
+
+		prevHash = hash
+
+		if height > 0 && height <= r.PrunedHeight {
+			continue
+		}
+
+		if err := t.checkBlockTxs(r, header); err != nil {
+			return nil, err
+		}
+	}
+
+	// Height index entries above the tip
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeightPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		height, ok := indexedHeight(iterator.Key())
+		if !ok {
+			r.add(IssueDanglingHeight, 0, iterator.Value(), "malformed height key")
+			continue
+		}
+
+		if height > r.Tip {
+			r.add(IssueDanglingHeight, height, iterator.Value(), "height above the chain tip %d", r.Tip)
+		}
+	}
+
+	return mainChain, iterator.Error()
+}
+
+// checkBlockTxs verifies the TxRoot of a block against its stored txs, and
+// that each tx is referenced back by the TxID index.
+func (t transaction) checkBlockTxs(r *CheckReport, header *block.Header) error {
+	scanFilter := append(TxPrefix, header.Hash...)
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(scanFilter), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		txID := iterator.Key()[len(scanFilter):]
+		r.Txs++
+
+		value, err := t.snapshot.Get(append(TxIDPrefix, txID...), nil)
+
+		switch {
+		case err == leveldb.ErrNotFound:
+			r.add(IssueMissingTxID, header.Height, header.Hash, "tx %s", hex.EncodeToString(txID))
+		case err != nil:
+			return err
+		case !bytes.Equal(value, header.Hash):
+			r.add(IssueMissingTxID, header.Height, header.Hash, "tx %s references block %s",
+				hex.EncodeToString(txID), hex.EncodeToString(value))
+		}
+	}
+
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+
+	txs, err := t.FetchBlockTxs(header.Hash)
+	if err != nil {
+		r.add(IssueMalformedTx, header.Height, header.Hash, "%v", err)
+		return nil
+	}
+
+	if len(txs) == 0 {
+		return nil
+	}
+
+	blk := &block.Block{Header: header, Txs: txs}
+
+	root, err := blk.CalculateRoot()
+	if err != nil {
+		r.add(IssueMalformedTx, header.Height, header.Hash, "%v", err)
+		return nil
+	}
+
+	if !bytes.Equal(root, header.TxRoot) {
+		r.add(IssueTxRootMismatch, header.Height, header.Hash, "calculated root %s", hex.EncodeToString(root))
+	}
+
+	return nil
+}
+
+// checkOrphans reports the headers which are not part of the main chain, and
+// the TxID entries which point at no stored tx.
+func (t transaction) checkOrphans(r *CheckReport, mainChain map[string]struct{}) error {
+	headers := t.snapshot.NewIterator(util.BytesPrefix(HeaderPrefix), nil)
+	defer headers.Release()
+
+	for headers.Next() {
+		hash := headers.Key()[len(HeaderPrefix):]
+		if _, ok := mainChain[string(hash)]; !ok {
+			r.add(IssueOrphanHeader, 0, hash, "header not referenced by the height index")
+		}
+	}
+
+	if err := headers.Error(); err != nil {
+		return err
+	}
+
+	txIDs := t.snapshot.NewIterator(util.BytesPrefix(TxIDPrefix), nil)
+	defer txIDs.Release()
+
+	for txIDs.Next() {
+		txID := txIDs.Key()[len(TxIDPrefix):]
+		hash := txIDs.Value()
+
+		key := append(append(TxPrefix, hash...), txID...)
+
+		exists, err := t.snapshot.Has(key, nil)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			r.add(IssueDanglingTxID, 0, hash, "tx %s not found in the referenced block", hex.EncodeToString(txID))
+		}
+	}
+
+	return txIDs.Error()
+}
+
+// highestIndexedHeight returns the highest height found on the height index.
+// It is used as chain tip when the chain state is missing.
+func (t transaction) highestIndexedHeight() uint64 {
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeightPrefix), nil)
+	defer iterator.Release()
+
+	var tip uint64
+
+	for iterator.Next() {
+		height, ok := indexedHeight(iterator.Key())
+		if ok && height > tip {
+			tip = height
+		}
+	}
+
+	return tip
+}
+
+// indexedHeight decodes the height of a height index key. Heights are little
+// endian encoded, see utils.WriteUint64.
+func indexedHeight(key []byte) (uint64, bool) {
+	if len(key) != len(HeightPrefix)+8 {
+		return 0, false
+	}
+
+	return binary.LittleEndian.Uint64(key[len(HeightPrefix):]), true
+}
+
+// Repair rebuilds the indexes derived from block headers and bodies: the
+// chain tip state, the height index, the TxID index and the secondary indexes.
+//
+// The main chain is rebuilt by walking back from the highest stored header,
+// following the previous block hashes, down to genesis. The headers off the
+// main chain, reported as orphans by Check, are deleted along with their txs.
+// The headers and txs of the main chain are never deleted, as they are the
+// source of the rebuilt data.
+//
+// All the changes are written in a single batch, so that a crash never leaves
+// the store half repaired. The secondary indexes are built afterwards, and are
+// built again on the next start if it is interrupted.
+func Repair(db database.DB) error {
+	heavyDB, ok := db.(DB)
+	if !ok {
+		return errNotHeavy
+	}
+
+	if heavyDB.readOnly {
+		return errors.New("database is read-only")
+	}
+
+	snapshot, err := heavyDB.storage.GetSnapshot()
+	if err != nil {
+		return err
+	}
+
+	defer snapshot.Release()
+
+	t := transaction{db: &heavyDB, snapshot: snapshot}
+
+	chain, err := t.findMainChain()
+	if err != nil {
+		return err
+	}
+
+	log.WithField("tip", len(chain)-1).Info("repairing derived indexes")
+
+	batch := new(leveldb.Batch)
+
+	// Drop all derived entries
+	for _, prefix := range [][]byte{HeightPrefix, TxIDPrefix, TimestampPrefix, TxTypePrefix, IndexedPrefix, StatePrefix} {
+		if err := deletePrefix(snapshot, batch, prefix); err != nil {
+			return err
+		}
+	}
+
+	// Drop the orphan headers and their txs
+	orphans, err := t.orphanHeaders(chain)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range orphans {
+		log.WithField("hash", hex.EncodeToString(hash)).Warn("deleting orphan header")
+
+		batch.Delete(append(HeaderPrefix, hash...))
+
+		if err := deletePrefix(snapshot, batch, append(TxPrefix, hash...)); err != nil {
+			return err
+		}
+	}
+
+	// Rebuild height, TxID and state entries from the main chain
+	for height, hash := range chain {
+		heightBuf := new(bytes.Buffer)
+		if err := utils.WriteUint64(heightBuf, uint64(height)); err != nil {
+			return err
+		}
+
+		batch.Put(append(HeightPrefix, heightBuf.Bytes()...), hash)
+
+		scanFilter := append(TxPrefix, hash...)
+		iterator := snapshot.NewIterator(util.BytesPrefix(scanFilter), nil)
+
+		for iterator.Next() {
+			txID := iterator.Key()[len(scanFilter):]
+			batch.Put(append(TxIDPrefix, txID...), hash)
+		}
+
+		iterator.Release()
+
+		if err := iterator.Error(); err != nil {
+			return err
+		}
+	}
+
+	if len(chain) > 0 {
+		batch.Put(StatePrefix, chain[len(chain)-1])
+	}
+
+	if err := t.repairSlashes(batch, chain); err != nil {
+		return err
+	}
+
+	if err := heavyDB.storage.Write(batch, writeOptions); err != nil {
+		return err
+	}
+
+	log.WithField("orphans", len(orphans)).Info("derived indexes repaired")
+
+	// Secondary indexes are rebuilt from scratch, as their marker is gone
+	return heavyDB.buildIndexes()
+}
+
+// orphanHeaders returns the hashes of the stored headers which are not part of
+// the given main chain.
+func (t transaction) orphanHeaders(chain [][]byte) ([][]byte, error) {
+	mainChain := make(map[string]struct{}, len(chain))
+	for _, hash := range chain {
+		mainChain[string(hash)] = struct{}{}
+	}
+
+	orphans := make([][]byte, 0)
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeaderPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		hash := iterator.Key()[len(HeaderPrefix):]
+		if _, ok := mainChain[string(hash)]; !ok {
+			orphans = append(orphans, append([]byte{}, hash...))
+		}
+	}
+
+	return orphans, iterator.Error()
+}
+
+// deletePrefix adds to the batch the deletion of all the keys with the given
+// prefix.
+func deletePrefix(snapshot *leveldb.Snapshot, batch *leveldb.Batch, prefix []byte) error {
+	iterator := snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		batch.Delete(iterator.Key())
+	}
+
+	return iterator.Error()
+}
+
+// findMainChain returns the hashes of the main chain blocks, ordered by
+// height. The main chain ends at the highest stored header whose ancestry can
+// be followed down to genesis. As headers are deleted on reverting blocks,
+// any other header is a leftover of a corrupted write. The chain tip state is
+// preferred among headers at the same height. All headers are loaded in
+// memory.
+func (t transaction) findMainChain() ([][]byte, error) {
+	headers := make(map[string]*block.Header)
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeaderPrefix), nil)
+
+	for iterator.Next() {
+		hash := iterator.Key()[len(HeaderPrefix):]
+
+		header := block.NewHeader()
+		if err := message.UnmarshalHeader(bytes.NewBuffer(iterator.Value()), header); err != nil {
+			log.WithError(err).WithField("hash", hex.EncodeToString(hash)).
+				Warn("skipping malformed header")
+			continue
+		}
+
+		headers[string(hash)] = header
+	}
+
+	iterator.Release()
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	var tipHash []byte
+	if state, err := t.FetchState(); err == nil {
+		tipHash = state.TipHash
+	}
+
+	var best [][]byte
+
+	for hash, header := range headers {
+		if best != nil && header.Height+1 < uint64(len(best)) {
+			continue
+		}
+
+		if best != nil && header.Height+1 == uint64(len(best)) && !bytes.Equal([]byte(hash), tipHash) {
+			continue
+		}
+
+		if chain := ancestry(headers, []byte(hash)); chain != nil {
+			best = chain
+		}
+	}
+
+	if best == nil {
+		return nil, errors.New("no chain down to genesis found")
+	}
+
+	return best, nil
+}
+
+// ancestry returns the hashes of the blocks from genesis up to the given one,
+// or nil if any ancestor is missing.
+func ancestry(headers map[string]*block.Header, hash []byte) [][]byte {
+	chain := make([][]byte, 0)
+
+	for {
+		header, ok := headers[string(hash)]
+
+		// A missing ancestor, or a loop on a corrupted store
+		if !ok || len(chain) >= len(headers) {
+			return nil
+		}
+
+		chain = append(chain, hash)
+
+		if header.Height == 0 {
+			break
+		}
+
+		hash = header.PrevBlockHash
+	}
+
+	// Reverse, so that chain[height] is the hash of the block at height
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+
+	for height, h := range chain {
+		if headers[string(h)].Height != uint64(height) {
+			return nil
+		}
+	}
+
+	return chain
+}
@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCheckAndRepair(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "heavy_check_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = closeStorage()
+	}()
+
+	blocks := chainBlocks(5)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for _, blk := range blocks {
+			if err := t.StoreBlock(blk); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	report, err := Check(db)
+	assert.NoError(err)
+	assert.True(report.OK(), "%+v", report.Issues)
+	assert.Equal(uint64(4), report.Tip)
+	assert.Equal(uint64(5), report.Blocks)
+
+	// Corrupt the height index, a TxID back-reference and the chain tip
+	storage := db.(DB).storage
+
+	heightBuf := new(bytes.Buffer)
+	assert.NoError(utils.WriteUint64(heightBuf, 2))
+	assert.NoError(storage.Delete(append(HeightPrefix, heightBuf.Bytes()...), nil))
+
+	txID, err := blocks[3].Txs[0].CalculateHash()
+	assert.NoError(err)
+	assert.NoError(storage.Put(append(TxIDPrefix, txID...), blocks[1].Header.Hash, nil))
+
+	assert.NoError(storage.Put(StatePrefix, blocks[3].Header.Hash, nil))
+
+	// Leave a fork header off the main chain, along with one of its txs
+	orphan := chainBlocks(3)[2]
+	orphan.Header.PrevBlockHash = blocks[1].Header.Hash
+	orphan.Header.Hash, err = orphan.CalculateHash()
+	assert.NoError(err)
+
+	headerBuf := new(bytes.Buffer)
+	assert.NoError(message.MarshalHeader(headerBuf, orphan.Header))
+	assert.NoError(storage.Put(append(HeaderPrefix, orphan.Header.Hash...), headerBuf.Bytes(), nil))
+
+	orphanTxID, err := orphan.Txs[0].CalculateHash()
+	assert.NoError(err)
+
+	orphanTx, err := utils.EncodeBlockTx(orphan.Txs[0], 0)
+	assert.NoError(err)
+
+	orphanTxKey := append(append(TxPrefix, orphan.Header.Hash...), orphanTxID...)
+	assert.NoError(storage.Put(orphanTxKey, orphanTx, nil))
+
+	report, err = Check(db)
+	assert.NoError(err)
+	assert.False(report.OK())
+
+	kinds := make(map[string]bool)
+	for _, issue := range report.Issues {
+		kinds[issue.Kind] = true
+	}
+
+	assert.True(kinds[IssueMissingHeight])
+	assert.True(kinds[IssueMissingTxID])
+	assert.True(kinds[IssueDanglingTxID])
+	assert.True(kinds[IssueDanglingHeight])
+	assert.True(kinds[IssueOrphanHeader])
+
+	// Repair rebuilds the indexes from the highest header down to genesis, and
+	// deletes the orphan header
+	assert.NoError(Repair(db))
+
+	exists, err := storage.Has(append(HeaderPrefix, orphan.Header.Hash...), nil)
+	assert.NoError(err)
+	assert.False(exists)
+
+	exists, err = storage.Has(orphanTxKey, nil)
+	assert.NoError(err)
+	assert.False(exists)
+
+	report, err = Check(db)
+	assert.NoError(err)
+	assert.True(report.OK(), "%+v", report.Issues)
+	assert.Equal(uint64(4), report.Tip)
+}
+
+// chainBlocks generates a chain of random blocks, starting from genesis.
+func chainBlocks(n int) []*block.Block {
+	blocks := make([]*block.Block, n)
+
+	for i := range blocks {
+		blk := helper.RandomBlock(uint64(i), 2)
+
+		if i > 0 {
+			blk.Header.PrevBlockHash = blocks[i-1].Header.Hash
+
+			hash, err := blk.CalculateHash()
+			if err != nil {
+				panic(err)
+			}
+
+			blk.Header.Hash = hash
+		}
+
+		blocks[i] = blk
+	}
+
+	return blocks
+}
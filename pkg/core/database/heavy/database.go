@@ -10,6 +10,7 @@ import (
 	"os"
 	"sync"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -29,6 +30,9 @@ type DB struct {
 
 	// Read-only mode provided at heavy.DB level. If true, accepts read-only Transaction.
 	readOnly bool
+
+	// indexes enables the secondary indexes. See indexes.go.
+	indexes bool
 }
 
 // openStorage is a wrapper around leveldb.OpenFile to provide singleton
@@ -88,7 +92,20 @@ func NewDatabase(path string, network protocol.Magic, readonly bool) (database.D
 		return nil, err
 	}
 
-	return DB{storage, readonly}, nil
+	// Bring the store layout up to date. See migrations.go
+	if err := migrate(storage, readonly, false); err != nil {
+		return nil, err
+	}
+
+	db := DB{storage: storage, readOnly: readonly, indexes: cfg.Get().Database.Indexes}
+
+	if !readonly {
+		if err := db.buildIndexes(); err != nil {
+			return nil, err
+		}
+	}
+
+	return db, nil
 }
 
 // Begin builds read-only or read-write Transaction.
@@ -0,0 +1,242 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// evidenceRoundKey builds the key of the evidence round index. As for the
+// secondary indexes, the round is big endian encoded.
+//
+// Key = EvidenceRoundPrefix + round + evidence.hash
+// Value = nil
+func evidenceRoundKey(round uint64, hash []byte) []byte {
+	key := make([]byte, 0, len(EvidenceRoundPrefix)+8+len(hash))
+	key = append(key, EvidenceRoundPrefix...)
+	key = appendUint64(key, round)
+	key = append(key, hash...)
+
+	return key
+}
+
+// StoreEvidence stores the evidence of an equivocation.
+//
+// Key = EvidencePrefix + evidence.hash
+// Value = evidence
+func (t transaction) StoreEvidence(e *slashing.Evidence) error {
+	hash, err := e.Hash()
+	if err != nil {
+		return err
+	}
+
+	key := append(EvidencePrefix, hash...)
+
+	exists, err := t.snapshot.Has(key, nil)
+	if err != nil || exists {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := slashing.Marshal(buf, *e); err != nil {
+		return err
+	}
+
+	t.put(key, buf.Bytes())
+	t.put(evidenceRoundKey(e.Round, hash), nil)
+	return nil
+}
+
+// FetchEvidence returns the evidence of an equivocation by hash.
+func (t transaction) FetchEvidence(hash []byte) (*slashing.Evidence, error) {
+	value, err := t.snapshot.Get(append(EvidencePrefix, hash...), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, database.ErrEvidenceNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(slashing.Evidence)
+	if err := slashing.Unmarshal(bytes.NewBuffer(value), e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FetchEvidenceByRound returns the evidence of the equivocations committed
+// within the [fromRound, toRound] range.
+func (t transaction) FetchEvidenceByRound(fromRound, toRound uint64) ([]slashing.Evidence, error) {
+	if fromRound > toRound {
+		return nil, errors.New("invalid round range")
+	}
+
+	rng := &util.Range{Start: evidenceRoundKey(fromRound, nil)}
+	if toRound < math.MaxUint64 {
+		rng.Limit = evidenceRoundKey(toRound+1, nil)
+	} else {
+		rng.Limit = util.BytesPrefix(EvidenceRoundPrefix).Limit
+	}
+
+	iterator := t.snapshot.NewIterator(rng, nil)
+	defer iterator.Release()
+
+	list := make([]slashing.Evidence, 0)
+
+	for iterator.Next() {
+		hash := iterator.Key()[len(EvidenceRoundPrefix)+8:]
+
+		e, err := t.FetchEvidence(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, *e)
+	}
+
+	return list, iterator.Error()
+}
+
+// slashKey builds the key of the Slash txs index.
+//
+// Key = SlashPrefix + round + step + pubkey
+// Value = block.header.hash
+func slashKey(round uint64, step uint8, pubKey []byte) []byte {
+	key := make([]byte, 0, len(SlashPrefix)+9+len(pubKey))
+	key = append(key, SlashPrefix...)
+	key = appendUint64(key, round)
+	key = append(key, step)
+	key = append(key, pubKey...)
+
+	return key
+}
+
+// storeSlashes indexes the equivocations reported by the Slash txs of a
+// block. A Slash tx carrying a malformed evidence reports nothing.
+func (t transaction) storeSlashes(b *block.Block) {
+	for _, tx := range b.Txs {
+		if tx.Type() != transactions.Slash {
+			continue
+		}
+
+		e, err := slashing.Decode(tx)
+		if err != nil {
+			continue
+		}
+
+		t.put(slashKey(e.Round, e.Step, e.PubKeyBLS), b.Header.Hash)
+	}
+}
+
+// indexSlashes puts the Slash txs index entries for all blocks in the
+// [from, to] height range. The txs of pruned blocks are not indexed.
+func (t transaction) indexSlashes(from, to uint64) error {
+	for height := from; height <= to; height++ {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		txs, err := t.FetchBlockTxs(hash)
+		if err == database.ErrBlockPruned {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		t.storeSlashes(&block.Block{Header: &block.Header{Hash: hash}, Txs: txs})
+	}
+
+	return nil
+}
+
+// repairSlashes adds to the batch the deletion of the Slash txs index entries
+// pointing out of the main chain, and the entries of its Slash txs. Unlike the
+// other derived entries, they are not dropped altogether, as the entries of
+// the pruned blocks can not be rebuilt.
+func (t transaction) repairSlashes(batch *leveldb.Batch, chain [][]byte) error {
+	inChain := make(map[string]struct{}, len(chain))
+	for _, hash := range chain {
+		inChain[string(hash)] = struct{}{}
+	}
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(SlashPrefix), nil)
+
+	for iterator.Next() {
+		if _, ok := inChain[string(iterator.Value())]; !ok {
+			batch.Delete(append([]byte{}, iterator.Key()...))
+		}
+	}
+
+	iterator.Release()
+
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+
+	w := transaction{writable: true, db: t.db, snapshot: t.snapshot, batch: batch}
+
+	for _, hash := range chain {
+		txs, err := t.FetchBlockTxs(hash)
+		if err == database.ErrBlockPruned {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		w.storeSlashes(&block.Block{Header: &block.Header{Hash: hash}, Txs: txs})
+	}
+
+	return nil
+}
+
+// deleteSlashes removes the Slash txs index entries of a block, if they still
+// point at it.
+func (t transaction) deleteSlashes(hash []byte, blockTxs map[uint32]transactions.ContractCall) error {
+	for _, tx := range blockTxs {
+		if tx.Type() != transactions.Slash {
+			continue
+		}
+
+		e, err := slashing.Decode(tx)
+		if err != nil {
+			continue
+		}
+
+		if err := t.removeIfEqual(slashKey(e.Round, e.Step, e.PubKeyBLS), hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FetchSlashBlock returns the hash of the block whose Slash tx reported the
+// equivocation of the provisioner at round and step.
+func (t transaction) FetchSlashBlock(pubKey []byte, round uint64, step uint8) ([]byte, error) {
+	hash, err := t.snapshot.Get(slashKey(round, step, pubKey), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, database.ErrSlashNotFound
+	}
+
+	return hash, err
+}
@@ -0,0 +1,260 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Secondary indexes are optional (see databaseConfiguration.Indexes). Unlike
+// the primary schema, their keys are big endian encoded, so that the leveldb
+// key ordering matches the numerical one and range lookups can be applied.
+
+// maxIndexedPerBatch limits the number of blocks indexed within a single
+// leveldb batch on (re)building the indexes.
+const maxIndexedPerBatch = 1000
+
+// timestampKey builds the key of the timestamp index.
+//
+// Key = TimestampPrefix + timestamp + height
+// Value = block.header.hash
+func timestampKey(timestamp int64, height uint64) []byte {
+	if timestamp < 0 {
+		timestamp = 0
+	}
+
+	key := make([]byte, 0, len(TimestampPrefix)+16)
+	key = append(key, TimestampPrefix...)
+	key = appendUint64(key, uint64(timestamp))
+	key = appendUint64(key, height)
+
+	return key
+}
+
+// txTypeKey builds the key of the tx type index.
+//
+// Key = TxTypePrefix + tx.type + height + tx.index
+// Value = txID
+func txTypeKey(txType transactions.TxType, height uint64, index uint32) []byte {
+	key := make([]byte, 0, len(TxTypePrefix)+16)
+	key = append(key, TxTypePrefix...)
+	key = appendUint32(key, uint32(txType))
+	key = appendUint64(key, height)
+	key = appendUint32(key, index)
+
+	return key
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// storeIndexes puts the secondary index entries of a block.
+func (t transaction) storeIndexes(b *block.Block) error {
+	t.put(timestampKey(b.Header.Timestamp, b.Header.Height), b.Header.Hash)
+
+	for i, tx := range b.Txs {
+		txID, err := tx.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		t.put(txTypeKey(tx.Type(), b.Header.Height, uint32(i)), txID)
+	}
+
+	return nil
+}
+
+// deleteIndexes removes the secondary index entries of a block, if they still
+// point at it. Index entries are removed regardless of the indexes being
+// enabled, so that no stale entry is left behind.
+func (t transaction) deleteIndexes(header *block.Header, blockTxs map[uint32]transactions.ContractCall) error {
+	if err := t.removeIfEqual(timestampKey(header.Timestamp, header.Height), header.Hash); err != nil {
+		return err
+	}
+
+	for index, tx := range blockTxs {
+		txID, err := tx.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		if err := t.removeIfEqual(txTypeKey(tx.Type(), header.Height, index), txID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FetchBlockHeightByTimestamp returns the height of the first block whose
+// timestamp is greater than or equal to unixTime.
+func (t transaction) FetchBlockHeightByTimestamp(unixTime int64) (uint64, error) {
+	if !t.db.indexes {
+		return 0, database.ErrIndexDisabled
+	}
+
+	rng := &util.Range{
+		Start: timestampKey(unixTime, 0),
+		Limit: util.BytesPrefix(TimestampPrefix).Limit,
+	}
+
+	iterator := t.snapshot.NewIterator(rng, nil)
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		if err := iterator.Error(); err != nil {
+			return 0, err
+		}
+
+		return 0, database.ErrBlockNotFound
+	}
+
+	key := iterator.Key()
+	return binary.BigEndian.Uint64(key[len(key)-8:]), nil
+}
+
+// FetchTxIDsByType returns the IDs of the txs of txType, stored within the
+// [fromHeight, toHeight] range. Most recent txs are returned first.
+func (t transaction) FetchTxIDsByType(txType transactions.TxType, fromHeight, toHeight uint64, limit int) ([][]byte, error) {
+	if !t.db.indexes {
+		return nil, database.ErrIndexDisabled
+	}
+
+	if fromHeight > toHeight {
+		return nil, errors.New("invalid height range")
+	}
+
+	// Limit is past any tx index of the block at toHeight
+	limitKey := txTypeKey(txType, toHeight, math.MaxUint32)
+	limitKey = append(limitKey, 0xff)
+
+	rng := &util.Range{
+		Start: txTypeKey(txType, fromHeight, 0),
+		Limit: limitKey,
+	}
+
+	iterator := t.snapshot.NewIterator(rng, nil)
+	defer iterator.Release()
+
+	txIDs := make([][]byte, 0)
+
+	for ok := iterator.Last(); ok; ok = iterator.Prev() {
+		txID := make([]byte, len(iterator.Value()))
+		copy(txID, iterator.Value())
+
+		txIDs = append(txIDs, txID)
+
+		if limit > 0 && len(txIDs) >= limit {
+			break
+		}
+	}
+
+	return txIDs, iterator.Error()
+}
+
+// buildIndexes (re)builds the secondary indexes of all stored blocks, if they
+// have not been built yet. The IndexedPrefix entry marks the indexes as
+// complete. It is removed when the indexes are disabled, so that they are
+// rebuilt once enabled again.
+func (db DB) buildIndexes() error {
+	built, err := db.storage.Has(IndexedPrefix, nil)
+	if err != nil {
+		return err
+	}
+
+	if !db.indexes {
+		if built {
+			return db.storage.Delete(IndexedPrefix, writeOptions)
+		}
+
+		return nil
+	}
+
+	if built {
+		return nil
+	}
+
+	var tip uint64
+
+	err = db.View(func(t database.Transaction) error {
+		var err error
+		tip, err = t.FetchCurrentHeight()
+		return err
+	})
+
+	switch {
+	case err == database.ErrStateNotFound:
+		// Empty storage, nothing to index
+		return db.storage.Put(IndexedPrefix, []byte{}, writeOptions)
+	case err != nil:
+		return err
+	}
+
+	log.WithField("tip", tip).Info("building secondary indexes")
+
+	for from := uint64(0); from <= tip; from += maxIndexedPerBatch {
+		to := from + maxIndexedPerBatch - 1
+		if to > tip {
+			to = tip
+		}
+
+		if err := db.Update(func(t database.Transaction) error {
+			return t.(*transaction).indexBlocks(from, to)
+		}); err != nil {
+			return err
+		}
+	}
+
+	log.Info("secondary indexes built")
+	return db.storage.Put(IndexedPrefix, []byte{}, writeOptions)
+}
+
+// indexBlocks puts the secondary index entries for all blocks in the
+// [from, to] height range. The txs of pruned blocks are not indexed.
+func (t transaction) indexBlocks(from, to uint64) error {
+	for height := from; height <= to; height++ {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		header, err := t.FetchBlockHeader(hash)
+		if err != nil {
+			return err
+		}
+
+		txs, err := t.FetchBlockTxs(hash)
+		if err != nil && err != database.ErrBlockPruned {
+			return err
+		}
+
+		if err := t.storeIndexes(&block.Block{Header: header, Txs: txs}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// migration upgrades the store layout from version-1 to version.
+type migration struct {
+	version     uint32
+	description string
+
+	// run applies the migration. All writes must go through the migrator, so
+	// that nothing is written in dry-run mode.
+	run func(m *migrator) error
+}
+
+// migrations is the ordered registry of the store layout changes. A new
+// migration must be appended with the next version. Stores without a schema
+// version record are at version 0.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "record schema version",
+		run: func(m *migrator) error {
+			// Layout up to CandidatePrefix is unchanged, only the schema
+			// version record is introduced
+			return nil
+		},
+	},
+	{
+		version:     2,
+		description: "record the height from which evidence and provisioner sets are stored",
+		run:         recordHistoryHeight,
+	},
+	{
+		version:     3,
+		description: "index the equivocations reported by the Slash txs",
+		run:         indexSlashes,
+	},
+}
+
+// recordHistoryHeight marks a store which holds blocks accepted before the
+// evidence (EvidencePrefix, EvidenceRoundPrefix) and the provisioner sets
+// (ProvisionersPrefix) were stored. Unlike the secondary indexes, they are not
+// derived from the blocks, and can not be rebuilt out of them: they are only
+// available from the chain tip on, unless the chain is synced again.
+func recordHistoryHeight(m *migrator) error {
+	hash, err := m.storage.Get(StatePrefix, nil)
+	if err == leveldb.ErrNotFound || len(hash) == 0 {
+		// No block, nothing is missing
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	value, err := m.storage.Get(append(HeaderPrefix, hash...), nil)
+	if err != nil {
+		return err
+	}
+
+	header := block.NewHeader()
+	if err := message.UnmarshalHeader(bytes.NewBuffer(value), header); err != nil {
+		return err
+	}
+
+	log.WithField("migration", m.name).WithField("height", header.Height).
+		Warn("evidence and provisioner sets of the previous blocks are missing, sync the chain again to rebuild them")
+
+	batch := new(leveldb.Batch)
+	batch.Put(HistoryPrefix, historyHeightValue(header.Height))
+
+	return m.write(batch)
+}
+
+// indexSlashes builds the Slash txs index (SlashPrefix) out of the stored
+// blocks. The txs of pruned blocks are gone, so are their Slash txs.
+func indexSlashes(m *migrator) error {
+	db := DB{storage: m.storage}
+
+	var tip, pruned uint64
+
+	err := db.View(func(t database.Transaction) error {
+		var err error
+		if tip, err = t.FetchCurrentHeight(); err != nil {
+			return err
+		}
+
+		pruned, err = t.FetchPrunedHeight()
+		return err
+	})
+
+	switch {
+	case err == database.ErrStateNotFound:
+		// No block, nothing to index
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if pruned > 0 {
+		log.WithField("migration", m.name).WithField("pruned_height", pruned).
+			Warn("Slash txs of the pruned blocks are not indexed")
+	}
+
+	for from := uint64(0); from <= tip; from += maxIndexedPerBatch {
+		to := from + maxIndexedPerBatch - 1
+		if to > tip {
+			to = tip
+		}
+
+		t, err := db.Begin(true)
+		if err != nil {
+			return err
+		}
+
+		// Writes go through the migrator rather than on Commit
+		tx := t.(*transaction)
+
+		err = tx.indexSlashes(from, to)
+		if err == nil {
+			err = m.write(tx.batch)
+		}
+
+		t.Close()
+
+		if err != nil {
+			return err
+		}
+
+		m.progress(to+1, tip+1)
+	}
+
+	return nil
+}
+
+func historyHeightValue(height uint64) []byte {
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, height)
+
+	return value
+}
+
+// fetchHistoryHeight returns the height from which the evidence and the
+// provisioner sets are stored. It is 0 unless the store was migrated from a
+// version which did not store them.
+func fetchHistoryHeight(snapshot *leveldb.Snapshot) (uint64, error) {
+	value, err := snapshot.Get(HistoryPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(value) != 8 {
+		return 0, errors.New("history height malformed")
+	}
+
+	return binary.LittleEndian.Uint64(value), nil
+}
+
+// SchemaVersion returns the version of the store layout supported by this
+// node.
+func SchemaVersion() uint32 {
+	if len(migrations) == 0 {
+		return 0
+	}
+
+	return migrations[len(migrations)-1].version
+}
+
+// migrator is passed to the running migration.
+type migrator struct {
+	storage *leveldb.DB
+	dryRun  bool
+	name    string
+
+	lastLog time.Time
+}
+
+// write applies a batch, unless in dry-run mode.
+func (m *migrator) write(batch *leveldb.Batch) error {
+	if m.dryRun {
+		log.WithField("migration", m.name).WithField("writes", batch.Len()).
+			Info("dry-run: batch not written")
+		return nil
+	}
+
+	return m.storage.Write(batch, writeOptions)
+}
+
+// progress logs the progress of the running migration, at most once per
+// second.
+func (m *migrator) progress(done, total uint64) {
+	if time.Since(m.lastLog) < time.Second && done < total {
+		return
+	}
+
+	m.lastLog = time.Now()
+
+	log.WithField("migration", m.name).
+		WithField("done", done).
+		WithField("total", total).
+		Info("migration progress")
+}
+
+// fetchSchemaVersion returns the schema version of the store. A store without
+// a schema version record is at version 0, unless it is empty.
+func fetchSchemaVersion(storage *leveldb.DB) (version uint32, empty bool, err error) {
+	value, err := storage.Get(SchemaPrefix, nil)
+	if err == nil {
+		if len(value) != 4 {
+			return 0, false, errors.New("schema version malformed")
+		}
+
+		return binary.LittleEndian.Uint32(value), false, nil
+	}
+
+	if err != leveldb.ErrNotFound {
+		return 0, false, err
+	}
+
+	iterator := storage.NewIterator(nil, nil)
+	defer iterator.Release()
+
+	return 0, !iterator.First(), iterator.Error()
+}
+
+func storeSchemaVersion(storage *leveldb.DB, version uint32) error {
+	value := make([]byte, 4)
+	binary.LittleEndian.PutUint32(value, version)
+
+	return storage.Put(SchemaPrefix, value, writeOptions)
+}
+
+// migrate brings the store up to SchemaVersion, applying all pending
+// migrations in order. The schema version is recorded after each migration,
+// so that an interrupted upgrade is resumed from the failed migration.
+//
+// In dry-run mode, pending migrations are run without writing anything. As
+// such, a migration relying on the writes of a previous one can not be fully
+// simulated. In read-only mode, no migration is run at all.
+func migrate(storage *leveldb.DB, readonly, dryRun bool) error {
+	version, empty, err := fetchSchemaVersion(storage)
+	if err != nil {
+		return err
+	}
+
+	latest := SchemaVersion()
+
+	if version > latest {
+		return fmt.Errorf("%w: store version %d, supported version %d", database.ErrSchemaTooNew, version, latest)
+	}
+
+	if empty {
+		if readonly || dryRun {
+			return nil
+		}
+
+		// A new store is created with the latest layout
+		return storeSchemaVersion(storage, latest)
+	}
+
+	if version == latest {
+		return nil
+	}
+
+	if readonly {
+		log.WithField("version", version).WithField("latest", latest).
+			Warn("store requires migration, open it in read-write mode")
+		return nil
+	}
+
+	for _, mig := range migrations {
+		if mig.version <= version {
+			continue
+		}
+
+		l := log.WithField("version", mig.version).WithField("dry_run", dryRun)
+		l.WithField("description", mig.description).Info("running migration")
+
+		m := &migrator{storage: storage, dryRun: dryRun, name: mig.description}
+		if err := mig.run(m); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", mig.version, mig.description, err)
+		}
+
+		if !dryRun {
+			if err := storeSchemaVersion(storage, mig.version); err != nil {
+				return err
+			}
+		}
+
+		l.Info("migration completed")
+	}
+
+	return nil
+}
+
+// Migrate runs the pending migrations on the store at path. In dry-run mode
+// nothing is written, and the migrations only report what they would do.
+// Note that opening the store (see NewDatabase) already runs them.
+func Migrate(path string, dryRun bool) (from uint32, to uint32, err error) {
+	storage, err := openStorage(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	from, _, err = fetchSchemaVersion(storage)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err = migrate(storage, false, dryRun); err != nil {
+		return from, from, err
+	}
+
+	return from, SchemaVersion(), nil
+}
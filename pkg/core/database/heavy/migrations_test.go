@@ -0,0 +1,193 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	assert "github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestMigrations(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "heavy_migrations_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	// A new store is created with the latest schema version
+	_, err = NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = closeStorage()
+	}()
+
+	storage := _storage
+
+	version, empty, err := fetchSchemaVersion(storage)
+	assert.NoError(err)
+	assert.False(empty)
+	assert.Equal(SchemaVersion(), version)
+
+	// Register a new migration
+	key := []byte{0xff, 0x01}
+	latest := SchemaVersion()
+
+	defer func(registry []migration) {
+		migrations = registry
+	}(migrations)
+
+	migrations = append(migrations, migration{
+		version:     latest + 1,
+		description: "test migration",
+		run: func(m *migrator) error {
+			batch := new(leveldb.Batch)
+			batch.Put(key, []byte{1})
+
+			m.progress(1, 1)
+			return m.write(batch)
+		},
+	})
+
+	// Dry-run does not write anything
+	from, to, err := Migrate(dir, true)
+	assert.NoError(err)
+	assert.Equal(latest, from)
+	assert.Equal(latest+1, to)
+
+	_, err = storage.Get(key, nil)
+	assert.Equal(leveldb.ErrNotFound, err)
+
+	version, _, err = fetchSchemaVersion(storage)
+	assert.NoError(err)
+	assert.Equal(latest, version)
+
+	// Pending migration is applied on opening the store
+	_, err = NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	_, err = storage.Get(key, nil)
+	assert.NoError(err)
+
+	version, _, err = fetchSchemaVersion(storage)
+	assert.NoError(err)
+	assert.Equal(latest+1, version)
+
+	// A store newer than the supported schema is refused
+	assert.NoError(storeSchemaVersion(storage, latest+2))
+
+	_, err = NewDatabase(dir, protocol.DevNet, false)
+	assert.True(errors.Is(err, database.ErrSchemaTooNew))
+
+	_, err = NewDatabase(dir, protocol.DevNet, true)
+	assert.True(errors.Is(err, database.ErrSchemaTooNew))
+}
+
+func TestHistoryMigration(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "heavy_history_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = closeStorage()
+	}()
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for _, blk := range chainBlocks(5) {
+			if err := t.StoreBlock(blk); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	// A store created with the evidence and the provisioner sets has the
+	// whole history
+	report, err := Check(db)
+	assert.NoError(err)
+	assert.Zero(report.HistoryHeight)
+
+	// A store holding blocks accepted before records the height they are
+	// stored from
+	assert.NoError(storeSchemaVersion(db.(DB).storage, 1))
+
+	db, err = NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	report, err = Check(db)
+	assert.NoError(err)
+	assert.True(report.OK(), "%+v", report.Issues)
+	assert.Equal(uint64(4), report.HistoryHeight)
+}
+
+func TestSlashesMigration(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "heavy_slashes_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = closeStorage()
+	}()
+
+	e := slashing.MockEvidence(2, 3)
+	blocks := chainBlocks(5)
+	blocks[3].Txs = append([]transactions.ContractCall{slashing.MockSlashTx(e)}, blocks[3].Txs...)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for _, blk := range blocks {
+			if err := t.StoreBlock(blk); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	// A store holding blocks accepted before the Slash txs were indexed
+	storage := db.(DB).storage
+	assert.NoError(storage.Delete(slashKey(e.Round, e.Step, e.PubKeyBLS), nil))
+	assert.NoError(storeSchemaVersion(storage, 2))
+
+	db, err = NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		hash, err := t.FetchSlashBlock(e.PubKeyBLS, e.Round, e.Step)
+		assert.NoError(err)
+		assert.Equal(blocks[3].Header.Hash, hash)
+		return nil
+	}))
+}
@@ -0,0 +1,95 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// provisionersKey builds the key of a provisioner set. The height is big
+// endian encoded, so that the latest set below a height can be sought.
+//
+// Key = ProvisionersPrefix + height
+func provisionersKey(height uint64) []byte {
+	key := make([]byte, 0, len(ProvisionersPrefix)+8)
+	key = append(key, ProvisionersPrefix...)
+
+	return appendUint64(key, height)
+}
+
+// StoreProvisioners records the provisioner set in effect after the block at
+// height.
+//
+// Key = ProvisionersPrefix + height
+// Value = provisioners
+func (t transaction) StoreProvisioners(height uint64, p *user.Provisioners) error {
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, p); err != nil {
+		return err
+	}
+
+	t.put(provisionersKey(height), buf.Bytes())
+	return nil
+}
+
+// FetchProvisioners returns the most recent provisioner set stored at or
+// below height.
+func (t transaction) FetchProvisioners(height uint64) (*user.Provisioners, error) {
+	rng := &util.Range{Start: provisionersKey(0)}
+	if height < math.MaxUint64 {
+		rng.Limit = provisionersKey(height + 1)
+	} else {
+		rng.Limit = util.BytesPrefix(ProvisionersPrefix).Limit
+	}
+
+	iterator := t.snapshot.NewIterator(rng, nil)
+	defer iterator.Release()
+
+	if !iterator.Last() {
+		if err := iterator.Error(); err != nil {
+			return nil, err
+		}
+
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	// The iterator owns the value
+	value := append([]byte(nil), iterator.Value()...)
+
+	p, err := user.UnmarshalProvisioners(bytes.NewBuffer(value))
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// deleteProvisionersAbove deletes the provisioner sets stored above height.
+func (t transaction) deleteProvisionersAbove(height uint64) error {
+	if height == math.MaxUint64 {
+		return nil
+	}
+
+	rng := &util.Range{
+		Start: provisionersKey(height + 1),
+		Limit: util.BytesPrefix(ProvisionersPrefix).Limit,
+	}
+
+	iterator := t.snapshot.NewIterator(rng, nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		t.remove(iterator.Key())
+	}
+
+	return iterator.Error()
+}
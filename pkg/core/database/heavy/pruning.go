@@ -0,0 +1,203 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	defaultPruneInterval = time.Minute
+
+	// maxPrunedPerBatch limits the number of blocks pruned within a single
+	// leveldb batch.
+	maxPrunedPerBatch = 500
+)
+
+var errNotHeavy = errors.New("pruning is supported by heavy driver only")
+
+// Pruner periodically removes the txs of the blocks older than the last keep
+// blocks. Block headers (along with their certificates) and the height index
+// are kept for the entire chain, so that the chain can still be verified and
+// served in headers-first mode.
+//
+// The height up to which blocks are pruned is stored under PrunedPrefix, so
+// that the pruned blocks are not advertised to the network.
+type Pruner struct {
+	db       DB
+	keep     uint64
+	interval time.Duration
+}
+
+// NewPruner returns a Pruner which keeps the bodies of the last keep blocks.
+func NewPruner(db database.DB, keep uint64, interval time.Duration) (*Pruner, error) {
+	heavyDB, ok := db.(DB)
+	if !ok {
+		return nil, errNotHeavy
+	}
+
+	if keep == 0 {
+		return nil, errors.New("at least one block body must be kept")
+	}
+
+	if interval <= 0 {
+		interval = defaultPruneInterval
+	}
+
+	return &Pruner{db: heavyDB, keep: keep, interval: interval}, nil
+}
+
+// Run prunes the storage at each interval, until the context is canceled.
+func (p *Pruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := p.Prune()
+			if err != nil {
+				log.WithError(err).Warn("could not prune blocks")
+				continue
+			}
+
+			if pruned == 0 {
+				continue
+			}
+
+			if err := p.Compact(); err != nil {
+				log.WithError(err).Warn("could not compact storage")
+			}
+		}
+	}
+}
+
+// Prune removes the txs of all blocks below the retained range. It returns
+// the number of pruned blocks.
+func (p *Pruner) Prune() (uint64, error) {
+	var pruned uint64
+
+	for {
+		var n uint64
+
+		err := p.db.Update(func(t database.Transaction) error {
+			var err error
+			n, err = t.(*transaction).pruneBlocks(p.keep, maxPrunedPerBatch)
+			return err
+		})
+		if err != nil {
+			return pruned, err
+		}
+
+		pruned += n
+
+		if n < maxPrunedPerBatch {
+			break
+		}
+	}
+
+	if pruned > 0 {
+		log.WithField("blocks", pruned).Info("blocks pruned")
+	}
+
+	return pruned, nil
+}
+
+// Compact compacts the key ranges affected by pruning, so that the disk
+// space is actually reclaimed.
+func (p *Pruner) Compact() error {
+	for _, prefix := range [][]byte{TxPrefix, TxIDPrefix} {
+		if err := p.db.storage.CompactRange(*util.BytesPrefix(prefix)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneBlocks removes the txs of up to limit blocks, following the last
+// pruned height, while keeping the last keep blocks untouched. Genesis block
+// is never pruned.
+func (t transaction) pruneBlocks(keep uint64, limit uint64) (uint64, error) {
+	if t.batch == nil {
+		return 0, errors.New("pruneBlocks cannot be called on read-only transaction")
+	}
+
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	if tip <= keep {
+		return 0, nil
+	}
+
+	prunedHeight, err := t.FetchPrunedHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	target := tip - keep
+	if target > prunedHeight+limit {
+		target = prunedHeight + limit
+	}
+
+	if target <= prunedHeight {
+		return 0, nil
+	}
+
+	for height := prunedHeight + 1; height <= target; height++ {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := t.pruneBlockTxs(hash); err != nil {
+			return 0, err
+		}
+	}
+
+	// Key = PrunedPrefix
+	// Value = pruned height
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, target)
+	t.put(PrunedPrefix, value)
+
+	return target - prunedHeight, nil
+}
+
+// pruneBlockTxs deletes the txs of a block along with the TxID index. Key
+// images are kept, as they are still needed to detect double spending.
+func (t transaction) pruneBlockTxs(hash []byte) error {
+	// Scan filter = TX_PREFIX + block.header.hash
+	scanFilter := append(TxPrefix, hash...)
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(scanFilter), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		key := iterator.Key()
+		txID := key[len(scanFilter):]
+
+		if err := t.removeIfEqual(append(TxIDPrefix, txID...), hash); err != nil {
+			return err
+		}
+
+		t.remove(key)
+	}
+
+	return iterator.Error()
+}
@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPruneBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "heavy_pruning_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = closeStorage()
+	}()
+
+	blocks := make([]*block.Block, 10)
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 2)
+	}
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for _, blk := range blocks {
+			if err := t.StoreBlock(blk); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	_, err = NewPruner(db, 0, 0)
+	assert.Error(err)
+
+	pruner, err := NewPruner(db, 3, 0)
+	assert.NoError(err)
+
+	// Blocks 1 to 6 are pruned, genesis and the last 3 blocks are kept
+	pruned, err := pruner.Prune()
+	assert.NoError(err)
+	assert.Equal(uint64(6), pruned)
+	assert.NoError(pruner.Compact())
+
+	// Nothing left to prune
+	pruned, err = pruner.Prune()
+	assert.NoError(err)
+	assert.Equal(uint64(0), pruned)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		prunedHeight, err := t.FetchPrunedHeight()
+		assert.NoError(err)
+		assert.Equal(uint64(6), prunedHeight)
+
+		for _, blk := range blocks {
+			// Headers are kept for the entire chain
+			header, err := t.FetchBlockHeader(blk.Header.Hash)
+			assert.NoError(err)
+			assert.True(header.Equals(blk.Header))
+
+			hash, err := t.FetchBlockHashByHeight(blk.Header.Height)
+			assert.NoError(err)
+			assert.Equal(blk.Header.Hash, hash)
+
+			txID, err := blk.Txs[0].CalculateHash()
+			assert.NoError(err)
+
+			_, err = t.FetchBlock(blk.Header.Hash)
+			_, _, _, txErr := t.FetchBlockTxByHash(txID)
+
+			if blk.Header.Height > 0 && blk.Header.Height <= 6 {
+				assert.Equal(database.ErrBlockPruned, err)
+				assert.Equal(database.ErrTxNotFound, txErr)
+			} else {
+				assert.NoError(err)
+				assert.NoError(txErr)
+			}
+		}
+
+		return nil
+	}))
+
+	// Reverting below the pruned height is not possible anymore
+	assert.Error(db.Update(func(t database.Transaction) error {
+		return t.RevertToHeight(5)
+	}))
+}
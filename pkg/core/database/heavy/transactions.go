@@ -58,6 +58,29 @@ var (
 	OutputKeyPrefix = []byte{0x07}
 	// CandidatePrefix is the prefix to identify Candidate messages.
 	CandidatePrefix = []byte{0x08}
+	// PrunedPrefix is the prefix to identify the height up to which block
+	// bodies have been pruned.
+	PrunedPrefix = []byte{0x09}
+	// TimestampPrefix is the prefix to identify the timestamp index.
+	TimestampPrefix = []byte{0x0a}
+	// TxTypePrefix is the prefix to identify the tx type index.
+	TxTypePrefix = []byte{0x0b}
+	// IndexedPrefix is the prefix to identify the secondary indexes marker.
+	IndexedPrefix = []byte{0x0c}
+	// SchemaPrefix is the prefix to identify the schema version.
+	SchemaPrefix = []byte{0x0d}
+	// EvidencePrefix is the prefix to identify the equivocation evidence.
+	EvidencePrefix = []byte{0x0e}
+	// EvidenceRoundPrefix is the prefix to identify the evidence round index.
+	EvidenceRoundPrefix = []byte{0x0f}
+	// ProvisionersPrefix is the prefix to identify the provisioner sets.
+	ProvisionersPrefix = []byte{0x10}
+	// HistoryPrefix is the prefix to identify the height from which the
+	// evidence and the provisioner sets are recorded.
+	HistoryPrefix = []byte{0x11}
+	// SlashPrefix is the prefix to identify the equivocations reported by
+	// the Slash txs of the chain.
+	SlashPrefix = []byte{0x12}
 )
 
 type transaction struct {
@@ -174,6 +197,162 @@ func (t transaction) StoreBlock(b *block.Block) error {
 
 	t.put(key, value)
 
+	// Unlike the secondary indexes, the Slash txs index is needed by the
+	// block verification, see evidence.go
+	t.storeSlashes(b)
+
+	// Secondary indexes, see indexes.go
+	if t.db.indexes {
+		if err := t.storeIndexes(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteBlock removes the block data stored by StoreBlock and resets the chain
+// tip to the block parent. As with StoreBlock, storage state changes only when
+// Commit() is called on Transaction completion.
+//
+// Index entries (TxID, Height) are removed only if they still point at this
+// block, as a tx or a height could be already claimed by a competing block.
+// Key images are not written by StoreBlock, so there are none to remove.
+func (t transaction) DeleteBlock(hash []byte) error {
+	if t.batch == nil {
+		// t.batch is initialized only on a open, read-write transaction
+		// (built with transaction.Update()).
+		return errors.New("DeleteBlock cannot be called on read-only transaction")
+	}
+
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	if header.Height == 0 {
+		return errors.New("genesis block cannot be deleted")
+	}
+
+	// Delete block transaction data along with the TxID index.
+	//
+	// Scan filter = TX_PREFIX + block.header.hash
+	scanFilter := append(TxPrefix, hash...)
+
+	blockTxs := make(map[uint32]transactions.ContractCall)
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(scanFilter), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		key := iterator.Key()
+		txID := key[len(scanFilter):]
+
+		tx, txIndex, err := utils.DecodeBlockTx(iterator.Value(), database.AnyTxType)
+		if err != nil {
+			return err
+		}
+
+		blockTxs[txIndex] = tx
+
+		if err := t.removeIfEqual(append(TxIDPrefix, txID...), hash); err != nil {
+			return err
+		}
+
+		t.remove(key)
+	}
+
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+
+	if err := t.deleteIndexes(header, blockTxs); err != nil {
+		return err
+	}
+
+	if err := t.deleteSlashes(hash, blockTxs); err != nil {
+		return err
+	}
+
+	// Delete height index
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, header.Height); err != nil {
+		return err
+	}
+
+	if err := t.removeIfEqual(append(HeightPrefix, heightBuf.Bytes()...), hash); err != nil {
+		return err
+	}
+
+	// Delete block header
+	t.remove(append(HeaderPrefix, hash...))
+
+	// Key = StatePrefix
+	// Value = Hash(chain tip)
+	//
+	// Chain tip moves back to the parent block
+	t.put(StatePrefix, header.PrevBlockHash)
+
+	return nil
+}
+
+// RevertToHeight deletes all blocks above height, one by one starting from the
+// chain tip, along with the provisioner sets stored above height, and resets
+// the chain tip to the block at height. All deletions are applied atomically
+// on Commit().
+//
+// Lookups are applied into the transaction snapshot, so RevertToHeight should
+// not be combined with StoreBlock/DeleteBlock calls in the same transaction.
+func (t transaction) RevertToHeight(height uint64) error {
+	if t.batch == nil {
+		// t.batch is initialized only on a open, read-write transaction
+		// (built with transaction.Update()).
+		return errors.New("RevertToHeight cannot be called on read-only transaction")
+	}
+
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	if height > tip {
+		return fmt.Errorf("cannot revert to height %d above the chain tip %d", height, tip)
+	}
+
+	prunedHeight, err := t.FetchPrunedHeight()
+	if err != nil {
+		return err
+	}
+
+	if height < prunedHeight {
+		return fmt.Errorf("cannot revert to height %d below the pruned height %d", height, prunedHeight)
+	}
+
+	hash, err := t.FetchBlockHashByHeight(height)
+	if err != nil {
+		return err
+	}
+
+	for h := tip; h > height; h-- {
+		blockHash, err := t.FetchBlockHashByHeight(h)
+		if err != nil {
+			return err
+		}
+
+		if err := t.DeleteBlock(blockHash); err != nil {
+			return err
+		}
+	}
+
+	// The provisioner sets of the deleted blocks are not in effect anymore
+	if err := t.deleteProvisionersAbove(height); err != nil {
+		return err
+	}
+
+	// Key = StatePrefix
+	// Value = Hash(chain tip)
+	t.put(StatePrefix, hash)
+
 	return nil
 }
 
@@ -279,6 +458,10 @@ func (t transaction) FetchBlockHeader(hash []byte) (*block.Header, error) {
 }
 
 func (t transaction) FetchBlockTxs(hashHeader []byte) ([]transactions.ContractCall, error) {
+	if err := t.checkPruned(hashHeader); err != nil {
+		return nil, err
+	}
+
 	scanFilter := append(TxPrefix, hashHeader...)
 	tempTxs := make(map[uint32]transactions.ContractCall)
 
@@ -357,6 +540,38 @@ func (t transaction) put(key []byte, value []byte) {
 	}
 }
 
+func (t transaction) remove(key []byte) {
+	if !t.writable {
+		return
+	}
+
+	if t.batch != nil {
+		t.batch.Delete(key)
+	} else {
+		// fail-fast when a writable transaction is not capable of deleting data
+		log.Panic("leveldb batch is unreachable")
+	}
+}
+
+// removeIfEqual deletes a key only if its currently stored value is equal to
+// the expected one.
+func (t transaction) removeIfEqual(key []byte, expected []byte) error {
+	value, err := t.snapshot.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(value, expected) {
+		t.remove(key)
+	}
+
+	return nil
+}
+
 func (t transaction) FetchBlockTxByHash(txID []byte) (transactions.ContractCall, uint32, []byte, error) {
 	txIndex := uint32(math.MaxUint32)
 
@@ -487,6 +702,24 @@ func (t transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (
 
 	n := uint64(math.Min(float64(tip), float64(offset)))
 
+	// Lookup the timestamp index, if available
+	if t.db.indexes {
+		height, err := t.FetchBlockHeightByTimestamp(sinceUnixTime)
+		if err == database.ErrBlockNotFound {
+			return tip, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if height < tip-n {
+			height = tip - n
+		}
+
+		return height, nil
+	}
+
 	pos, err := utils.Search(n, func(pos uint64) (bool, error) {
 		height := tip - n + pos
 
@@ -509,6 +742,49 @@ func (t transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (
 	return tip - n + pos, nil
 }
 
+// FetchPrunedHeight returns the height up to which block bodies have been
+// pruned. See also Pruner.
+func (t transaction) FetchPrunedHeight() (uint64, error) {
+	value, err := t.snapshot.Get(PrunedPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(value) != 8 {
+		return 0, errors.New("pruned height malformed")
+	}
+
+	return binary.LittleEndian.Uint64(value), nil
+}
+
+// checkPruned returns database.ErrBlockPruned if the txs of the block have
+// already been pruned.
+func (t transaction) checkPruned(hash []byte) error {
+	prunedHeight, err := t.FetchPrunedHeight()
+	if err != nil || prunedHeight == 0 {
+		return err
+	}
+
+	header, err := t.FetchBlockHeader(hash)
+	if err == database.ErrBlockNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if header.Height > 0 && header.Height <= prunedHeight {
+		return database.ErrBlockPruned
+	}
+
+	return nil
+}
+
 func (t transaction) StoreCandidateMessage(cm block.Block) error {
 	buf := new(bytes.Buffer)
 	if err := message.MarshalBlock(buf, &cm); err != nil {
@@ -553,6 +829,11 @@ func (t transaction) ClearDatabase() error {
 	defer iter.Release()
 
 	for iter.Next() {
+		// Schema version is not affected, as the layout stays the same
+		if bytes.Equal(iter.Key(), SchemaPrefix) {
+			continue
+		}
+
 		t.batch.Delete(iter.Key())
 	}
 
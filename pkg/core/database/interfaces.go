@@ -10,8 +10,10 @@ import (
 	"errors"
 	"math"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 )
 
@@ -25,10 +27,26 @@ var (
 	ErrTxNotFound = errors.New("database: transaction not found")
 	// ErrBlockNotFound returned on a block lookup by hash or height.
 	ErrBlockNotFound = errors.New("database: block not found")
+	// ErrBlockPruned returned on a block lookup when only the block header
+	// is still stored.
+	ErrBlockPruned = errors.New("database: block pruned")
+	// ErrIndexDisabled returned on a lookup of a secondary index which is
+	// not maintained by the driver.
+	ErrIndexDisabled = errors.New("database: index disabled")
+	// ErrSchemaTooNew returned on opening a store written by a more recent
+	// version of the node.
+	ErrSchemaTooNew = errors.New("database: store schema is newer than supported")
 	// ErrStateNotFound returned on missing state db entry.
 	ErrStateNotFound = errors.New("database: state not found")
 	// ErrOutputNotFound returned on output lookup during tx verification.
 	ErrOutputNotFound = errors.New("database: output not found")
+	// ErrEvidenceNotFound returned on an equivocation evidence lookup by hash.
+	ErrEvidenceNotFound = errors.New("database: evidence not found")
+	// ErrProvisionersNotFound returned on a provisioner set lookup by height.
+	ErrProvisionersNotFound = errors.New("database: provisioners not found")
+	// ErrSlashNotFound returned on a lookup of an equivocation not reported
+	// by the Slash txs of the chain.
+	ErrSlashNotFound = errors.New("database: slash not found")
 
 	// AnyTxType is used as a filter value on FetchBlockTxByHash.
 	AnyTxType = transactions.TxType(math.MaxUint8)
@@ -79,6 +97,15 @@ type Transaction interface {
 	// Not to be called concurrently, as it updates chain tip.
 	StoreBlock(block *block.Block) error
 
+	// DeleteBlock removes a block, its txs and all indexes pointing at it,
+	// and moves the chain tip back to the block parent. It is the inverse
+	// of StoreBlock and it should be called on the chain tip only.
+	DeleteBlock(hash []byte) error
+
+	// RevertToHeight deletes all blocks above height, starting from the
+	// chain tip, so that the block at height becomes the new chain tip.
+	RevertToHeight(height uint64) error
+
 	// FetchBlock will return a block, given a hash.
 	FetchBlock(hash []byte) (*block.Block, error)
 
@@ -98,6 +125,21 @@ type Transaction interface {
 	// sinceUnixTime starting the search from height (tip - offset).
 	FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (uint64, error)
 
+	// FetchPrunedHeight returns the height up to which the block bodies
+	// have been pruned. Only the headers of these blocks are still stored.
+	// Zero means that no block has been pruned.
+	FetchPrunedHeight() (uint64, error)
+
+	// FetchBlockHeightByTimestamp returns the height of the first block
+	// whose timestamp is greater than or equal to unixTime. It is served by
+	// the timestamp secondary index.
+	FetchBlockHeightByTimestamp(unixTime int64) (uint64, error)
+
+	// FetchTxIDsByType returns the IDs of the txs of txType stored within
+	// the [fromHeight, toHeight] range, the most recent first. A limit of
+	// zero returns all of them. It is served by the tx type secondary index.
+	FetchTxIDsByType(txType transactions.TxType, fromHeight, toHeight uint64, limit int) ([][]byte, error)
+
 	// StoreCandidateMessage will...
 	StoreCandidateMessage(cm block.Block) error
 
@@ -105,6 +147,31 @@ type Transaction interface {
 
 	ClearCandidateMessages() error
 
+	// StoreEvidence stores the evidence of an equivocation, identified by
+	// its hash. Storing the same equivocation twice keeps the first one.
+	StoreEvidence(e *slashing.Evidence) error
+
+	// FetchEvidence returns the evidence of an equivocation by hash.
+	FetchEvidence(hash []byte) (*slashing.Evidence, error)
+
+	// FetchEvidenceByRound returns the evidence of the equivocations
+	// committed within the [fromRound, toRound] range, by ascending round
+	// and then by hash.
+	FetchEvidenceByRound(fromRound, toRound uint64) ([]slashing.Evidence, error)
+
+	// FetchSlashBlock returns the hash of the block whose Slash tx reported
+	// the equivocation of the provisioner at round and step. The Slash txs
+	// are indexed by StoreBlock.
+	FetchSlashBlock(pubKey []byte, round uint64, step uint8) ([]byte, error)
+
+	// StoreProvisioners records the provisioner set in effect after the
+	// block at height, i.e. the one voting at round height+1.
+	StoreProvisioners(height uint64, p *user.Provisioners) error
+
+	// FetchProvisioners returns the provisioner set in effect after the
+	// block at height. It is the most recent set stored at or below height.
+	FetchProvisioners(height uint64) (*user.Provisioners, error)
+
 	// ClearDatabase will remove all information from the database.
 	ClearDatabase() error
 
@@ -29,6 +29,9 @@ const (
 	stateInd
 	outputKeyInd
 	candidateInd
+	evidenceInd
+	provisionersInd
+	slashesInd
 	maxInd
 )
 
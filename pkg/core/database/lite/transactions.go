@@ -12,12 +12,16 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-crypto/hash"
 )
 
 type transaction struct {
@@ -67,6 +71,10 @@ func (t *transaction) StoreBlock(b *block.Block) error {
 
 		t.batch[txsInd][toKey(txID)] = data
 		t.batch[txHashInd][toKey(txID)] = b.Header.Hash
+
+		if k, ok := slashKey(tx); ok {
+			t.batch[slashesInd][k] = b.Header.Hash
+		}
 	}
 
 	// Map height to buffer bytes
@@ -85,6 +93,114 @@ func (t *transaction) StoreBlock(b *block.Block) error {
 	return nil
 }
 
+// DeleteBlock removes the block data stored by StoreBlock and resets the chain
+// tip to the block parent. Deletions are marked in the batch with a nil value
+// and applied on Commit.
+func (t *transaction) DeleteBlock(hash []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	if len(t.batch) == 0 {
+		return errors.New("empty batch")
+	}
+
+	blockBytes, exists := t.db.storage[blocksInd][toKey(hash)]
+	if !exists {
+		return database.ErrBlockNotFound
+	}
+
+	b := block.NewBlock()
+	if err := message.UnmarshalBlock(bytes.NewBuffer(blockBytes), b); err != nil {
+		return err
+	}
+
+	if b.Header.Height == 0 {
+		return errors.New("genesis block cannot be deleted")
+	}
+
+	for _, tx := range b.Txs {
+		txID, err := tx.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		// Delete tx entries only if they still point at this block
+		if blockHash, ok := t.db.storage[txHashInd][toKey(txID)]; ok && bytes.Equal(blockHash, hash) {
+			t.batch[txsInd][toKey(txID)] = nil
+			t.batch[txHashInd][toKey(txID)] = nil
+		}
+
+		if k, ok := slashKey(tx); ok {
+			if blockHash, ok := t.db.storage[slashesInd][k]; ok && bytes.Equal(blockHash, hash) {
+				t.batch[slashesInd][k] = nil
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, b.Header.Height); err != nil {
+		return err
+	}
+
+	if data, ok := t.db.storage[heightInd][toKey(buf.Bytes())]; ok && bytes.Equal(data, blockBytes) {
+		t.batch[heightInd][toKey(buf.Bytes())] = nil
+	}
+
+	t.batch[blocksInd][toKey(hash)] = nil
+
+	// Map stateKey to the parent block
+	t.batch[stateInd][toKey(stateKey)] = b.Header.PrevBlockHash
+
+	return nil
+}
+
+// RevertToHeight deletes all blocks above height, one by one starting from the
+// chain tip, along with the provisioner sets stored above height, and resets
+// the chain tip to the block at height.
+func (t *transaction) RevertToHeight(height uint64) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	if height > tip {
+		return fmt.Errorf("cannot revert to height %d above the chain tip %d", height, tip)
+	}
+
+	hash, err := t.FetchBlockHashByHeight(height)
+	if err != nil {
+		return err
+	}
+
+	for h := tip; h > height; h-- {
+		blockHash, err := t.FetchBlockHashByHeight(h)
+		if err != nil {
+			return err
+		}
+
+		if err := t.DeleteBlock(blockHash); err != nil {
+			return err
+		}
+	}
+
+	// The provisioner sets of the deleted blocks are not in effect anymore
+	for k := range t.db.storage[provisionersInd] {
+		if binary.BigEndian.Uint64(k[:8]) > height {
+			t.batch[provisionersInd][k] = nil
+		}
+	}
+
+	// Map stateKey to chain state (tip)
+	t.batch[stateInd][toKey(stateKey)] = hash
+
+	return nil
+}
+
 // Commit writes a batch to LevelDB storage. See also fsyncEnabled variable.
 func (t *transaction) Commit() error {
 	if !t.writable {
@@ -94,6 +210,12 @@ func (t *transaction) Commit() error {
 	/// commit changes
 	for i := range t.db.storage {
 		for k, v := range t.batch[i] {
+			// nil value marks a deleted entry
+			if v == nil {
+				delete(t.db.storage[i], k)
+				continue
+			}
+
 			t.db.storage[i][k] = v
 		}
 	}
@@ -305,6 +427,97 @@ func (t transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (
 	return tip - n + pos, nil
 }
 
+// FetchPrunedHeight always returns 0, as lite driver does not support pruning.
+func (t transaction) FetchPrunedHeight() (uint64, error) {
+	return 0, nil
+}
+
+// FetchBlockHeightByTimestamp scans all blocks, as lite driver does not
+// maintain secondary indexes.
+func (t transaction) FetchBlockHeightByTimestamp(unixTime int64) (uint64, error) {
+	var height uint64
+
+	found := false
+
+	for _, data := range t.db.storage[blocksInd] {
+		b := block.NewBlock()
+		if err := message.UnmarshalBlock(bytes.NewBuffer(data), b); err != nil {
+			return 0, err
+		}
+
+		if b.Header.Timestamp >= unixTime && (!found || b.Header.Height < height) {
+			height = b.Header.Height
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, database.ErrBlockNotFound
+	}
+
+	return height, nil
+}
+
+// FetchTxIDsByType walks the blocks in the height range, as lite driver does
+// not maintain secondary indexes.
+func (t transaction) FetchTxIDsByType(txType transactions.TxType, fromHeight, toHeight uint64, limit int) ([][]byte, error) {
+	if fromHeight > toHeight {
+		return nil, errors.New("invalid height range")
+	}
+
+	txIDs := make([][]byte, 0)
+
+	tip, err := t.FetchCurrentHeight()
+	if err == database.ErrStateNotFound {
+		return txIDs, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if toHeight > tip {
+		toHeight = tip
+	}
+
+	if fromHeight > toHeight {
+		return txIDs, nil
+	}
+
+	for height := toHeight; ; height-- {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err == nil {
+			txs, err := t.FetchBlockTxs(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := len(txs) - 1; i >= 0; i-- {
+				if txs[i].Type() != txType {
+					continue
+				}
+
+				txID, err := txs[i].CalculateHash()
+				if err != nil {
+					return nil, err
+				}
+
+				txIDs = append(txIDs, txID)
+
+				if limit > 0 && len(txIDs) >= limit {
+					return txIDs, nil
+				}
+			}
+		}
+
+		if height == fromHeight {
+			break
+		}
+	}
+
+	return txIDs, nil
+}
+
 func (t *transaction) StoreCandidateMessage(cm block.Block) error {
 	buf := new(bytes.Buffer)
 	if err := message.MarshalBlock(buf, &cm); err != nil {
@@ -337,6 +550,176 @@ func (t *transaction) ClearCandidateMessages() error {
 	return nil
 }
 
+// StoreEvidence stores the evidence of an equivocation by hash.
+func (t *transaction) StoreEvidence(e *slashing.Evidence) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	hash, err := e.Hash()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := t.db.storage[evidenceInd][toKey(hash)]; ok {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := slashing.Marshal(buf, *e); err != nil {
+		return err
+	}
+
+	t.batch[evidenceInd][toKey(hash)] = buf.Bytes()
+	return nil
+}
+
+// FetchEvidence returns the evidence of an equivocation by hash.
+func (t transaction) FetchEvidence(hash []byte) (*slashing.Evidence, error) {
+	data, ok := t.db.storage[evidenceInd][toKey(hash)]
+	if !ok {
+		return nil, database.ErrEvidenceNotFound
+	}
+
+	e := new(slashing.Evidence)
+	if err := slashing.Unmarshal(bytes.NewBuffer(data), e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FetchEvidenceByRound scans the whole evidence table, as equivocations are
+// expected to be rare. Evidence within the same round is ordered by hash.
+func (t transaction) FetchEvidenceByRound(fromRound, toRound uint64) ([]slashing.Evidence, error) {
+	if fromRound > toRound {
+		return nil, errors.New("invalid round range")
+	}
+
+	hashes := make([]key, 0)
+	list := make([]slashing.Evidence, 0)
+
+	for k, data := range t.db.storage[evidenceInd] {
+		var e slashing.Evidence
+		if err := slashing.Unmarshal(bytes.NewBuffer(data), &e); err != nil {
+			return nil, err
+		}
+
+		if e.Round >= fromRound && e.Round <= toRound {
+			hashes = append(hashes, k)
+			list = append(list, e)
+		}
+	}
+
+	sort.Sort(evidenceByRound{hashes, list})
+	return list, nil
+}
+
+// evidenceByRound sorts the evidence along with the table keys.
+type evidenceByRound struct {
+	hashes []key
+	list   []slashing.Evidence
+}
+
+func (s evidenceByRound) Len() int {
+	return len(s.list)
+}
+
+func (s evidenceByRound) Less(i, j int) bool {
+	if s.list[i].Round != s.list[j].Round {
+		return s.list[i].Round < s.list[j].Round
+	}
+
+	return bytes.Compare(s.hashes[i][:], s.hashes[j][:]) < 0
+}
+
+func (s evidenceByRound) Swap(i, j int) {
+	s.hashes[i], s.hashes[j] = s.hashes[j], s.hashes[i]
+	s.list[i], s.list[j] = s.list[j], s.list[i]
+}
+
+// slashKey returns the key of the equivocation reported by a Slash tx, if
+// any. The key is hashed, as the public key does not fit in a table key.
+func slashKey(tx transactions.ContractCall) (key, bool) {
+	if tx.Type() != transactions.Slash {
+		return key{}, false
+	}
+
+	e, err := slashing.Decode(tx)
+	if err != nil {
+		return key{}, false
+	}
+
+	return slotKey(e.PubKeyBLS, e.Round, e.Step), true
+}
+
+func slotKey(pubKey []byte, round uint64, step uint8) key {
+	buf := make([]byte, 9, 9+len(pubKey))
+	binary.BigEndian.PutUint64(buf, round)
+	buf[8] = step
+
+	digest, _ := hash.Sha3256(append(buf, pubKey...))
+	return toKey(digest)
+}
+
+// FetchSlashBlock returns the hash of the block whose Slash tx reported the
+// equivocation of the provisioner at round and step.
+func (t transaction) FetchSlashBlock(pubKey []byte, round uint64, step uint8) ([]byte, error) {
+	blockHash, ok := t.db.storage[slashesInd][slotKey(pubKey, round, step)]
+	if !ok {
+		return nil, database.ErrSlashNotFound
+	}
+
+	return blockHash, nil
+}
+
+// StoreProvisioners records the provisioner set in effect after the block at
+// height. The table is keyed by the big endian height.
+func (t *transaction) StoreProvisioners(height uint64, p *user.Provisioners) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, p); err != nil {
+		return err
+	}
+
+	var heightKey [8]byte
+
+	binary.BigEndian.PutUint64(heightKey[:], height)
+	t.batch[provisionersInd][toKey(heightKey[:])] = buf.Bytes()
+
+	return nil
+}
+
+// FetchProvisioners scans the whole provisioners table for the most recent
+// set stored at or below height.
+func (t transaction) FetchProvisioners(height uint64) (*user.Provisioners, error) {
+	var (
+		data  []byte
+		found uint64
+	)
+
+	for k, v := range t.db.storage[provisionersInd] {
+		h := binary.BigEndian.Uint64(k[:8])
+		if h <= height && (data == nil || h > found) {
+			data, found = v, h
+		}
+	}
+
+	if data == nil {
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	p, err := user.UnmarshalProvisioners(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
 func (t transaction) ClearDatabase() error {
 	for key := range t.db.storage {
 		t.db.storage[key] = make(table)
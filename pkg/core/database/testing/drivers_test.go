@@ -19,8 +19,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
@@ -28,6 +32,7 @@ import (
 
 	// Import here any supported drivers to verify if they are fully compliant
 	// to the blockchain database layer requirements.
+	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/bbolt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
@@ -54,6 +59,11 @@ var (
 func TestMain(m *testing.M) {
 	var code int
 
+	// Secondary indexes are optional, and tested here.
+	r := config.Get()
+	r.Database.Indexes = true
+	config.Mock(&r)
+
 	// Run on all registered drivers.
 	for _, driverName := range database.Drivers() {
 		code = _TestDriver(m, driverName)
@@ -191,6 +201,178 @@ func TestStoreBlock(test *testing.T) {
 	}
 }
 
+func TestDeleteBlock(test *testing.T) {
+	genBlocks, err := generateChainBlocks(1)
+	if err != nil {
+		test.Fatal(err.Error())
+	}
+
+	blk := genBlocks[0]
+
+	// Chain the new block to the current tip
+	err = db.View(func(t database.Transaction) error {
+		s, err1 := t.FetchState()
+		if err1 != nil {
+			return err1
+		}
+
+		blk.Header.PrevBlockHash = s.TipHash
+		return nil
+	})
+	require.NoError(test, err)
+
+	require.NoError(test, storeBlocks(db, genBlocks))
+
+	err = db.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(blk.Header.Hash)
+	})
+	require.NoError(test, err)
+
+	err = db.View(func(t database.Transaction) error {
+		if _, err1 := t.FetchBlockExists(blk.Header.Hash); err1 != database.ErrBlockNotFound {
+			return errors.New("deleted block still exists")
+		}
+
+		if _, err1 := t.FetchBlockHashByHeight(blk.Header.Height); err1 != database.ErrBlockNotFound {
+			return errors.New("deleted block is still indexed by height")
+		}
+
+		for _, tx := range blk.Txs {
+			txID, err1 := tx.CalculateHash()
+			if err1 != nil {
+				return err1
+			}
+
+			if _, _, _, err1 := t.FetchBlockTxByHash(txID); err1 != database.ErrTxNotFound {
+				return errors.New("deleted tx still exists")
+			}
+		}
+
+		// Ensure chain tip is moved back to the parent block
+		s, err1 := t.FetchState()
+		if err1 != nil {
+			return err1
+		}
+
+		if !bytes.Equal(blk.Header.PrevBlockHash, s.TipHash) {
+			return errors.New("invalid chain tip")
+		}
+
+		return nil
+	})
+	require.NoError(test, err)
+}
+
+func TestRevertToHeight(test *testing.T) {
+	var tipHash []byte
+	var tipHeight uint64
+
+	err := db.View(func(t database.Transaction) error {
+		s, err1 := t.FetchState()
+		if err1 != nil {
+			return err1
+		}
+
+		tipHash = s.TipHash
+
+		tipHeight, err1 = t.FetchCurrentHeight()
+		return err1
+	})
+	require.NoError(test, err)
+
+	// Store a few more blocks on top of the chain tip
+	genBlocks, err := generateChainBlocks(3)
+	require.NoError(test, err)
+
+	prevHash := tipHash
+	for i, blk := range genBlocks {
+		blk.Header.Height = tipHeight + uint64(i) + 1
+		blk.Header.PrevBlockHash = prevHash
+		prevHash = blk.Header.Hash
+	}
+
+	require.NoError(test, storeBlocks(db, genBlocks))
+
+	// Provisioner sets of the new blocks
+	sets := make([]*user.Provisioners, 2)
+
+	err = db.Update(func(t database.Transaction) error {
+		for i := range sets {
+			sets[i] = user.NewProvisioners()
+			if err1 := sets[i].Add(key.NewRandKeys().BLSPubKey, 1000, 0, 100); err1 != nil {
+				return err1
+			}
+
+			if err1 := t.StoreProvisioners(tipHeight+uint64(2*i)+1, sets[i]); err1 != nil {
+				return err1
+			}
+		}
+
+		return nil
+	})
+	require.NoError(test, err)
+
+	// Reverting above the chain tip must fail
+	err = db.Update(func(t database.Transaction) error {
+		return t.RevertToHeight(tipHeight + 10)
+	})
+	require.Error(test, err)
+
+	err = db.Update(func(t database.Transaction) error {
+		return t.RevertToHeight(tipHeight)
+	})
+	require.NoError(test, err)
+
+	err = db.View(func(t database.Transaction) error {
+		for _, blk := range genBlocks {
+			if _, err1 := t.FetchBlockExists(blk.Header.Hash); err1 != database.ErrBlockNotFound {
+				return fmt.Errorf("block at height %d was not reverted", blk.Header.Height)
+			}
+
+			if _, err1 := t.FetchBlockHashByHeight(blk.Header.Height); err1 != database.ErrBlockNotFound {
+				return fmt.Errorf("height %d is still indexed", blk.Header.Height)
+			}
+		}
+
+		s, err1 := t.FetchState()
+		if err1 != nil {
+			return err1
+		}
+
+		if !bytes.Equal(tipHash, s.TipHash) {
+			return errors.New("invalid chain tip")
+		}
+
+		height, err1 := t.FetchCurrentHeight()
+		if err1 != nil {
+			return err1
+		}
+
+		if height != tipHeight {
+			return fmt.Errorf("expected height %d but got %d", tipHeight, height)
+		}
+
+		// The sets stored above the tip are deleted
+		p, err1 := t.FetchProvisioners(tipHeight + 3)
+		if err1 == database.ErrProvisionersNotFound {
+			return nil
+		}
+
+		if err1 != nil {
+			return err1
+		}
+
+		for _, set := range sets {
+			if set.Set.Equal(p.Set) {
+				return errors.New("provisioners above the chain tip were not reverted")
+			}
+		}
+
+		return nil
+	})
+	require.NoError(test, err)
+}
+
 func TestFetchBlockExists(test *testing.T) {
 	test.Parallel()
 
@@ -356,6 +538,232 @@ func TestFetchBlockHashByHeight(test *testing.T) {
 	})
 }
 
+func TestFetchBlockHeightByTimestamp(test *testing.T) {
+	test.Parallel()
+
+	_ = db.View(func(t database.Transaction) error {
+		for _, block := range blocks {
+			// Exact timestamp
+			height, err := t.FetchBlockHeightByTimestamp(block.Header.Timestamp)
+			require.NoError(test, err)
+			require.Equal(test, block.Header.Height, height)
+
+			// Timestamp between the block and its predecessor
+			height, err = t.FetchBlockHeightByTimestamp(block.Header.Timestamp - 1)
+			require.NoError(test, err)
+			require.Equal(test, block.Header.Height, height)
+		}
+
+		_, err := t.FetchBlockHeightByTimestamp(math.MaxInt64)
+		require.Equal(test, database.ErrBlockNotFound, err)
+
+		return nil
+	})
+}
+
+func TestFetchTxIDsByType(test *testing.T) {
+	test.Parallel()
+
+	from := blocks[2].Header.Height
+	to := blocks[5].Header.Height
+
+	_ = db.View(func(t database.Transaction) error {
+		// Expected txs, the most recent first
+		expected := make([][]byte, 0)
+
+		for i := 5; i >= 2; i-- {
+			txs := blocks[i].Txs
+
+			for j := len(txs) - 1; j >= 0; j-- {
+				if txs[j].Type() != transactions.Distribute {
+					continue
+				}
+
+				txID, err := txs[j].CalculateHash()
+				require.NoError(test, err)
+
+				expected = append(expected, txID)
+			}
+		}
+
+		txIDs, err := t.FetchTxIDsByType(transactions.Distribute, from, to, 0)
+		require.NoError(test, err)
+		require.Equal(test, expected, txIDs)
+
+		// Limit is applied to the most recent txs
+		limited, err := t.FetchTxIDsByType(transactions.Distribute, from, to, 2)
+		require.NoError(test, err)
+		require.Equal(test, txIDs[:2], limited)
+
+		// Any tx can be looked up by its type and height
+		for _, tx := range blocks[0].Txs {
+			txID, err := tx.CalculateHash()
+			require.NoError(test, err)
+
+			height := blocks[0].Header.Height
+			txIDs, err := t.FetchTxIDsByType(tx.Type(), height, height, 0)
+			require.NoError(test, err)
+			require.Contains(test, txIDs, txID)
+		}
+
+		_, err = t.FetchTxIDsByType(transactions.Distribute, to, from, 0)
+		require.Error(test, err)
+
+		return nil
+	})
+}
+
+func TestEvidence(test *testing.T) {
+	evidence := []*slashing.Evidence{
+		slashing.MockEvidence(12, 2),
+		slashing.MockEvidence(10, 3),
+		slashing.MockEvidence(10, 1),
+		slashing.MockEvidence(15, 1),
+	}
+
+	err := db.Update(func(t database.Transaction) error {
+		for _, e := range evidence {
+			if err := t.StoreEvidence(e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	require.NoError(test, err)
+
+	// The evidence of an equivocation is stored once, regardless of the
+	// reporter
+	err = db.Update(func(t database.Transaction) error {
+		duplicate := *evidence[0]
+		duplicate.Reporter = evidence[1].Reporter
+
+		return t.StoreEvidence(&duplicate)
+	})
+	require.NoError(test, err)
+
+	_ = db.View(func(t database.Transaction) error {
+		for _, e := range evidence {
+			hash, err := e.Hash()
+			require.NoError(test, err)
+
+			fetched, err := t.FetchEvidence(hash)
+			require.NoError(test, err)
+			require.True(test, e.Equal(*fetched))
+			require.Equal(test, e.Reporter, fetched.Reporter)
+			require.NoError(test, fetched.Verify())
+		}
+
+		_, err := t.FetchEvidence(make([]byte, 32))
+		require.Equal(test, database.ErrEvidenceNotFound, err)
+
+		list, err := t.FetchEvidenceByRound(10, 12)
+		require.NoError(test, err)
+		require.Len(test, list, 3)
+
+		// Ordered by round
+		require.Equal(test, uint64(10), list[0].Round)
+		require.Equal(test, uint64(10), list[1].Round)
+		require.True(test, evidence[0].Equal(list[2]))
+
+		list, err = t.FetchEvidenceByRound(13, math.MaxUint64)
+		require.NoError(test, err)
+		require.Len(test, list, 1)
+		require.True(test, evidence[3].Equal(list[0]))
+
+		list, err = t.FetchEvidenceByRound(16, 20)
+		require.NoError(test, err)
+		require.Empty(test, list)
+
+		_, err = t.FetchEvidenceByRound(12, 10)
+		require.Error(test, err)
+
+		return nil
+	})
+}
+
+func TestSlashes(test *testing.T) {
+	genBlocks, err := generateChainBlocks(1)
+	require.NoError(test, err)
+
+	e := slashing.MockEvidence(20, 4)
+	blk := genBlocks[0]
+	blk.Txs = append([]transactions.ContractCall{slashing.MockSlashTx(e)}, blk.Txs...)
+
+	err = db.View(func(t database.Transaction) error {
+		s, err1 := t.FetchState()
+		if err1 != nil {
+			return err1
+		}
+
+		blk.Header.PrevBlockHash = s.TipHash
+		return nil
+	})
+	require.NoError(test, err)
+
+	require.NoError(test, storeBlocks(db, genBlocks))
+
+	_ = db.View(func(t database.Transaction) error {
+		hash, err := t.FetchSlashBlock(e.PubKeyBLS, e.Round, e.Step)
+		require.NoError(test, err)
+		require.Equal(test, blk.Header.Hash, hash)
+
+		_, err = t.FetchSlashBlock(e.PubKeyBLS, e.Round, e.Step+1)
+		require.Equal(test, database.ErrSlashNotFound, err)
+
+		return nil
+	})
+
+	// The equivocation is not reported anymore once the block is deleted
+	require.NoError(test, db.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(blk.Header.Hash)
+	}))
+
+	_ = db.View(func(t database.Transaction) error {
+		_, err := t.FetchSlashBlock(e.PubKeyBLS, e.Round, e.Step)
+		require.Equal(test, database.ErrSlashNotFound, err)
+
+		return nil
+	})
+}
+
+func TestProvisioners(test *testing.T) {
+	sets := make([]*user.Provisioners, 2)
+
+	for i := range sets {
+		sets[i] = user.NewProvisioners()
+
+		for j := 0; j <= i; j++ {
+			k := key.NewRandKeys()
+			require.NoError(test, sets[i].Add(k.BLSPubKey, 1000, 0, 100))
+		}
+	}
+
+	err := db.Update(func(t database.Transaction) error {
+		if err := t.StoreProvisioners(5, sets[0]); err != nil {
+			return err
+		}
+
+		return t.StoreProvisioners(8, sets[1])
+	})
+	require.NoError(test, err)
+
+	_ = db.View(func(t database.Transaction) error {
+		_, err := t.FetchProvisioners(4)
+		require.Equal(test, database.ErrProvisionersNotFound, err)
+
+		// The most recent set at or below the height
+		for height, i := range map[uint64]int{5: 0, 7: 0, 8: 1, 9: 1, math.MaxUint64: 1} {
+			p, err := t.FetchProvisioners(height)
+			require.NoError(test, err)
+			require.True(test, sets[i].Set.Equal(p.Set))
+			require.Equal(test, sets[i].TotalWeight(), p.TotalWeight())
+		}
+
+		return nil
+	})
+}
+
 // TestAtomicUpdates ensures no change is applied into storage state when DB
 // writable tx does fail.
 // That said, no parallelism should be applied.
@@ -16,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/firststep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/secondstep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
@@ -136,6 +137,9 @@ func (c *Consensus) CreateStateMachine(db database.DB, consensusTimeOut time.Dur
 func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.Controller, round consensus.RoundUpdate) consensus.Results {
 	defer c.teardown(round)
 
+	start := c.Now()
+	c.Telemetry.StartRound(round.Round, start)
+
 	// Allow listeners to report warnings
 	for _, l := range c.listeners {
 		l.SetLogLevel(log.InfoLevel)
@@ -175,7 +179,9 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 	// synchronous consensus loop keeps running until the agreement invokes
 	// context.Done or the context is canceled some other way
 	for step := uint8(1); ; step++ {
+		c.Telemetry.StartStep(round.Round, step, c.Now())
 		phaseFunction = phaseFunction.Run(stepCtx, c.eventQueue, c.eventChan, round, step)
+		c.Telemetry.EndStep(round.Round, step, c.Now())
 		// if result is nil, this round is over
 		if phaseFunction == nil {
 			lg.
@@ -188,8 +194,11 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 				// Take round results from the agreement goroutine
 			select {
 			case results := <-resultsChan:
+				c.observe(round.Round, results, c.Now().Sub(start))
+				c.record(round.Round, results)
 				return results
 			default:
+				c.record(round.Round, consensus.Results{Err: context.Canceled})
 				return consensus.Results{Blk: block.Block{}, Err: context.Canceled}
 			}
 		}
@@ -213,6 +222,7 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 					"step":  step,
 				}).
 				Error("max steps reached")
+			c.record(round.Round, consensus.Results{Err: ErrMaxStepsReached})
 			return consensus.Results{Blk: block.Block{}, Err: ErrMaxStepsReached}
 		}
 	}
@@ -238,6 +248,63 @@ func (c *Consensus) teardown(round consensus.RoundUpdate) {
 	}
 }
 
+// observe feeds the duration of a round to the adaptive timeouts, if any.
+func (c *Consensus) observe(round uint64, results consensus.Results, d time.Duration) {
+	if c.Timeouts == nil || results.Err != nil || results.Blk.Header == nil {
+		return
+	}
+
+	cert := results.Blk.Header.Certificate
+	if cert == nil {
+		return
+	}
+
+	c.Timeouts.Observe(d, cert.Step)
+
+	lg.
+		WithFields(log.Fields{
+			"round":    round,
+			"steps":    cert.Step,
+			"duration": d,
+			"timeout":  c.Timeouts.Base(),
+		}).
+		Debug("step timeout adapted")
+
+	if config.Get().API.Enabled {
+		go reportRound(round, cert.Step, d, c.Timeouts.Base())
+	}
+}
+
+// record completes the telemetry of a round, if any, and stores it.
+func (c *Consensus) record(round uint64, results consensus.Results) {
+	var (
+		hash []byte
+		step uint8
+	)
+
+	if results.Err == nil && results.Blk.Header != nil {
+		hash = results.Blk.Header.Hash
+
+		if cert := results.Blk.Header.Certificate; cert != nil {
+			step = cert.Step
+		}
+	}
+
+	rec := c.Telemetry.EndRound(round, c.Now(), hash, step, results.Err)
+	if rec == nil {
+		return
+	}
+
+	go func() {
+		if err := capi.GetStormDBInstance().Save(rec); err != nil {
+			lg.
+				WithField("round", round).
+				WithError(err).
+				Error("could not save RoundRecordJSON into StormDB")
+		}
+	}()
+}
+
 var steps = []string{"selection", "reduction1", "reduction2"} // nolint
 
 func report(round uint64, step uint8) {
@@ -256,6 +323,30 @@ func report(round uint64, step uint8) {
 	*/
 }
 
+func reportRound(round uint64, step uint8, d, timeout time.Duration) {
+	store(capi.RoundInfoJSON{
+		Round:     round,
+		Step:      step,
+		UpdatedAt: time.Now(),
+		Method:    "Agreement",
+		Name:      "agreement",
+		Timeout:   timeout,
+		Duration:  d,
+	})
+}
+
+func store(info capi.RoundInfoJSON) {
+	if err := capi.GetStormDBInstance().Save(&info); err != nil {
+		lg.
+			WithFields(log.Fields{
+				"round": info.Round,
+				"step":  info.Step,
+			}).
+			WithError(err).
+			Error("could not save RoundInfoJSON into StormDB")
+	}
+}
+
 // phase should start by
 // - cleaning the events from the previous round
 // - cleaning the events from the previous steps
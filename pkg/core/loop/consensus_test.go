@@ -9,12 +9,14 @@ package loop
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
@@ -191,3 +193,38 @@ func TestClearQueues(t *testing.T) {
 
 	require.Empty(t, l.eventQueue.Flush(1))
 }
+
+// TestTelemetry tests that the record of a round is stored when it finishes.
+func TestTelemetry(t *testing.T) {
+	store, err := capi.NewStormDBInstance(filepath.Join(t.TempDir(), "api.db"))
+	require.NoError(t, err)
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	capi.SetStormDBInstance(store)
+
+	e := consensus.MockEmitter(time.Second)
+	e.Telemetry = consensus.NewTelemetry()
+
+	ctx := context.Background()
+	l := New(e, keys.NewPublicKey())
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	_ = l.Spin(ctx, &step{&wg}, &succesfulAgreement{&wg}, consensus.RoundUpdate{Round: uint64(1)})
+
+	var rec capi.RoundRecordJSON
+
+	require.Eventually(t, func() bool {
+		return store.Find("Round", uint64(1), &rec) == nil
+	}, time.Second, 10*time.Millisecond)
+
+	require.Empty(t, rec.Error)
+	require.Len(t, rec.Steps, 1)
+	require.Equal(t, "selection", rec.Steps[0].Phase)
+	require.False(t, rec.Steps[0].End.Before(rec.Steps[0].Start))
+}
@@ -9,6 +9,8 @@ package mempool
 import (
 	"bytes"
 	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -20,7 +22,9 @@ import (
 const (
 	feePrefix       = "fi:"
 	feeIndex        = "fee_index"
-	itemsCountPerTx = 2
+	ratePrefix      = "fr:"
+	rateIndex       = "fee_rate_index"
+	itemsCountPerTx = 3
 )
 
 const (
@@ -36,11 +40,17 @@ type (
 
 		// Cumulative size of all added transactions
 		cumulativeTxsSize uint32
+
+		// spent nullifiers from the transactions in the pool, indexed in
+		// memory only.
+		lock  *sync.RWMutex
+		spent map[string]txHash
 	}
 )
 
 // newBuntdbPool opens/creates buntdb file with buntdb.EverySecond config.
-// fee_index is created to sort transaction ids by fee.
+// fee_index is created to sort transaction ids by fee, and fee_rate_index by
+// fee per byte.
 func (m *buntdbPool) Create(path string) error {
 	db, errOpen := buntdb.Open(path)
 	if errOpen != nil {
@@ -71,30 +81,29 @@ func (m *buntdbPool) Create(path string) error {
 		log.WithError(err).Warn("could not create indices")
 	}
 
-	// Sum cumulative trasactions size
+	// Sum cumulative trasactions size and index the spent nullifiers
 	cumulativeTxsSize := uint32(0)
-	_ = db.View(func(tx *buntdb.Tx) error {
-		err := tx.Ascend("", func(key, value string) bool {
-			buf := bytes.NewBufferString(value)
+	m.lock = &sync.RWMutex{}
+	m.spent = make(map[string]txHash)
 
-			txd, err := unmarshalTxDesc(buf, needTxSizeOnly)
-			if err != nil {
-				panic(err)
-			}
+	_ = m.Range(func(k txHash, t TxDesc) error {
+		cumulativeTxsSize += uint32(t.size)
 
-			cumulativeTxsSize += uint32(txd.size)
-			return true // continue iteration
-		})
-		return err
+		for _, n := range t.nullifiers() {
+			m.spent[string(n)] = k
+		}
+
+		return nil
 	})
 
 	atomic.StoreUint32(&m.cumulativeTxsSize, cumulativeTxsSize)
 	return nil
 }
 
-// Put adds new transaction to the pool. It creates two key-value pairs per a tx.
+// Put adds new transaction to the pool. It creates three key-value pairs per a tx.
 // transacton_id -> transaction data (marshaled)
 // fi:transacton_id -> transaction fee value
+// fr:transacton_id -> transaction fee per byte
 func (m *buntdbPool) Put(t TxDesc) error {
 	var key, value bytes.Buffer
 
@@ -123,11 +132,31 @@ func (m *buntdbPool) Put(t TxDesc) error {
 			return err
 		}
 
+		rateKey := ratePrefix + key.String()
+
+		_, _, err = tx.Set(rateKey, strconv.FormatFloat(t.feeRate(), 'g', -1, 64), nil)
+		if err != nil {
+			return err
+		}
+
 		atomic.AddUint32(&m.cumulativeTxsSize, uint32(t.size))
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	var k txHash
+	copy(k[:], txID)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, n := range t.nullifiers() {
+		m.spent[string(n)] = k
+	}
+
+	return nil
 }
 
 // Contains returns true if the given key is in the pool.
@@ -199,9 +228,9 @@ func (m *buntdbPool) Delete(txID []byte) error {
 
 	key.Write(txID)
 
-	// For the purpose of deleting a transaction we need to recalculate cumulativeTxsSize.
-	// getTxDesc is called with needTxSizeOnly to ensure we read tx size only but not entire tx.
-	desc, err := m.getTxDesc(txID, needTxSizeOnly)
+	// For the purpose of deleting a transaction we need to recalculate
+	// cumulativeTxsSize, and to release its nullifiers.
+	desc, err := m.getTxDesc(txID, needFullTx)
 	if err != nil {
 		return errNotFound
 	}
@@ -219,18 +248,42 @@ func (m *buntdbPool) Delete(txID []byte) error {
 			return err
 		}
 
+		_, err = t.Delete(ratePrefix + key.String())
+		if err != nil {
+			return err
+		}
+
 		// subtract deleted tx size
 		atomic.AddUint32(&m.cumulativeTxsSize, ^uint32(size-1))
 		return err
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	var k txHash
+	copy(k[:], txID)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, n := range desc.nullifiers() {
+		if m.spent[string(n)] == k {
+			delete(m.spent, string(n))
+		}
+	}
+
+	return nil
 }
 
 // Range iterates through all tx entries ordered by transaction ids.
 func (m *buntdbPool) Range(fn func(k txHash, t TxDesc) error) error {
 	err := m.db.View(func(tx *buntdb.Tx) error {
 		err := tx.Ascend("", func(key, value string) bool {
+			if isIndexKey(key) {
+				return true
+			}
+
 			buf := bytes.NewBufferString(value)
 
 			txdesc, err := unmarshalTxDesc(buf, needFullTx)
@@ -309,6 +362,53 @@ func (m *buntdbPool) RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error
 	})
 }
 
+// RangeFeeRate iterates through all tx entries sorted by fee per byte
+// in an ascending order.
+func (m *buntdbPool) RangeFeeRate(fn func(k txHash, t TxDesc) (bool, error)) error {
+	return m.db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend(rateIndex, func(rateKey, rate string) bool {
+			txid := rateKey[len(ratePrefix):]
+
+			value, err := tx.Get(txid)
+			if err != nil {
+				return false
+			}
+
+			txdesc, err := unmarshalTxDesc(bytes.NewBufferString(value), needFullTx)
+			if err != nil {
+				panic(err)
+			}
+
+			var t txHash
+			copy(t[:], bytes.NewBufferString(txid).Bytes())
+
+			done, err := fn(t, txdesc)
+			return err == nil && !done
+		})
+	})
+}
+
+// Conflicts returns the tx entries spending any of the nullifiers of t.
+func (m *buntdbPool) Conflicts(t TxDesc) map[txHash]TxDesc {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	conflicts := make(map[txHash]TxDesc)
+
+	for _, n := range t.nullifiers() {
+		k, ok := m.spent[string(n)]
+		if !ok {
+			continue
+		}
+
+		if desc, err := m.getTxDesc(k[:], needFullTx); err == nil {
+			conflicts[k] = desc
+		}
+	}
+
+	return conflicts
+}
+
 // Clone the entire pool.
 func (m *buntdbPool) Clone() []transactions.ContractCall {
 	// Not in use
@@ -323,23 +423,18 @@ func (m *buntdbPool) FilterByType(filterType transactions.TxType) []transactions
 }
 
 func (m *buntdbPool) createIndices() error {
-	var found bool
-	// Create index for sorting txids by fee
+	// Create index for sorting txids by fee, and by fee per byte
 	indexList, err := m.db.Indexes()
 	if err != nil {
 		return err
 	}
 
+	found := make(map[string]bool)
 	for _, name := range indexList {
-		if name != feeIndex {
-			continue
-		}
-
-		found = true
-		break
+		found[name] = true
 	}
 
-	if !found {
+	if !found[feeIndex] {
 		pattern := feePrefix + "*"
 		// An error will occur if an index with the same name already exists.
 		if err := m.db.CreateIndex(feeIndex, pattern, buntdb.IndexInt); err != nil {
@@ -347,9 +442,20 @@ func (m *buntdbPool) createIndices() error {
 		}
 	}
 
+	if !found[rateIndex] {
+		if err := m.db.CreateIndex(rateIndex, ratePrefix+"*", buntdb.IndexFloat); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// isIndexKey returns true for the keys holding the indexed values of a tx.
+func isIndexKey(key string) bool {
+	return strings.HasPrefix(key, feePrefix) || strings.HasPrefix(key, ratePrefix)
+}
+
 func (m buntdbPool) Close() {
 	if err := m.db.Close(); err != nil {
 		log.WithError(err).Warn("buntdb close with error")
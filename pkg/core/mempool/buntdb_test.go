@@ -110,3 +110,38 @@ func TestBuntSortedKeys(tst *testing.T) {
 
 	assert.NotEqual(prevVal != math.MaxUint64, "rangesort not called")
 }
+
+func TestBuntFeeRateKeys(t *testing.T) {
+	dbpath := createTemp("file3.db")
+	defer os.Remove(dbpath)
+
+	pool := buntdbPool{}
+	assert.NoError(t, pool.Create(dbpath))
+
+	testFeeRateKeys(t, &pool)
+}
+
+func TestBuntConflicts(t *testing.T) {
+	assert := assert.New(t)
+
+	dbpath := createTemp("file4.db")
+	defer os.Remove(dbpath)
+
+	pool := buntdbPool{}
+	assert.NoError(pool.Create(dbpath))
+
+	testConflicts(t, &pool)
+
+	// The spent nullifiers are indexed again when the pool is reopened
+	tx := transactions.RandTx()
+	assert.NoError(pool.Put(TxDesc{tx: tx, received: time.Now()}))
+	pool.Close()
+
+	reopened := buntdbPool{}
+	assert.NoError(reopened.Create(dbpath))
+
+	defer reopened.Close()
+
+	assert.Equal(2, reopened.Len())
+	assert.Len(reopened.Conflicts(TxDesc{tx: tx}), 1)
+}
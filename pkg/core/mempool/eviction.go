@@ -0,0 +1,203 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+)
+
+// Reasons of an EvictedTx.
+const (
+	// EvictionExpired is the reason of the txs kept longer than the TTL.
+	EvictionExpired = "expired"
+	// EvictionInvalid is the reason of the txs rejected by the state of a
+	// newly accepted block.
+	EvictionInvalid = "invalid"
+	// EvictionReplaced is the reason of the txs replaced by a conflicting tx
+	// paying a higher fee per byte.
+	EvictionReplaced = "replaced"
+	// EvictionFeeTooLow is the reason of the txs evicted from a full mempool
+	// by a tx paying a higher fee per byte.
+	EvictionFeeTooLow = "fee too low"
+)
+
+// EvictedTx is published on topics.EvictedTx when a tx is dropped from the
+// mempool without being accepted.
+type EvictedTx struct {
+	TxID   []byte
+	Reason string
+}
+
+// Copy an EvictedTx.
+// Implements the payload.Safe interface.
+func (e EvictedTx) Copy() payload.Safe {
+	return EvictedTx{TxID: append([]byte{}, e.TxID...), Reason: e.Reason}
+}
+
+// evict a tx from the pool, and notify the subscribers of topics.EvictedTx.
+func (m *Mempool) evict(k txHash, t TxDesc, reason string) {
+	if err := m.verified.Delete(k[:]); err != nil {
+		// already accepted or evicted
+		return
+	}
+
+	m.verifier.Forget(k[:])
+
+	log.WithField("txid", toHex(k[:])).
+		WithField("txtype", t.tx.Type()).
+		WithField("fee_rate", t.feeRate()).
+		WithField("reason", reason).
+		Debug("evicted transaction")
+
+	e := EvictedTx{TxID: k[:], Reason: reason}
+	errList := m.eventBus.Publish(topics.EvictedTx, message.New(topics.EvictedTx, e))
+	diagnostics.LogPublishErrors("mempool/eviction.go, topics.EvictedTx", errList)
+}
+
+// scheduleSweep queues a sweep of the pool against the state of the block
+// accepted at height. A sweep still queued is superseded.
+func (m *Mempool) scheduleSweep(height uint64) {
+	select {
+	case <-m.pendingSweep:
+	default:
+	}
+
+	m.pendingSweep <- height
+}
+
+// sweepLoop sweeps the pool after the blocks are accepted, one sweep at a
+// time.
+func (m *Mempool) sweepLoop(ctx context.Context) {
+	for {
+		select {
+		case height := <-m.pendingSweep:
+			// The verifications performed on the previous state are outdated,
+			// so the pending txs are verified again by revalidate
+			m.refreshVerifier(height)
+
+			m.expire()
+			m.revalidate()
+
+			// The accepted and evicted txs are dropped from the journal
+			m.rotateJournal()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshVerifier notifies the verifier of the block accepted at height.
+func (m *Mempool) refreshVerifier(height uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(),
+		time.Duration(config.Get().RPC.Rusk.ContractTimeout)*time.Millisecond)
+	defer cancel()
+
+	if err := m.verifier.Refresh(ctx, height); err != nil {
+		log.WithError(err).
+			WithField("blk_height", height).
+			Warn("could not refresh the verifier")
+	}
+}
+
+// expire evicts the txs received longer than the TTL ago.
+func (m *Mempool) expire() {
+	if m.txTTL == 0 {
+		return
+	}
+
+	deadline := time.Now().Add(-m.txTTL)
+	expired := make(map[txHash]TxDesc)
+
+	_ = m.verified.Range(func(k txHash, t TxDesc) error {
+		if t.received.Before(deadline) {
+			expired[k] = t
+		}
+
+		return nil
+	})
+
+	// The pool cannot be updated while iterating
+	for k, t := range expired {
+		m.evict(k, t, EvictionExpired)
+	}
+}
+
+// revalidate verifies the txs of the pool against the current state, and
+// evicts the ones rejected. The txs are verified concurrently, for the
+// verifier to batch them. Slash txs are checked locally instead, and evicted
+// once their equivocation is reported by a block.
+func (m *Mempool) revalidate() {
+	pending := make(map[txHash]TxDesc)
+	slashed := make(map[txHash]TxDesc)
+
+	_ = m.verified.Range(func(k txHash, t TxDesc) error {
+		if t.tx.Type() == transactions.Slash {
+			if m.checkSlash(t.tx) == inspector.ErrAlreadySlashed {
+				slashed[k] = t
+			}
+
+			return nil
+		}
+
+		pending[k] = t
+		return nil
+	})
+
+	// The pool cannot be updated while iterating
+	for k, t := range slashed {
+		m.evict(k, t, EvictionInvalid)
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		time.Duration(config.Get().RPC.Rusk.ContractTimeout)*time.Millisecond)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		invalid = make(map[txHash]TxDesc)
+	)
+
+	for k, t := range pending {
+		wg.Add(1)
+
+		go func(k txHash, t TxDesc) {
+			defer wg.Done()
+
+			// Other errors, like timeouts, say nothing of the tx
+			if err := m.verifier.VerifyTransaction(ctx, t.tx); err == transactions.ErrVerificationFailed {
+				lock.Lock()
+				invalid[k] = t
+				lock.Unlock()
+			}
+		}(k, t)
+	}
+
+	wg.Wait()
+
+	for k, t := range invalid {
+		m.evict(k, t, EvictionInvalid)
+	}
+
+	log.WithField("txs_count", len(pending)).
+		WithField("invalid_count", len(invalid)).
+		Debug("revalidation completed")
+}
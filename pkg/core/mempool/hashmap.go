@@ -19,6 +19,11 @@ type (
 		f uint64
 	}
 
+	keyRate struct {
+		k txHash
+		r float64
+	}
+
 	// HashMap represents a pool implementation based on golang map. The generic
 	// solution to bench against.
 	HashMap struct {
@@ -31,8 +36,13 @@ type (
 		// Block Generator to fetch highest-fee txs without delays in sorting.
 		sorted []keyFee
 
-		// spent key images from the transactions in the pool
-		// spentkeyImages map[keyImage]bool.
+		// byRate is data keys sorted by fee per byte in an ascending order,
+		// for the lowest paying txs to be evicted first.
+		byRate []keyRate
+
+		// spent nullifiers from the transactions in the pool.
+		spent map[string]txHash
+
 		Capacity uint32
 		txsSize  uint32
 	}
@@ -42,6 +52,8 @@ type (
 func (m *HashMap) Create(path string) error {
 	m.data = make(map[txHash]TxDesc, m.Capacity)
 	m.sorted = make([]keyFee, 0, m.Capacity)
+	m.byRate = make([]keyRate, 0, m.Capacity)
+	m.spent = make(map[string]txHash)
 
 	return nil
 }
@@ -87,6 +99,24 @@ func (m *HashMap) Put(t TxDesc) error {
 	copy(m.sorted[index+1:], m.sorted[index:])
 
 	m.sorted[index] = keyFee{k: k, f: fee}
+
+	// sort keys by fee per byte
+	rate := t.feeRate()
+
+	index = sort.Search(len(m.byRate), func(i int) bool {
+		return m.byRate[i].r > rate
+	})
+
+	m.byRate = append(m.byRate, keyRate{})
+
+	copy(m.byRate[index+1:], m.byRate[index:])
+
+	m.byRate[index] = keyRate{k: k, r: rate}
+
+	for _, n := range t.nullifiers() {
+		m.spent[string(n)] = k
+	}
+
 	return nil
 }
 
@@ -178,6 +208,19 @@ func (m *HashMap) Delete(txID []byte) error {
 		}
 	}
 
+	for i, entry := range m.byRate {
+		if entry.k == k {
+			m.byRate = append(m.byRate[:i], m.byRate[i+1:]...)
+			break
+		}
+	}
+
+	for _, n := range tx.nullifiers() {
+		if m.spent[string(n)] == k {
+			delete(m.spent, string(n))
+		}
+	}
+
 	return nil
 }
 
@@ -230,6 +273,42 @@ func (m *HashMap) RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error {
 	return nil
 }
 
+// RangeFeeRate iterates through all tx entries sorted by fee per byte
+// in an ascending order.
+func (m *HashMap) RangeFeeRate(fn func(k txHash, t TxDesc) (bool, error)) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, value := range m.byRate {
+		done, err := fn(value.k, m.data[value.k])
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Conflicts returns the tx entries spending any of the nullifiers of t.
+func (m *HashMap) Conflicts(t TxDesc) map[txHash]TxDesc {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	conflicts := make(map[txHash]TxDesc)
+
+	for _, n := range t.nullifiers() {
+		if k, ok := m.spent[string(n)]; ok {
+			conflicts[k] = m.data[k]
+		}
+	}
+
+	return conflicts
+}
+
 // Close empty implementation of Pool.Close.
 func (m *HashMap) Close() {
 }
@@ -83,6 +83,84 @@ func TestStableSortedKeys(t *testing.T) {
 	}
 }
 
+func TestFeeRateKeys(t *testing.T) {
+	pool := &HashMap{lock: &sync.RWMutex{}, Capacity: 100}
+	pool.Create("")
+
+	testFeeRateKeys(t, pool)
+}
+
+func TestConflicts(t *testing.T) {
+	pool := &HashMap{lock: &sync.RWMutex{}, Capacity: 100}
+	pool.Create("")
+
+	testConflicts(t, pool)
+}
+
+// testFeeRateKeys expects the pool to iterate from the lowest fee per byte
+// tx, whatever its fee.
+func testFeeRateKeys(t *testing.T, pool Pool) {
+	assert := assert.New(t)
+
+	for i := 0; i < 20; i++ {
+		tx := transactions.RandTx()
+		tx.Payload.Fee.GasPrice = uint64(rand.Intn(1000))
+
+		td := TxDesc{tx: tx, received: time.Now(), size: uint(100 + rand.Intn(1000))}
+		assert.NoError(pool.Put(td))
+	}
+
+	var (
+		prevRate float64
+		count    int
+	)
+
+	err := pool.RangeFeeRate(func(k txHash, t TxDesc) (bool, error) {
+		if t.feeRate() < prevRate {
+			return false, errors.New("keys not in an ascending order")
+		}
+
+		prevRate = t.feeRate()
+		count++
+		return false, nil
+	})
+
+	assert.NoError(err)
+	assert.Equal(20, count)
+}
+
+// testConflicts expects the pool to find the txs spending the same
+// nullifiers, until they are deleted.
+func testConflicts(t *testing.T, pool Pool) {
+	assert := assert.New(t)
+
+	tx := transactions.RandTx()
+	td := TxDesc{tx: tx, received: time.Now()}
+	assert.NoError(pool.Put(td))
+	assert.NoError(pool.Put(TxDesc{tx: transactions.RandTx(), received: time.Now()}))
+
+	// A tx spending one of the nullifiers conflicts
+	conflicting := transactions.RandTx()
+	conflicting.Payload.Nullifiers = append(conflicting.Payload.Nullifiers, tx.Payload.Nullifiers[0])
+
+	hash, err := tx.CalculateHash()
+	assert.NoError(err)
+
+	var k txHash
+	copy(k[:], hash)
+
+	conflicts := pool.Conflicts(TxDesc{tx: conflicting})
+	assert.Len(conflicts, 1)
+	assert.Contains(conflicts, k)
+
+	// Unrelated txs do not conflict
+	assert.Empty(pool.Conflicts(TxDesc{tx: transactions.RandTx()}))
+
+	// Deleted txs release their nullifiers
+	assert.NoError(pool.Delete(hash))
+	assert.Empty(pool.Conflicts(TxDesc{tx: conflicting}))
+}
+
 func TestGet(t *testing.T) {
 	assert := assert.New(t)
 	txsCount := 10
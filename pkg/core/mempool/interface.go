@@ -31,6 +31,26 @@ type TxDesc struct {
 	kadHeight byte
 }
 
+// feeRate returns the fee per byte of a tx.
+func (t TxDesc) feeRate() float64 {
+	_, fee := t.tx.Values()
+	if t.size == 0 {
+		return float64(fee)
+	}
+
+	return float64(fee) / float64(t.size)
+}
+
+// nullifiers returns the nullifiers spent by a tx.
+func (t TxDesc) nullifiers() [][]byte {
+	payload := t.tx.StandardTx()
+	if payload == nil {
+		return nil
+	}
+
+	return payload.Nullifiers
+}
+
 // Pool represents a transaction pool of the verified txs only.
 type Pool interface {
 	// Create instantiates the underlying data storage.
@@ -62,6 +82,14 @@ type Pool interface {
 	// in a descending order.
 	RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error
 
+	// RangeFeeRate iterates through all tx entries sorted by fee per byte
+	// in an ascending order.
+	RangeFeeRate(fn func(k txHash, t TxDesc) (bool, error)) error
+
+	// Conflicts returns the tx entries spending any of the nullifiers of the
+	// given tx.
+	Conflicts(t TxDesc) map[txHash]TxDesc
+
 	// Close closes backend.
 	Close()
 }
@@ -0,0 +1,292 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+)
+
+// reloadWorkers is the number of journaled txs verified at once on reload. It
+// matches the batch size of the verifier, for the batches to be filled.
+const reloadWorkers = 128
+
+// journal keeps the txs accepted into the mempool on disk, for them to
+// survive restarts. The txs are appended as they are accepted, and the
+// journal is rewritten from the pool once it is swept, which drops the txs
+// accepted into a block or evicted since.
+type journal struct {
+	path string
+
+	lock   *sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+func newJournal(path string) *journal {
+	return &journal{
+		path: path,
+		lock: &sync.Mutex{},
+	}
+}
+
+// load the txs of the journal. A record left incomplete by a crash ends the
+// journal.
+func (j *journal) load() ([]TxDesc, error) {
+	data, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(data)
+	txs := make([]TxDesc, 0)
+
+	for buf.Len() > 0 {
+		n, err := encoding.ReadVarInt(buf)
+		if err != nil || n > uint64(buf.Len()) {
+			log.WithField("path", j.path).
+				Warn("truncated mempool journal")
+			break
+		}
+
+		t, err := unmarshalTxDesc(bytes.NewBuffer(buf.Next(int(n))), needFullTx)
+		if err != nil {
+			log.WithError(err).
+				WithField("path", j.path).
+				Warn("invalid mempool journal record")
+			continue
+		}
+
+		txs = append(txs, t)
+	}
+
+	return txs, nil
+}
+
+// insert appends a tx to the journal.
+func (j *journal) insert(t TxDesc) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeRecord(buf, t); err != nil {
+		return err
+	}
+
+	_, err := j.file.Write(buf.Bytes())
+	return err
+}
+
+// rotate rewrites the journal with the txs of the pool, and reopens it for
+// the txs to come.
+func (j *journal) rotate(pool Pool) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.closed {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := pool.Range(func(k txHash, t TxDesc) error {
+		return writeRecord(buf, t)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The journal is replaced at once, to never be left half written
+	tmp := j.path + ".new"
+	if err := ioutil.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	if j.file != nil {
+		_ = j.file.Close()
+		j.file = nil
+	}
+
+	if err := os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	return err
+}
+
+// opened returns true once the journal is rewritten from the pool, i.e. once
+// the journaled txs are reloaded.
+func (j *journal) opened() bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.file != nil
+}
+
+// close the journal. The txs are no longer journaled.
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.closed = true
+
+	if j.file == nil {
+		return nil
+	}
+
+	err := j.file.Close()
+	j.file = nil
+
+	return err
+}
+
+func writeRecord(w *bytes.Buffer, t TxDesc) error {
+	record := new(bytes.Buffer)
+	if err := marshalTxDesc(record, &t); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(w, record.Bytes())
+}
+
+// reload the txs kept across a restart, i.e. the diskpool entries and the
+// journaled txs. They are verified again against the current tip, and the
+// valid ones are propagated again. The new txs are accepted meanwhile.
+func (m *Mempool) reload(ctx context.Context) {
+	defer close(m.reloaded)
+
+	if m.db != nil {
+		var height uint64
+
+		err := m.db.View(func(t database.Transaction) error {
+			var err error
+			height, err = t.FetchCurrentHeight()
+			return err
+		})
+		if err != nil {
+			log.WithError(err).Warn("could not fetch the tip height")
+		} else {
+			m.refreshVerifier(height)
+		}
+	}
+
+	// The diskpool entries are swept as after an accepted block
+	m.expire()
+	m.revalidate()
+
+	kept := make([]TxDesc, 0)
+
+	_ = m.verified.Range(func(k txHash, t TxDesc) error {
+		// The reloaded txs are propagated as if submitted to this node
+		t.kadHeight = config.KadcastInitialHeight
+		kept = append(kept, t)
+		return nil
+	})
+
+	go func() {
+		for _, t := range kept {
+			select {
+			case m.pendingPropagation <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var journaled []TxDesc
+
+	if m.journal != nil {
+		var err error
+
+		journaled, err = m.journal.load()
+		if err != nil {
+			log.WithError(err).Error("could not load the mempool journal")
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		reloaded int
+		deadline = time.Now().Add(-m.txTTL)
+		queue    = make(chan TxDesc)
+	)
+
+	// The journaled txs go through the admission of new txs, by a fixed
+	// number of workers for the verifier to batch them. The ones already in
+	// the diskpool are rejected as duplicates.
+	for i := 0; i < reloadWorkers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for t := range queue {
+				if _, err := m.processTx(t); err == nil {
+					lock.Lock()
+					reloaded++
+					lock.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, t := range journaled {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if m.txTTL > 0 && t.received.Before(deadline) {
+			continue
+		}
+
+		t.kadHeight = config.KadcastInitialHeight
+		queue <- t
+	}
+
+	close(queue)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	m.rotateJournal()
+
+	log.WithField("kept_count", len(kept)).
+		WithField("journaled_count", len(journaled)).
+		WithField("reloaded_count", reloaded).
+		Info("reload completed")
+}
+
+// rotateJournal rewrites the journal from the pool, if enabled.
+func (m *Mempool) rotateJournal() {
+	if m.journal == nil {
+		return
+	}
+
+	if err := m.journal.rotate(m.verified); err != nil {
+		log.WithError(err).Error("could not rotate the mempool journal")
+	}
+}
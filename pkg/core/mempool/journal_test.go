@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+	j := newJournal(path)
+
+	// No journal yet
+	txs, err := j.load()
+	assert.NoError(err)
+	assert.Empty(txs)
+
+	pool := &HashMap{lock: &sync.RWMutex{}}
+	assert.NoError(pool.Create(""))
+
+	kept := TxDesc{tx: transactions.RandTx(), received: time.Now(), size: 100}
+	assert.NoError(pool.Put(kept))
+
+	// The journal is opened by a rotation
+	assert.False(j.opened())
+	assert.NoError(j.rotate(pool))
+	assert.True(j.opened())
+
+	dropped := TxDesc{tx: transactions.RandTx(), received: time.Now(), size: 200}
+	assert.NoError(j.insert(dropped))
+
+	txs, err = j.load()
+	assert.NoError(err)
+	assert.Len(txs, 2)
+	assert.Equal(kept.size, txs[0].size)
+	assert.Equal(dropped.size, txs[1].size)
+
+	keptHash, err := kept.tx.CalculateHash()
+	assert.NoError(err)
+
+	hash, err := txs[0].tx.CalculateHash()
+	assert.NoError(err)
+	assert.Equal(keptHash, hash)
+
+	// A rotation drops the txs which are no longer in the pool
+	assert.NoError(j.rotate(pool))
+
+	txs, err = j.load()
+	assert.NoError(err)
+	assert.Len(txs, 1)
+
+	// A record left incomplete ends the journal
+	assert.NoError(j.insert(dropped))
+
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.NoError(os.Truncate(path, info.Size()-10))
+
+	txs, err = j.load()
+	assert.NoError(err)
+	assert.Len(txs, 1)
+
+	// Nothing is journaled once closed
+	assert.NoError(j.close())
+	assert.NoError(j.insert(dropped))
+	assert.NoError(j.rotate(pool))
+
+	info2, err := os.Stat(path)
+	assert.NoError(err)
+	assert.Equal(info.Size()-10, info2.Size())
+}
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"sync"
 	"time"
 
@@ -21,8 +22,10 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -49,6 +52,22 @@ var (
 	ErrAlreadyExists = errors.New("already exists")
 	// ErrDoubleSpending transaction uses outputs spent in other mempool txs.
 	ErrDoubleSpending = errors.New("double-spending in mempool")
+	// ErrFeeTooLow transaction pays a fee per byte too low to get in a full
+	// mempool.
+	ErrFeeTooLow = errors.New("fee too low")
+	// ErrReplacementUnderpriced transaction conflicts with mempool txs paying
+	// a fee too close to its own.
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+	// ErrSlashingDisabled Slash txs are refused unless slashing is enabled.
+	ErrSlashingDisabled = errors.New("slashing not enabled")
+	// ErrAlreadyReported Slash tx reports an equivocation already reported
+	// by a mempool tx.
+	ErrAlreadyReported = errors.New("equivocation already reported")
+	// ErrTooManySlashTxs mempool holds as many Slash txs as allowed.
+	ErrTooManySlashTxs = errors.New("too many slash transactions")
+	// ErrUnconfirmedBalance the unconfirmed balance of a key is only known
+	// to the wallet holding it.
+	ErrUnconfirmedBalance = errors.New("unconfirmed balance is served by the wallet")
 )
 
 // Mempool is a storage for the chain transactions that are valid according to the
@@ -74,12 +93,38 @@ type Mempool struct {
 	// the magic function that knows best what is valid chain Tx.
 	verifier transactions.UnconfirmedTxProber
 
+	// admission of verified txs, which may evict pool entries.
+	admission *sync.Mutex
+
+	// txTTL is how long a tx is kept in the pool.
+	txTTL time.Duration
+	// pendingSweep is the height of the last accepted block, if the pool
+	// is not swept yet.
+	pendingSweep chan uint64
+
+	// journal of the accepted txs, if enabled.
+	journal *journal
+	// db holds the tip the reloaded txs are verified against.
+	db database.DB
+	// reloaded is closed once the txs pending before a restart are back.
+	reloaded chan struct{}
+
 	limiter *rate.Limiter
 }
 
 // checkTx is responsible to determine if a tx is valid or not.
 // Among the other checks, the underlying verifier also checks double spending.
 func (m *Mempool) checkTx(tx transactions.ContractCall) error {
+	// Rusk does not slash the accused provisioners yet, their evidence is
+	// checked locally as in the blocks
+	if tx.Type() == transactions.Slash {
+		if !config.Get().Consensus.Slashing {
+			return ErrSlashingDisabled
+		}
+
+		return m.checkSlash(tx)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(),
 		time.Duration(config.Get().RPC.Rusk.ContractTimeout)*time.Millisecond)
 	defer cancel()
@@ -92,6 +137,31 @@ func (m *Mempool) checkTx(tx transactions.ContractCall) error {
 	return nil
 }
 
+// checkSlash verifies the equivocation evidence carried by a Slash tx, as it
+// would be in the next block. See inspector.CheckEvidence.
+func (m *Mempool) checkSlash(tx transactions.ContractCall) error {
+	e, err := slashing.Decode(tx)
+	if err != nil {
+		return err
+	}
+
+	if m.db == nil {
+		return errors.New("no chain to look the committee up")
+	}
+
+	var tip uint64
+
+	err = m.db.View(func(t database.Transaction) error {
+		tip, err = t.FetchCurrentHeight()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return inspector.New(m.db).CheckEvidence(*e, tip+1)
+}
+
 // NewMempool instantiates and initializes node mempool.
 func NewMempool(db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, verifier transactions.UnconfirmedTxProber, srv *grpc.Server) *Mempool {
 	log.Infof("create instance")
@@ -138,6 +208,19 @@ func NewMempool(db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCB
 			WithField("propagate_burst", burst)
 	}
 
+	var txTTL time.Duration
+
+	if len(cfg.TxTTL) > 0 {
+		var err error
+
+		txTTL, err = time.ParseDuration(cfg.TxTTL)
+		if err != nil {
+			log.WithError(err).Fatal("could not parse mempool tx ttl")
+		}
+
+		l = l.WithField("tx_ttl", cfg.TxTTL)
+	}
+
 	m := &Mempool{
 		eventBus:                eventBus,
 		latestBlockTimestamp:    math.MinInt32,
@@ -148,6 +231,17 @@ func NewMempool(db database.DB, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCB
 		verifier:                verifier,
 		limiter:                 limiter,
 		pendingPropagation:      make(chan TxDesc, 1000),
+		admission:               &sync.Mutex{},
+		txTTL:                   txTTL,
+		pendingSweep:            make(chan uint64, 1),
+		db:                      db,
+		reloaded:                make(chan struct{}),
+	}
+
+	if len(cfg.JournalPath) > 0 {
+		m.journal = newJournal(cfg.JournalPath)
+
+		l = l.WithField("journal_path", cfg.JournalPath)
 	}
 
 	// Setting the pool where to cache verified transactions.
@@ -173,6 +267,14 @@ func (m *Mempool) Run(ctx context.Context) {
 
 	// Loop to drain pendingPropagation and try to propagate transaction
 	go m.propagateLoop(ctx)
+
+	// Bring back the txs pending before a restart, then sweep the pool after
+	// each accepted block. The reload verifies every journaled tx, so it does
+	// not hold back the node.
+	go func() {
+		m.reload(ctx)
+		m.sweepLoop(ctx)
+	}()
 }
 
 // Loop listens for GetMempoolTxs request and topics.AcceptedBlock events.
@@ -243,14 +345,6 @@ func (m *Mempool) propagateLoop(ctx context.Context) {
 
 // ProcessTx processes a Transaction wire message.
 func (m *Mempool) ProcessTx(srcPeerID string, msg message.Message) ([]bytes.Buffer, error) {
-	maxSizeBytes := config.Get().Mempool.MaxSizeMB * 1000 * 1000
-	if m.verified.Size() > maxSizeBytes {
-		log.WithField("max_size_mb", maxSizeBytes).
-			WithField("alloc_size", m.verified.Size()/1000).
-			Warn("mempool is full, dropping transaction")
-		return nil, errors.New("mempool is full, dropping transaction")
-	}
-
 	var h byte
 	if len(msg.Header()) > 0 {
 		h = msg.Header()[0]
@@ -307,6 +401,11 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 		return txid, ErrAlreadyExists
 	}
 
+	// expect it pays enough to get in, before the costly verification
+	if _, _, err := m.makeRoom(t); err != nil {
+		return txid, err
+	}
+
 	// execute tx verification procedure
 	if err := m.checkTx(t.tx); err != nil {
 		return txid, fmt.Errorf("verification err - %v", err)
@@ -315,9 +414,8 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 	// if consumer's verification passes, mark it as verified
 	t.verified = time.Now()
 
-	// we've got a valid transaction pushed
-	if err := m.verified.Put(t); err != nil {
-		return txid, fmt.Errorf("store err - %v", err)
+	if err := m.admit(t); err != nil {
+		return txid, err
 	}
 
 	// queue transaction for (re)propagation
@@ -328,9 +426,174 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 	return txid, nil
 }
 
+// admit a verified tx into the pool, in place of the txs it replaces or
+// evicts.
+func (m *Mempool) admit(t TxDesc) error {
+	m.admission.Lock()
+	defer m.admission.Unlock()
+
+	// the pool may have changed during the verification
+	replaced, evicted, err := m.makeRoom(t)
+	if err != nil {
+		return err
+	}
+
+	for k, d := range replaced {
+		m.evict(k, d, EvictionReplaced)
+	}
+
+	for k, d := range evicted {
+		m.evict(k, d, EvictionFeeTooLow)
+	}
+
+	// we've got a valid transaction pushed
+	if err := m.verified.Put(t); err != nil {
+		return fmt.Errorf("store err - %v", err)
+	}
+
+	if m.journal != nil {
+		if err := m.journal.insert(t); err != nil {
+			log.WithError(err).Error("could not journal transaction")
+		}
+	}
+
+	return nil
+}
+
+// makeRoom returns the pool entries to drop for the tx to get in. These are
+// the txs it replaces, if it pays a fee per byte higher by the replace-by-fee
+// margin, and the lowest fee per byte txs it evicts, if the pool is full.
+func (m *Mempool) makeRoom(t TxDesc) (replaced, evicted map[txHash]TxDesc, err error) {
+	cfg := config.Get().Mempool
+
+	if t.tx.Type() == transactions.Slash {
+		if err := m.checkSlashRoom(t); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	replaced = m.verified.Conflicts(t)
+	evicted = make(map[txHash]TxDesc)
+
+	// The replacement is priced by fee per byte, as the eviction ranks the
+	// txs, against the fee per byte of all the txs it replaces. The rates are
+	// compared by cross-multiplying, to get no rounding at the margin. The
+	// sums and the products are computed as big.Int, as they overflow 64 bits
+	// for high enough fees.
+	if len(replaced) > 0 {
+		replacedFee, replacedSize := new(big.Int), new(big.Int)
+
+		for _, d := range replaced {
+			_, fee := d.tx.Values()
+			replacedFee.Add(replacedFee, new(big.Int).SetUint64(fee))
+			replacedSize.Add(replacedSize, new(big.Int).SetUint64(uint64(d.size)))
+		}
+
+		_, fee := t.tx.Values()
+		size := new(big.Int).SetUint64(uint64(t.size))
+
+		// as in TxDesc.feeRate, a tx of unknown size is priced by its fee
+		if size.Sign() == 0 {
+			size.SetInt64(1)
+		}
+
+		if replacedSize.Sign() == 0 {
+			replacedSize.SetInt64(1)
+		}
+
+		// the tx replaces the others if price > replacedPrice and
+		// price * 100 >= replacedPrice * (100 + margin)
+		price := new(big.Int).Mul(new(big.Int).SetUint64(fee), replacedSize)
+		replacedPrice := new(big.Int).Mul(replacedFee, size)
+
+		raised := new(big.Int).Mul(replacedPrice, big.NewInt(100+int64(cfg.ReplaceByFeeMargin)))
+		if price.Cmp(replacedPrice) <= 0 || new(big.Int).Mul(price, big.NewInt(100)).Cmp(raised) < 0 {
+			return nil, nil, ErrReplacementUnderpriced
+		}
+	}
+
+	maxSize := uint64(cfg.MaxSizeMB) * 1000 * 1000
+	if maxSize == 0 {
+		return replaced, evicted, nil
+	}
+
+	size := uint64(m.verified.Size()) + uint64(t.size)
+	for _, d := range replaced {
+		size -= uint64(d.size)
+	}
+
+	if size <= maxSize {
+		return replaced, evicted, nil
+	}
+
+	// Slash txs pay no fee, yet they get in ahead of any other tx, and are
+	// never evicted. Their amount is capped, see checkSlashRoom
+	rate := t.feeRate()
+	if t.tx.Type() == transactions.Slash {
+		rate = math.Inf(1)
+	}
+
+	err = m.verified.RangeFeeRate(func(k txHash, d TxDesc) (bool, error) {
+		if _, ok := replaced[k]; ok || d.tx.Type() == transactions.Slash {
+			return false, nil
+		}
+
+		if d.feeRate() >= rate {
+			return true, nil
+		}
+
+		evicted[k] = d
+		size -= uint64(d.size)
+
+		return size <= maxSize, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if size > maxSize {
+		return nil, nil, ErrFeeTooLow
+	}
+
+	return replaced, evicted, nil
+}
+
+// checkSlashRoom checks that a Slash tx reports an equivocation, identified
+// by the accused provisioner, the round and the step, which no pending Slash
+// tx reports, and that the cap of the Slash txs is not reached.
+func (m *Mempool) checkSlashRoom(t TxDesc) error {
+	e, err := slashing.Decode(t.tx)
+	if err != nil {
+		return err
+	}
+
+	pending := m.verified.FilterByType(transactions.Slash)
+
+	for _, tx := range pending {
+		p, err := slashing.Decode(tx)
+		if err != nil {
+			continue
+		}
+
+		if p.Round == e.Round && p.Step == e.Step && bytes.Equal(p.PubKeyBLS, e.PubKeyBLS) {
+			return ErrAlreadyReported
+		}
+	}
+
+	if uint32(len(pending)) >= config.Get().Mempool.MaxSlashTxs {
+		return ErrTooManySlashTxs
+	}
+
+	return nil
+}
+
 func (m *Mempool) onBlock(b block.Block) {
 	m.latestBlockTimestamp = b.Header.Timestamp
 	m.removeAccepted(b)
+
+	// The remaining txs are checked against the new state. This includes the
+	// blocks of a fallback branch, which are accepted in turn.
+	m.scheduleSweep(b.Header.Height)
 }
 
 // removeAccepted to clean up all txs from the mempool that have been already
@@ -357,14 +620,18 @@ func (m *Mempool) removeAccepted(b block.Block) {
 			log.WithError(err).Panic("could not calculate tx hash")
 		}
 
-		_ = m.verified.Delete(hash)
+		if err := m.verified.Delete(hash); err == nil {
+			m.verifier.Forget(hash)
+		}
 	}
 
 	l.Info("processing_block_completed")
 }
 
-// TODO: Get rid of stuck/expired transactions.
 func (m *Mempool) onIdle() {
+	// Get rid of stuck/expired transactions
+	m.expire()
+
 	log.
 		WithField("alloc_size", int64(m.verified.Size())/1000).
 		WithField("txs_count", m.verified.Len()).Info("process_on_idle")
@@ -505,21 +772,15 @@ func (m Mempool) SelectTx(ctx context.Context, req *node.SelectRequest) (*node.S
 	return resp, nil
 }
 
-// GetUnconfirmedBalance will return the amount of DUSK that is in the mempool
-// for a given key.
+// GetUnconfirmedBalance is not served by the mempool: only the wallet which
+// published the txs knows what they spend. The Wallet service of the node
+// serves the unconfirmed balance of its wallet.
 func (m Mempool) GetUnconfirmedBalance(ctx context.Context, req *node.GetUnconfirmedBalanceRequest) (*node.BalanceResponse, error) {
-	txs := m.verified.Clone()
-
-	balance, err := m.verifier.CalculateBalance(ctx, req.Vk, txs)
-	if err != nil {
-		return nil, err
-	}
-
-	return &node.BalanceResponse{LockedBalance: balance}, nil
+	return nil, ErrUnconfirmedBalance
 }
 
 // processGetMempoolTxsBySizeRequest returns a subset of verified mempool txs which
-// 1. contains only highest fee txs
+// 1. contains up to MaxSlashTxsPerBlock Slash txs, then only highest fee txs
 // 2. has total txs size not bigger than maxTxsSize (request param)
 // Called by BlockGenerator on generating a new candidate block.
 func (m Mempool) processGetMempoolTxsBySizeRequest(r rpcbus.Request) (interface{}, error) {
@@ -535,23 +796,42 @@ func (m Mempool) processGetMempoolTxsBySizeRequest(r rpcbus.Request) (interface{
 
 	var totalSize uint32
 
-	err := m.verified.RangeSort(func(k txHash, t TxDesc) (bool, error) {
-		var done bool
-		totalSize += uint32(t.size)
+	// Slash txs pay no fee, yet a few of them are included ahead of any
+	// other tx
+	maxSlashTxs := config.Get().Mempool.MaxSlashTxsPerBlock
+	slashTxs := uint32(0)
 
-		if totalSize <= maxTxsSize {
-			txs = append(txs, t.tx)
-		} else {
-			done = true
-		}
+	for _, slash := range []bool{true, false} {
+		err := m.verified.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+			if (t.tx.Type() == transactions.Slash) != slash {
+				return false, nil
+			}
 
-		return done, nil
-	})
-	if err != nil {
-		return bytes.Buffer{}, err
+			if slash {
+				if slashTxs >= maxSlashTxs {
+					return true, nil
+				}
+
+				slashTxs++
+			}
+
+			var done bool
+			totalSize += uint32(t.size)
+
+			if totalSize <= maxTxsSize {
+				txs = append(txs, t.tx)
+			} else {
+				done = true
+			}
+
+			return done, nil
+		})
+		if err != nil {
+			return bytes.Buffer{}, err
+		}
 	}
 
-	return txs, err
+	return txs, nil
 }
 
 // processSendMempoolTxRequest utilizes rpcbus to allow submitting a tx to mempool with.
@@ -615,6 +895,17 @@ func (m *Mempool) kadcastTx(t TxDesc) error {
 // OnClose performs mempool cleanup procedure. It's called on canceling mempool
 // context.
 func (m *Mempool) OnClose() {
+	if m.journal != nil {
+		// A journal not reloaded yet is kept for the next start
+		if m.journal.opened() {
+			m.rotateJournal()
+		}
+
+		if err := m.journal.close(); err != nil {
+			log.WithError(err).Error("could not close the mempool journal")
+		}
+	}
+
 	// Closing diskpool backend commits changes to file and close it.
 	m.verified.Close()
 }
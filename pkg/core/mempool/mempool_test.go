@@ -9,8 +9,10 @@ package mempool
 import (
 	"bytes"
 	"context"
+	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -18,13 +20,20 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/sirupsen/logrus"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestMain(m *testing.M) {
@@ -35,6 +44,12 @@ func TestMain(m *testing.M) {
 	r.Mempool.MaxSizeMB = 1
 	r.Mempool.PoolType = "hashmap"
 	r.Mempool.MaxInvItems = 10000
+	r.Mempool.ReplaceByFeeMargin = 10
+	r.Mempool.TxTTL = "1h"
+	r.Mempool.MaxSlashTxs = 2
+	r.Mempool.MaxSlashTxsPerBlock = 1
+	r.Consensus.Slashing = true
+	r.RPC.Rusk.ContractTimeout = 10000
 	config.Mock(&r)
 
 	code := m.Run()
@@ -46,13 +61,19 @@ func startMempoolTest(ctx context.Context) (*Mempool, *eventbus.EventBus, *rpcbu
 }
 
 func startMempoolTestWithLatency(ctx context.Context, latency time.Duration) (*Mempool, *eventbus.EventBus, *rpcbus.RPCBus, *eventbus.GossipStreamer) {
+	return startMempoolTestWithDB(ctx, nil, latency)
+}
+
+func startMempoolTestWithDB(ctx context.Context, db database.DB, latency time.Duration) (*Mempool, *eventbus.EventBus, *rpcbus.RPCBus, *eventbus.GossipStreamer) {
 	bus, streamer := eventbus.CreateGossipStreamer()
 
 	rpcBus := rpcbus.New()
 	v := &transactions.MockProxy{}
-	m := NewMempool(nil, bus, rpcBus, v.ProberWithParams(latency), nil)
+	m := NewMempool(db, bus, rpcBus, v.ProberWithParams(latency), nil)
 
 	m.Run(ctx)
+	<-m.reloaded
+
 	return m, bus, rpcBus, streamer
 }
 
@@ -295,6 +316,547 @@ func TestSendMempoolTx(t *testing.T) {
 	assert.Equal(m.verified.Size(), totalSize)
 }
 
+func TestReplaceByFee(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	tx := transactions.RandTx()
+	tx.Payload.Fee.GasPrice = 100
+
+	_, err := rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 0)
+	assert.NoError(err)
+
+	// A tx of the same size spending the same nullifiers replaces it only if
+	// it pays a fee per byte higher by the margin (10%)
+	underpriced := tx.Copy().(*transactions.Transaction)
+	underpriced.Payload.Fee.GasPrice = 105
+
+	_, err = rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(underpriced), 0)
+	assert.Equal(ErrReplacementUnderpriced, err)
+
+	replacement := tx.Copy().(*transactions.Transaction)
+	replacement.Payload.Fee.GasPrice = 110
+
+	_, err = rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(replacement), 0)
+	assert.NoError(err)
+
+	hash, err := tx.CalculateHash()
+	assert.NoError(err)
+	assert.False(m.verified.Contains(hash))
+
+	hash, err = replacement.CalculateHash()
+	assert.NoError(err)
+	assert.True(m.verified.Contains(hash))
+	assert.Equal(1, m.verified.Len())
+}
+
+func TestReplaceByFeeRate(t *testing.T) {
+	assert := assert.New(t)
+
+	dbpath := createTemp("rbf.db")
+	defer os.Remove(dbpath)
+
+	pools := map[string]Pool{
+		backendHashmap:  &HashMap{lock: &sync.RWMutex{}},
+		backendDiskpool: &buntdbPool{},
+	}
+
+	for name, pool := range pools {
+		assert.NoError(pool.Create(dbpath), name)
+
+		m := &Mempool{verified: pool}
+
+		tx := transactions.RandTx()
+		tx.Payload.Fee.GasPrice = 100
+
+		assert.NoError(pool.Put(TxDesc{tx: tx, received: time.Now(), size: 100}), name)
+
+		// A larger tx paying a higher fee, yet a lower fee per byte, does not
+		// replace it
+		heavier := transactions.RandTx()
+		heavier.Payload.Nullifiers = tx.Payload.Nullifiers
+		heavier.Payload.Fee.GasPrice = 150
+
+		_, _, err := m.makeRoom(TxDesc{tx: heavier, received: time.Now(), size: 200})
+		assert.Equal(ErrReplacementUnderpriced, err, name)
+
+		// A smaller tx paying a lower fee, yet a fee per byte higher by the
+		// margin, replaces it
+		lighter := transactions.RandTx()
+		lighter.Payload.Nullifiers = tx.Payload.Nullifiers
+		lighter.Payload.Fee.GasPrice = 60
+
+		replaced, _, err := m.makeRoom(TxDesc{tx: lighter, received: time.Now(), size: 50})
+		assert.NoError(err, name)
+
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+
+		var k txHash
+		copy(k[:], hash)
+
+		assert.Len(replaced, 1, name)
+		assert.Contains(replaced, k, name)
+
+		pool.Close()
+	}
+}
+
+
+// TestReplaceByFeeOverflow prices replacements whose fees times the sizes
+// overflow 64 bits.
+func TestReplaceByFeeOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	dbpath := createTemp("rbf_overflow.db")
+	defer os.Remove(dbpath)
+
+	pools := map[string]Pool{
+		backendHashmap:  &HashMap{lock: &sync.RWMutex{}},
+		backendDiskpool: &buntdbPool{},
+	}
+
+	withFee := func(tx *transactions.Transaction, fee uint64) *transactions.Transaction {
+		tx.Payload.Fee.GasLimit, tx.Payload.Fee.GasPrice = fee, 1
+		return tx
+	}
+
+	for name, pool := range pools {
+		assert.NoError(pool.Create(dbpath), name)
+
+		m := &Mempool{verified: pool}
+
+		// Two txs paying half of the max fee, spending a nullifier each
+		tx := withFee(transactions.RandTx(), math.MaxUint64/2)
+		other := withFee(transactions.RandTx(), math.MaxUint64/2)
+
+		assert.NoError(pool.Put(TxDesc{tx: tx, received: time.Now(), size: 100}), name)
+		assert.NoError(pool.Put(TxDesc{tx: other, received: time.Now(), size: 100}), name)
+
+		// A tx paying a hair more than one of them is underpriced
+		underpriced := withFee(transactions.RandTx(), math.MaxUint64/2+1)
+		underpriced.Payload.Nullifiers = tx.Payload.Nullifiers
+
+		_, _, err := m.makeRoom(TxDesc{tx: underpriced, received: time.Now(), size: 100})
+		assert.Equal(ErrReplacementUnderpriced, err, name)
+
+		// A tx paying the max fee doubles the fee per byte of one of them
+		replacement := withFee(transactions.RandTx(), math.MaxUint64)
+		replacement.Payload.Nullifiers = tx.Payload.Nullifiers
+
+		replaced, _, err := m.makeRoom(TxDesc{tx: replacement, received: time.Now(), size: 100})
+		assert.NoError(err, name)
+		assert.Len(replaced, 1, name)
+
+		// but twice as large, it pays the fee per byte of both, the sum of
+		// their fees overflowing as well
+		replacement.Payload.Nullifiers = append(tx.Payload.Nullifiers, other.Payload.Nullifiers...)
+
+		_, _, err = m.makeRoom(TxDesc{tx: replacement, received: time.Now(), size: 200})
+		assert.Equal(ErrReplacementUnderpriced, err, name)
+
+		pool.Close()
+	}
+}
+func TestFeeRateEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, rb, _ := startMempoolTest(ctx)
+
+	// Fill up the pool (1 MB) with txs paying an increasing fee per byte
+	pooled := make([][]byte, 10)
+
+	for i := range pooled {
+		tx := transactions.RandTx()
+		tx.Payload.Fee.GasPrice = uint64(i + 1)
+
+		assert.NoError(m.verified.Put(TxDesc{tx: tx, received: time.Now(), size: 100000}))
+
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+
+		pooled[i] = hash
+	}
+
+	// A tx paying less per byte than any pooled tx does not get in
+	cheap := transactions.RandTx()
+	cheap.Payload.Fee.GasPrice = 0
+
+	_, err := rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(cheap), 0)
+	assert.Equal(ErrFeeTooLow, err)
+
+	// A tx paying more evicts the lowest fee per byte tx
+	tx := transactions.RandTx()
+	tx.Payload.Fee.GasPrice = 100
+
+	_, err = rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 0)
+	assert.NoError(err)
+
+	hash, err := tx.CalculateHash()
+	assert.NoError(err)
+	assert.True(m.verified.Contains(hash))
+
+	assert.False(m.verified.Contains(pooled[0]))
+
+	for _, hash := range pooled[1:] {
+		assert.True(m.verified.Contains(hash))
+	}
+}
+
+func TestSlashTx(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A single provisioner, drawn in every committee of round 5
+	_, db := lite.CreateDBConnection()
+	p, keys := consensus.MockProvisioners(1)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		if err := t.StoreBlock(helper.RandomBlock(4, 1)); err != nil {
+			return err
+		}
+
+		return t.StoreProvisioners(4, p)
+	}))
+
+	m, bus, rb, _ := startMempoolTestWithDB(ctx, db, 0)
+
+	// Fill up the pool (1 MB) with txs paying a fee
+	pooled := make([][]byte, 10)
+
+	for i := range pooled {
+		tx := transactions.RandTx()
+		tx.Payload.Fee.GasPrice = uint64(i + 1)
+
+		assert.NoError(m.verified.Put(TxDesc{tx: tx, received: time.Now(), size: 100000}))
+
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+
+		pooled[i] = hash
+	}
+
+	send := func(tx transactions.ContractCall) error {
+		_, err := rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 0)
+		return err
+	}
+
+	// Slash txs are refused unless slashing is enabled
+	r := config.Get()
+	r.Consensus.Slashing = false
+	config.Mock(&r)
+
+	assert.Error(send(slashing.MockSlashTx(slashing.MockEvidenceOf(keys[0], 5, 2))))
+
+	r.Consensus.Slashing = true
+	config.Mock(&r)
+
+	// A Slash tx with a forged evidence is rejected
+	e := slashing.MockEvidenceOf(keys[0], 5, 2)
+	e.Votes[1].Signature = e.Votes[0].Signature
+	assert.Error(send(slashing.MockSlashTx(e)))
+
+	// A Slash tx accusing a provisioner out of the committee is rejected
+	outsider := slashing.MockEvidence(5, 3)
+	assert.Error(send(slashing.MockSlashTx(outsider)))
+
+	// A Slash tx is not verified by Rusk, its evidence is checked locally as
+	// in the blocks.
+	// It pays no fee, yet it gets in by evicting the lowest fee per byte tx
+	slash := slashing.MockSlashTx(slashing.MockEvidenceOf(keys[0], 5, 2))
+	transactions.Invalidate(slash)
+	assert.NoError(send(slash))
+
+	slashHash, err := slash.CalculateHash()
+	assert.NoError(err)
+	assert.True(m.verified.Contains(slashHash))
+	assert.False(m.verified.Contains(pooled[0]))
+
+	// The same equivocation is reported once
+	assert.Error(send(slashing.MockSlashTx(slashing.MockEvidenceOf(keys[0], 5, 2))))
+
+	// An evidence recorded by the local detector goes through the same checks
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreEvidence(outsider)
+	}))
+
+	assert.Error(send(slashing.MockSlashTx(outsider)))
+
+	other := slashing.MockSlashTx(slashing.MockEvidenceOf(keys[0], 5, 3))
+	assert.NoError(send(other))
+
+	// The Slash txs are capped
+	assert.Error(send(slashing.MockSlashTx(slashing.MockEvidenceOf(keys[0], 5, 5))))
+	assert.Len(m.verified.FilterByType(transactions.Slash), 2)
+
+	// They are never evicted in favor of a tx paying a fee
+	for i := 0; i < 10; i++ {
+		tx := transactions.RandTx()
+		tx.Payload.Fee.GasPrice = 1000
+
+		_ = send(tx)
+	}
+
+	assert.True(m.verified.Contains(slashHash))
+
+	// They survive the revalidation of the pool
+	evictedChan := make(chan message.Message, 10)
+	bus.Subscribe(topics.EvictedTx, eventbus.NewChanListener(evictedChan))
+
+	b := helper.RandomBlock(200, 0)
+	b.Txs = make([]transactions.ContractCall, 0)
+
+	errList := bus.Publish(topics.AcceptedBlock, message.New(topics.AcceptedBlock, *b))
+	assert.Empty(errList)
+
+	select {
+	case msg := <-evictedChan:
+		t.Fatalf("unexpected eviction of %x", msg.Payload().(EvictedTx).TxID)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	assert.True(m.verified.Contains(slashHash))
+
+	// A capped amount of them is included ahead of the txs paying a fee
+	param := new(bytes.Buffer)
+	assert.NoError(encoding.WriteUint32LE(param, 200000))
+
+	resp, err := rb.Call(topics.GetMempoolTxsBySize, rpcbus.NewRequest(*param), 0)
+	assert.NoError(err)
+
+	txs := resp.([]transactions.ContractCall)
+	assert.True(len(txs) > 1)
+	assert.Equal(transactions.Slash, txs[0].Type())
+
+	for _, tx := range txs[1:] {
+		assert.NotEqual(transactions.Slash, tx.Type())
+	}
+
+	// Once a block reports the equivocation, whatever the reporter, the
+	// Slash tx is evicted
+	b = helper.RandomBlock(5, 1)
+	b.Txs = append([]transactions.ContractCall{slashing.MockSlashTx(slashing.MockEvidenceOf(keys[0], 5, 2))}, b.Txs...)
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(b)
+	}))
+
+	errList = bus.Publish(topics.AcceptedBlock, message.New(topics.AcceptedBlock, *b))
+	assert.Empty(errList)
+
+	select {
+	case msg := <-evictedChan:
+		assert.Equal(slashHash, msg.Payload().(EvictedTx).TxID)
+	case <-time.After(time.Second):
+		t.Fatal("slash tx not evicted")
+	}
+
+	otherHash, err := other.CalculateHash()
+	assert.NoError(err)
+	assert.True(m.verified.Contains(otherHash))
+}
+
+func TestSweep(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, bus, _, _ := startMempoolTest(ctx)
+
+	evictedChan := make(chan message.Message, 10)
+	bus.Subscribe(topics.EvictedTx, eventbus.NewChanListener(evictedChan))
+
+	// A tx received beyond the TTL, a tx rejected by the next state and a
+	// valid tx
+	expired, invalid, valid := transactions.RandTx(), transactions.RandTx(), transactions.RandTx()
+
+	assert.NoError(m.verified.Put(TxDesc{tx: expired, received: time.Now().Add(-2 * time.Hour)}))
+	assert.NoError(m.verified.Put(TxDesc{tx: invalid, received: time.Now()}))
+	assert.NoError(m.verified.Put(TxDesc{tx: valid, received: time.Now()}))
+
+	expiredHash, err := expired.CalculateHash()
+	assert.NoError(err)
+
+	invalidHash, err := invalid.CalculateHash()
+	assert.NoError(err)
+
+	validHash, err := valid.CalculateHash()
+	assert.NoError(err)
+
+	transactions.Invalidate(invalid)
+
+	// The pool is swept once a block is accepted
+	b := helper.RandomBlock(200, 0)
+	b.Txs = make([]transactions.ContractCall, 0)
+
+	errList := bus.Publish(topics.AcceptedBlock, message.New(topics.AcceptedBlock, *b))
+	assert.Empty(errList)
+
+	evicted := make(map[string]string)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-evictedChan:
+			e := msg.Payload().(EvictedTx)
+			evicted[string(e.TxID)] = e.Reason
+		case <-time.After(time.Second):
+			t.Fatal("tx not evicted")
+		}
+	}
+
+	assert.Equal(EvictionExpired, evicted[string(expiredHash)])
+	assert.Equal(EvictionInvalid, evicted[string(invalidHash)])
+
+	assert.True(m.verified.Contains(validHash))
+	assert.Equal(1, m.verified.Len())
+}
+
+func TestReload(t *testing.T) {
+	assert := assert.New(t)
+
+	r := config.Get()
+	r.Mempool.JournalPath = filepath.Join(t.TempDir(), "mempool.journal")
+	config.Mock(&r)
+
+	defer func() {
+		r.Mempool.JournalPath = ""
+		config.Mock(&r)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m, _, _, _ := startMempoolTest(ctx)
+
+	// A tx accepted before the restart, a tx invalidated by the blocks
+	// accepted meanwhile and a tx which expired meanwhile
+	valid := transactions.RandTx()
+
+	_, err := m.ProcessTx("", message.New(topics.Tx, valid))
+	assert.NoError(err)
+
+	assert.NoError(m.journal.insert(TxDesc{tx: transactions.MockInvalidTx(), received: time.Now()}))
+	assert.NoError(m.journal.insert(TxDesc{tx: transactions.RandTx(), received: time.Now().Add(-2 * time.Hour)}))
+
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, streamer := startMempoolTest(ctx)
+
+	validHash, err := valid.CalculateHash()
+	assert.NoError(err)
+
+	assert.True(m.verified.Contains(validHash))
+	assert.Equal(1, m.verified.Len())
+
+	// The reloaded tx is propagated again
+	inv, err := streamer.Read()
+	assert.NoError(err)
+
+	msg := &message.Inv{}
+	assert.NoError(msg.Decode(bytes.NewBuffer(inv)))
+	assert.Equal(validHash, msg.InvList[0].Hash)
+
+	// The journal is rewritten with the survivors
+	txs, err := m.journal.load()
+	assert.NoError(err)
+	assert.Len(txs, 1)
+}
+
+// batchRecorder is a rusk.StateClient accepting any tx, and recording the
+// size of the batches it verifies.
+type batchRecorder struct {
+	rusk.StateClient
+
+	lock    sync.Mutex
+	batches []int
+}
+
+func (b *batchRecorder) VerifyStateTransition(ctx context.Context, req *rusk.VerifyStateTransitionRequest, opts ...grpc.CallOption) (*rusk.VerifyStateTransitionResponse, error) {
+	b.lock.Lock()
+	b.batches = append(b.batches, len(req.Txs))
+	b.lock.Unlock()
+
+	return &rusk.VerifyStateTransitionResponse{Success: true}, nil
+}
+
+// TestReloadBatches reloads more txs than the verifier verifies at once.
+func TestReloadBatches(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	const count = 300
+
+	buf := new(bytes.Buffer)
+	for i := 0; i < count; i++ {
+		assert.NoError(writeRecord(buf, TxDesc{tx: transactions.RandTx(), received: time.Now(), size: 100}))
+	}
+
+	assert.NoError(ioutil.WriteFile(path, buf.Bytes(), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state := &batchRecorder{}
+	proxy := transactions.NewProxy(state, nil, nil, nil, 5*time.Second, 5*time.Second, config.BlockGasLimit)
+
+	// The config is restored before the mempool routines read it
+	r := config.Get()
+	c := r
+	c.Mempool.JournalPath = path
+	config.Mock(&c)
+
+	bus, streamer := eventbus.CreateGossipStreamer()
+	m := NewMempool(nil, bus, rpcbus.New(), proxy.Prober(), nil)
+
+	config.Mock(&r)
+	m.Run(ctx)
+
+	select {
+	case <-m.reloaded:
+	case <-time.After(10 * time.Second):
+		t.Fatal("reload not completed")
+	}
+
+	assert.Equal(count, m.verified.Len())
+
+	// The txs were verified in several batches
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	var verified int
+
+	for _, size := range state.batches {
+		assert.LessOrEqual(size, 128)
+		verified += size
+	}
+
+	assert.Greater(len(state.batches), 2)
+	assert.Equal(count, verified)
+
+	// The journal is rewritten with the reloaded txs
+	txs, err := m.journal.load()
+	assert.NoError(err)
+	assert.Len(txs, count)
+
+	// The reloaded txs are all propagated again
+	for i := 0; i < count; i++ {
+		_, err := streamer.Read()
+		assert.NoError(err)
+	}
+}
+
 func BenchmarkProcessTx_0(b *testing.B) {
 	// Recent result
 	// BenchmarkProcessTx_0-8             50475             33671 ns/op
@@ -7,6 +7,7 @@
 package transactor
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
@@ -40,6 +41,66 @@ func (t *Transactor) handleAddress() (*node.LoadResponse, error) {
 	return loadResponseFromPub(t.w.PublicKey), nil
 }
 
+// handleBalance returns the confirmed balance of the wallet, along with the
+// balance once its txs pending in the mempool are accepted. The payments to
+// the wallet are only counted once confirmed, as the node cannot tell the
+// outputs of the wallet apart from the others.
+func (t *Transactor) handleBalance(ctx context.Context) (*transactions.Balance, error) {
+	if t.w == nil {
+		return nil, errWalletNotLoaded
+	}
+
+	vk := new(bytes.Buffer)
+	if err := keys.MarshalViewKey(vk, &t.w.ViewKey); err != nil {
+		return nil, err
+	}
+
+	confirmed, err := t.proxy.Prober().CalculateBalance(ctx, vk.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	spent, err := t.pendingSpendings()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &transactions.Balance{Confirmed: confirmed}
+	if spent < confirmed {
+		b.Unconfirmed = confirmed - spent
+	}
+
+	return b, nil
+}
+
+// pendingSpendings returns the value spent by the txs of the wallet which are
+// still in the mempool. The txs which left it, either accepted or evicted, are
+// forgotten.
+func (t *Transactor) pendingSpendings() (uint64, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	timeout := time.Duration(cfg.Get().RPC.Rusk.ContractTimeout) * time.Millisecond
+
+	var spent uint64
+
+	for hash, value := range t.spendings {
+		resp, err := t.rb.Call(topics.GetMempoolTxs, rpcbus.NewRequest(*bytes.NewBufferString(hash)), timeout)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(resp.([]transactions.ContractCall)) == 0 {
+			delete(t.spendings, hash)
+			continue
+		}
+
+		spent += value
+	}
+
+	return spent, nil
+}
+
 func (t *Transactor) handleGetTxHistory() (*node.TxHistoryResponse, error) {
 	if t.w == nil {
 		return nil, errWalletNotLoaded
@@ -101,7 +162,7 @@ func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.Transactio
 		return nil, err
 	}
 
-	hash, err := t.publishTx(tx)
+	hash, err := t.publishTx(tx, req.Amount)
 	if err != nil {
 		log.
 			WithField("amount", req.Amount).
@@ -151,7 +212,7 @@ func (t *Transactor) handleSendStandardTx(req *node.TransferRequest) (*node.Tran
 	log.WithField("duration_ms", d).Debug("NewTransfer grpc call")
 
 	// Publish transaction to the mempool processing
-	hash, err := t.publishTx(tx)
+	hash, err := t.publishTx(tx, req.Amount)
 	if err != nil {
 		log.
 			WithField("amount", req.Amount).
@@ -177,14 +238,26 @@ func (t *Transactor) handleClearWalletDatabase() (*node.GenericResponse, error)
 	return &node.GenericResponse{Response: "Wallet database deleted."}, nil
 }
 
-func (t *Transactor) publishTx(tx transactions.ContractCall) ([]byte, error) {
+// publishTx sends a tx of the wallet to the mempool. Until the tx leaves the
+// mempool, the amount it transfers and its fee are deducted from the
+// unconfirmed balance of the wallet.
+func (t *Transactor) publishTx(tx transactions.ContractCall, amount uint64) ([]byte, error) {
 	hash, err := tx.CalculateHash()
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = t.rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 5*time.Second)
-	return hash, err
+	if _, err = t.rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 5*time.Second); err != nil {
+		return hash, err
+	}
+
+	_, fee := tx.Values()
+
+	t.lock.Lock()
+	t.spendings[string(hash)] = amount + fee
+	t.lock.Unlock()
+
+	return hash, nil
 }
 
 func (t *Transactor) handleSendContract(c *node.CallContractRequest) (*node.TransactionResponse, error) {
@@ -203,7 +276,7 @@ func (t *Transactor) handleSendContract(c *node.CallContractRequest) (*node.Tran
 		}
 
 		// Publish transaction to the mempool processing
-		hash, err := t.publishTx(tx)
+		hash, err := t.publishTx(tx, 0)
 		if err != nil {
 			return nil, err
 		}
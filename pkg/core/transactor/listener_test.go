@@ -0,0 +1,170 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package transactor
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// notesClient is a rusk.StateClient holding transparent notes of a view key.
+type notesClient struct {
+	rusk.StateClient
+
+	vk     []byte
+	values []uint64
+}
+
+func (c *notesClient) GetNotesOwnedBy(ctx context.Context, req *rusk.GetNotesOwnedByRequest, opts ...grpc.CallOption) (*rusk.GetNotesOwnedByResponse, error) {
+	res := new(rusk.GetNotesOwnedByResponse)
+	if !bytes.Equal(req.Vk, c.vk) {
+		return res, nil
+	}
+
+	for _, value := range c.values {
+		data := make([]byte, 96)
+		binary.LittleEndian.PutUint64(data, value)
+
+		note, err := proto.Marshal(&rusk.Note{NoteType: rusk.Note_TRANSPARENT, EncryptedData: data})
+		if err != nil {
+			return nil, err
+		}
+
+		res.Notes = append(res.Notes, note)
+	}
+
+	return res, nil
+}
+
+// mockPool serves the mempool topics used by the Transactor.
+type mockPool struct {
+	lock sync.Mutex
+	txs  map[string]transactions.ContractCall
+}
+
+func newMockPool(assert *assert.Assertions, rb *rpcbus.RPCBus) *mockPool {
+	p := &mockPool{txs: make(map[string]transactions.ContractCall)}
+
+	sendChan := make(chan rpcbus.Request, 1)
+	getChan := make(chan rpcbus.Request, 1)
+
+	assert.NoError(rb.Register(topics.SendMempoolTx, sendChan))
+	assert.NoError(rb.Register(topics.GetMempoolTxs, getChan))
+
+	go func() {
+		for {
+			select {
+			case r := <-sendChan:
+				tx := r.Params.(transactions.ContractCall)
+				hash, err := tx.CalculateHash()
+
+				p.lock.Lock()
+				p.txs[string(hash)] = tx
+				p.lock.Unlock()
+
+				r.RespChan <- rpcbus.Response{Resp: hash, Err: err}
+			case r := <-getChan:
+				params := r.Params.(bytes.Buffer)
+				txs := make([]transactions.ContractCall, 0)
+
+				p.lock.Lock()
+				if tx, ok := p.txs[params.String()]; ok {
+					txs = append(txs, tx)
+				}
+				p.lock.Unlock()
+
+				r.RespChan <- rpcbus.Response{Resp: txs}
+			}
+		}
+	}()
+
+	return p
+}
+
+// remove drops a tx from the pool, as on accepting a block.
+func (p *mockPool) remove(hash []byte) {
+	p.lock.Lock()
+	delete(p.txs, string(hash))
+	p.lock.Unlock()
+}
+
+func TestBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	rb := rpcbus.New()
+	pool := newMockPool(assert, rb)
+
+	vk := keys.ViewKey{A: transactions.Rand32Bytes(), BG: transactions.Rand32Bytes()}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(keys.MarshalViewKey(buf, &vk))
+
+	state := &notesClient{vk: buf.Bytes(), values: []uint64{1000000, 500000}}
+
+	tr := &Transactor{
+		rb:        rb,
+		proxy:     transactions.NewProxy(state, nil, nil, nil, time.Second, time.Second, config.BlockGasLimit),
+		w:         &wallet.Wallet{ViewKey: vk},
+		spendings: make(map[string]uint64),
+	}
+
+	b, err := tr.handleBalance(context.Background())
+	assert.NoError(err)
+	assert.Equal(uint64(1500000), b.Confirmed)
+	assert.Equal(uint64(1500000), b.Unconfirmed)
+
+	// A tx of the wallet spends its amount and its fee until accepted
+	tx := transactions.RandTx()
+	tx.Payload.Fee.GasLimit, tx.Payload.Fee.GasPrice = 50000, 1
+
+	hash, err := tr.publishTx(tx, 200000)
+	assert.NoError(err)
+
+	b, err = tr.handleBalance(context.Background())
+	assert.NoError(err)
+	assert.Equal(uint64(1500000), b.Confirmed)
+	assert.Equal(uint64(1500000-200000-50000), b.Unconfirmed)
+
+	// The spendings over the confirmed balance leave nothing
+	overdraft := transactions.RandTx()
+	_, err = tr.publishTx(overdraft, 2000000)
+	assert.NoError(err)
+
+	b, err = tr.handleBalance(context.Background())
+	assert.NoError(err)
+	assert.Zero(b.Unconfirmed)
+
+	// Once the txs leave the mempool, the notes in the state account for them
+	pool.remove(hash)
+
+	overdraftHash, err := overdraft.CalculateHash()
+	assert.NoError(err)
+	pool.remove(overdraftHash)
+
+	state.values = []uint64{1250000}
+
+	b, err = tr.handleBalance(context.Background())
+	assert.NoError(err)
+	assert.Equal(uint64(1250000), b.Confirmed)
+	assert.Equal(uint64(1250000), b.Unconfirmed)
+	assert.Empty(tr.spendings)
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -31,7 +32,8 @@ type Transactor struct {
 	rb *rpcbus.RPCBus
 
 	// RPCBus channels
-	stakeChan <-chan rpcbus.Request
+	stakeChan   <-chan rpcbus.Request
+	balanceChan <-chan rpcbus.Request
 
 	// Passed to the consensus component startup
 	proxy transactions.Proxy
@@ -40,6 +42,11 @@ type Transactor struct {
 	getSyncProgress func() float64
 
 	w *wallet.Wallet
+
+	lock sync.Mutex
+	// spendings are the values spent by the txs the wallet published, by tx
+	// hash, until the txs leave the mempool.
+	spendings map[string]uint64
 }
 
 // New Instantiate a new Transactor struct.
@@ -49,15 +56,18 @@ func New(eb *eventbus.EventBus, rb *rpcbus.RPCBus, db database.DB, srv *grpc.Ser
 	}
 
 	stakeChan := make(chan rpcbus.Request, 1)
+	balanceChan := make(chan rpcbus.Request, 1)
 
 	t := &Transactor{
 		db:              db,
 		eb:              eb,
 		rb:              rb,
 		stakeChan:       stakeChan,
+		balanceChan:     balanceChan,
 		proxy:           proxy,
 		w:               w,
 		getSyncProgress: getSyncProgress,
+		spendings:       make(map[string]uint64),
 	}
 
 	if srv != nil {
@@ -69,30 +79,44 @@ func New(eb *eventbus.EventBus, rb *rpcbus.RPCBus, db database.DB, srv *grpc.Ser
 		return nil, err
 	}
 
+	if err := rb.Register(topics.GetBalance, balanceChan); err != nil {
+		return nil, err
+	}
+
 	go t.Listen()
 	return t, nil
 }
 
-// Listen to the stake and bid channels and trigger a stake and bid transaction
-// requests.
+// Listen to the stake and balance channels and trigger a stake transaction
+// and balance requests.
 func (t *Transactor) Listen() {
 	l := log.WithField("action", "listen")
 
-	for r := range t.stakeChan {
-		req, ok := r.Params.(*node.StakeRequest)
-		if !ok {
-			continue
-		}
-
-		var hash *bytes.Buffer
-
-		resp, err := t.Stake(context.Background(), req)
-		if err != nil {
-			l.WithError(err).Error("error in creating a stake transaction")
-		} else {
-			hash = bytes.NewBuffer(resp.GetHash())
+	for {
+		select {
+		case r := <-t.stakeChan:
+			req, ok := r.Params.(*node.StakeRequest)
+			if !ok {
+				continue
+			}
+
+			var hash *bytes.Buffer
+
+			resp, err := t.Stake(context.Background(), req)
+			if err != nil {
+				l.WithError(err).Error("error in creating a stake transaction")
+			} else {
+				hash = bytes.NewBuffer(resp.GetHash())
+			}
+			r.RespChan <- rpcbus.Response{Resp: hash, Err: err}
+		case r := <-t.balanceChan:
+			// The balance is served aside, so that the stake requests are
+			// not held up by Rusk
+			go func(r rpcbus.Request) {
+				resp, err := t.handleBalance(context.Background())
+				r.RespChan <- rpcbus.Response{Resp: resp, Err: err}
+			}(r)
 		}
-		r.RespChan <- rpcbus.Response{Resp: hash, Err: err}
 	}
 }
 
@@ -163,8 +187,29 @@ func (t *Transactor) GetAddress(ctx context.Context, e *node.EmptyRequest) (*nod
 	return t.handleAddress()
 }
 
-// GetBalance returns the balance of the loaded wallet.
+// GetBalance returns the balance of the loaded wallet. As the BalanceResponse
+// has no fields of its own for them, the UnlockedBalance is the confirmed
+// balance, and the LockedBalance is the balance once the transactions of the
+// wallet in the mempool are accepted.
 func (t *Transactor) GetBalance(ctx context.Context, e *node.EmptyRequest) (*node.BalanceResponse, error) {
-	// NOT IMPL
-	return &node.BalanceResponse{}, nil
+	b, err := t.handleBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &node.BalanceResponse{
+		UnlockedBalance: b.Confirmed,
+		LockedBalance:   b.Unconfirmed,
+	}, nil
+}
+
+// Balance returns the balance of the loaded wallet once the transactions in
+// the mempool are accepted, so that the pending spendings are not counted.
+func (t *Transactor) Balance() (uint64, error) {
+	resp, err := t.handleBalance(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Unconfirmed, nil
 }
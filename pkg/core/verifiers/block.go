@@ -10,11 +10,13 @@ import (
 	"bytes"
 	"errors"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
 )
 
 // ErrPrevBlockHash Previous block hash does not equal the previous hash in the current block.
@@ -124,3 +126,38 @@ func CheckMultiCoinbases(txs []transactions.ContractCall) error {
 	}
 	return nil
 }
+
+// CheckSlashTxs decodes the evidence carried by the Slash transactions, and
+// returns an error if the block carries more of them than allowed, or if two
+// of them report the same equivocation. The evidence is returned, to be
+// verified against the chain.
+func CheckSlashTxs(txs []transactions.ContractCall) ([]*slashing.Evidence, error) {
+	evidence := make([]*slashing.Evidence, 0)
+
+	for _, tx := range txs {
+		if tx.Type() != transactions.Slash {
+			continue
+		}
+
+		if len(evidence) >= config.MaxSlashTxsPerBlock {
+			return nil, errors.New("too many slash transactions")
+		}
+
+		e, err := slashing.Decode(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		// An equivocation is identified by the accused provisioner, the round
+		// and the step
+		for _, other := range evidence {
+			if other.Round == e.Round && other.Step == e.Step && bytes.Equal(other.PubKeyBLS, e.PubKeyBLS) {
+				return nil, errors.New("equivocation reported twice")
+			}
+		}
+
+		evidence = append(evidence, e)
+	}
+
+	return evidence, nil
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/sirupsen/logrus"
@@ -29,7 +31,8 @@ var log = logrus.WithField("process", "consensus").
 	WithField("actor", "broker")
 
 // Broker is a pub/sub broker that keeps updated all subscribers (websocket
-// connections) with latest block accepted published by node layer.
+// connections) with latest block accepted published by node layer, and with
+// the txs evicted from the mempool.
 //
 // IMPL Notes:
 // Broker is implemented in a non-blocking manner. That means it should not be
@@ -51,6 +54,8 @@ type Broker struct {
 	eventBus          eventbus.Broker
 	acceptedBlockChan chan block.Block
 	acceptedBlockID   uint32
+	evictedTxChan     chan message.Message
+	evictedTxID       uint32
 }
 
 // NewBroker creates a new Broker instance.
@@ -59,6 +64,8 @@ func NewBroker(id uint, eventBus eventbus.Broker, maxClientsCount uint, connChan
 	b.eventBus = eventBus
 	b.ConnectionChan = connChan
 	b.acceptedBlockChan, b.acceptedBlockID = consensus.InitAcceptedBlockUpdate(eventBus)
+	b.evictedTxChan = make(chan message.Message, 100)
+	b.evictedTxID = eventBus.Subscribe(topics.EvictedTx, eventbus.NewSafeChanListener(b.evictedTxChan))
 	b.clients = list.New()
 	b.maxClientsCount = maxClientsCount
 	b.id = id
@@ -77,6 +84,7 @@ func (b *Broker) Run() {
 
 		// Unsubscribe from all eventBus events.
 		b.eventBus.Unsubscribe(topics.AcceptedBlock, b.acceptedBlockID)
+		b.eventBus.Unsubscribe(topics.EvictedTx, b.evictedTxID)
 
 		// Terminate all clients goroutines.
 		for e := b.clients.Front(); e != nil; e = e.Next() {
@@ -102,6 +110,9 @@ func (b *Broker) Run() {
 		// new accepted block from node
 		case blk := <-b.acceptedBlockChan:
 			b.handleBlock(blk)
+		// tx evicted from mempool
+		case m := <-b.evictedTxChan:
+			b.handleEvictedTx(m.Payload().(mempool.EvictedTx))
 		case <-time.After(30 * time.Second):
 			b.handleIdle()
 		}
@@ -127,6 +138,19 @@ func (b *Broker) handleBlock(blk block.Block) {
 	b.broadcastMessage(msg)
 }
 
+// handleEvictedTx handles the topics.EvictedTx event emitted from node layer.
+// It packs a json from the evicted tx and broadcast it to all active clients.
+func (b *Broker) handleEvictedTx(e mempool.EvictedTx) {
+	b.reap()
+
+	msg, err := MarshalEvictedTxMsg(e)
+	if err != nil {
+		log.Errorf("encoding err: %v", err)
+	}
+
+	b.broadcastMessage(msg)
+}
+
 // handleConn handles a new websocket conn pushed from webserver layer It stores
 // the conn to list of active clients.
 func (b *Broker) handleConn(conn wsConn) {
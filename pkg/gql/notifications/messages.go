@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 )
 
 // BlockMsg represents the data need by Explorer UI on each new block accepted.
@@ -58,3 +59,23 @@ func MarshalBlockMsg(blk block.Block) (string, error) {
 
 	return string(msg), nil
 }
+
+// EvictedTxMsg represents the data sent on each tx dropped from the mempool
+// without being accepted.
+type EvictedTxMsg struct {
+	EvictedTx string
+	Reason    string
+}
+
+// MarshalEvictedTxMsg builds the JSON of an evicted tx.
+func MarshalEvictedTxMsg(e mempool.EvictedTx) (string, error) {
+	msg, err := json.Marshal(EvictedTxMsg{
+		EvictedTx: hex.EncodeToString(e.TxID),
+		Reason:    e.Reason,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(msg), nil
+}
@@ -0,0 +1,53 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	core "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+)
+
+// File purpose is to define the resolver of the "balance" query, which
+// returns the balance of the wallet loaded by the node.
+
+type balance struct {
+	rpcBus *rpcbus.RPCBus
+}
+
+type queryBalance struct {
+	Confirmed   uint64 `json:"confirmed"`
+	Unconfirmed uint64 `json:"unconfirmed"`
+}
+
+func (b balance) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type:    Balance,
+		Resolve: b.resolve,
+	}
+}
+
+func (b balance) resolve(p graphql.ResolveParams) (interface{}, error) {
+	// The Transactor calls Rusk, then the mempool
+	timeout := 2 * time.Duration(config.Get().RPC.Rusk.ContractTimeout) * time.Millisecond
+
+	resp, err := b.rpcBus.Call(topics.GetBalance, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	r := resp.(*core.Balance)
+
+	return queryBalance{
+		Confirmed:   r.Confirmed,
+		Unconfirmed: r.Unconfirmed,
+	}, nil
+}
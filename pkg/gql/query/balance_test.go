@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	core "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	rb := rpcbus.New()
+	balanceChan := make(chan rpcbus.Request, 1)
+	assert.NoError(rb.Register(topics.GetBalance, balanceChan))
+
+	go func() {
+		r := <-balanceChan
+		r.RespChan <- rpcbus.Response{Resp: &core.Balance{
+			Confirmed:   50000000000,
+			Unconfirmed: 35000000000,
+		}}
+	}()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: NewRoot(rb).Query})
+	assert.NoError(err)
+
+	query := `
+		{
+		  balance {
+			confirmed
+			unconfirmed
+		  }
+		}
+		`
+	response := `
+		{
+		  "data": {
+			"balance": {
+			  "confirmed": "50000000000",
+			  "unconfirmed": "35000000000"
+			}
+		  }
+		}
+		`
+
+	result, err := json.Marshal(execute(query, schema, db))
+	assert.NoError(err)
+
+	equal, err := assertJSONs(result, []byte(response))
+	assert.NoError(err)
+	assert.True(equal, string(result))
+}
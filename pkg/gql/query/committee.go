@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/inspector"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	committeeRoundArg = "round"
+	committeeStepArg  = "step"
+	certificateArg    = "height"
+)
+
+// File purpose is to define all arguments and resolvers relevant to
+// "committee" and "certificate" queries only.
+
+type committee struct{}
+
+func (c committee) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: Committee,
+		Args: graphql.FieldConfigArgument{
+			committeeRoundArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			committeeStepArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+		},
+		Resolve: c.resolve,
+	}
+}
+
+func (c committee) resolve(p graphql.ResolveParams) (interface{}, error) {
+	i, err := newInspector(p)
+	if err != nil {
+		return nil, err
+	}
+
+	round, ok := p.Args[committeeRoundArg].(int)
+	if !ok || round < 0 {
+		return nil, errors.New("invalid round")
+	}
+
+	step, ok := p.Args[committeeStepArg].(int)
+	if !ok || step <= 0 || step > math.MaxUint8 {
+		return nil, errors.New("invalid step")
+	}
+
+	return i.Committee(uint64(round), uint8(step))
+}
+
+type certificate struct{}
+
+func (c certificate) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: CertificateCheck,
+		Args: graphql.FieldConfigArgument{
+			certificateArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+		},
+		Resolve: c.resolve,
+	}
+}
+
+func (c certificate) resolve(p graphql.ResolveParams) (interface{}, error) {
+	i, err := newInspector(p)
+	if err != nil {
+		return nil, err
+	}
+
+	height, ok := p.Args[certificateArg].(int)
+	if !ok || height < 0 {
+		return nil, errors.New("invalid height")
+	}
+
+	return i.CheckCertificate(uint64(height))
+}
+
+func newInspector(p graphql.ResolveParams) (*inspector.Inspector, error) {
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	return inspector.New(db), nil
+}
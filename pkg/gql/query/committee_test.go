@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCommittee(t *testing.T) {
+	p, keys := consensus.MockProvisioners(1)
+
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		return t.StoreProvisioners(0, p)
+	}))
+
+	query := `
+		{
+		  committee(round: 1, step: 2) {
+			round
+			step
+			phase
+			size
+			quorum
+			members {
+			  pubkey
+			  votes
+			}
+		  }
+		}
+		`
+	response := fmt.Sprintf(`
+		{
+		  "data": {
+			"committee": {
+			  "round": 1,
+			  "step": 2,
+			  "phase": "reduction1",
+			  "size": 1,
+			  "quorum": 1,
+			  "members": [
+				{"pubkey": "%s", "votes": 1}
+			  ]
+			}
+		  }
+		}
+		`, hex.EncodeToString(keys[0].BLSPubKey))
+	assertQuery(t, query, response)
+
+	// Beyond the chain tip
+	query = `
+		{
+		  committee(round: 10, step: 1) {
+			size
+		  }
+		}
+		`
+	response = `
+		{
+		  "data": {
+			"committee": null
+		  },
+		  "errors": [
+			{
+			  "message": "inspector: unknown round",
+			  "locations": [{"line": 3, "column": 5}],
+			  "path": ["committee"]
+			}
+		  ]
+		}
+		`
+	assertQuery(t, query, response)
+}
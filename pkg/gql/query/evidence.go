@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	evidenceHashArg  = "hash"
+	evidenceRangeArg = "range"
+)
+
+type (
+	queryVote struct {
+		Hash      []byte
+		Signature []byte
+	}
+
+	// queryEvidence is a data-wrapper for the slashing.Evidence fields that
+	// can be fetched via graphql.
+	queryEvidence struct {
+		Hash      []byte
+		Round     uint64
+		Step      uint8
+		PubKey    []byte
+		Votes     []queryVote
+		Reporter  []byte
+		Signature []byte
+	}
+)
+
+func newQueryEvidence(e slashing.Evidence) (queryEvidence, error) {
+	hash, err := e.Hash()
+	if err != nil {
+		return queryEvidence{}, err
+	}
+
+	qe := queryEvidence{
+		Hash:      hash,
+		Round:     e.Round,
+		Step:      e.Step,
+		PubKey:    e.PubKeyBLS,
+		Votes:     make([]queryVote, 0, len(e.Votes)),
+		Reporter:  e.Reporter,
+		Signature: e.Signature,
+	}
+
+	for _, vote := range e.Votes {
+		qe.Votes = append(qe.Votes, queryVote{Hash: vote.BlockHash, Signature: vote.Signature})
+	}
+
+	return qe, nil
+}
+
+// File purpose is to define all arguments and resolvers relevant to "evidence" query only.
+
+type evidence struct{}
+
+func (e evidence) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(Evidence),
+		Args: graphql.FieldConfigArgument{
+			evidenceHashArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+			evidenceRangeArg: &graphql.ArgumentConfig{
+				Type: graphql.NewList(graphql.Int),
+			},
+		},
+		Resolve: e.resolve,
+	}
+}
+
+func (e evidence) resolve(p graphql.ResolveParams) (interface{}, error) {
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	// resolve argument hash (single evidence)
+	hash, ok := p.Args[evidenceHashArg].(string)
+	if ok {
+		return e.fetchEvidenceByHash(db, hash)
+	}
+
+	// resolve argument range (rounds range), all the evidence otherwise
+	from, to := uint64(0), uint64(math.MaxUint64)
+
+	roundRange, found := p.Args[evidenceRangeArg].([]interface{})
+	if found {
+		if len(roundRange) != 2 {
+			return nil, errors.New("range expects two values")
+		}
+
+		f, ok := roundRange[0].(int)
+		if !ok || f < 0 {
+			return nil, errors.New("invalid range `from` value")
+		}
+
+		t, ok := roundRange[1].(int)
+		if !ok || t < 0 {
+			return nil, errors.New("invalid range `to` value")
+		}
+
+		from, to = uint64(f), uint64(t)
+	}
+
+	return e.fetchEvidenceByRound(db, from, to)
+}
+
+func (e evidence) fetchEvidenceByHash(db database.DB, hash string) ([]queryEvidence, error) {
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, errors.New("invalid hash")
+	}
+
+	list := make([]queryEvidence, 0)
+
+	err = db.View(func(t database.Transaction) error {
+		fetched, err := t.FetchEvidence(hashBytes)
+		if err != nil {
+			return err
+		}
+
+		qe, err := newQueryEvidence(*fetched)
+		if err != nil {
+			return err
+		}
+
+		list = append(list, qe)
+		return nil
+	})
+
+	return list, err
+}
+
+func (e evidence) fetchEvidenceByRound(db database.DB, from, to uint64) ([]queryEvidence, error) {
+	list := make([]queryEvidence, 0)
+
+	err := db.View(func(t database.Transaction) error {
+		fetched, err := t.FetchEvidenceByRound(from, to)
+		if err != nil {
+			return err
+		}
+
+		for _, ev := range fetched {
+			qe, err := newQueryEvidence(ev)
+			if err != nil {
+				return err
+			}
+
+			list = append(list, qe)
+		}
+
+		return nil
+	})
+
+	return list, err
+}
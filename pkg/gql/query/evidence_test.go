@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEvidence(t *testing.T) {
+	first := slashing.MockEvidence(4, 2)
+	second := slashing.MockEvidence(7, 3)
+
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreEvidence(first); err != nil {
+			return err
+		}
+
+		return t.StoreEvidence(second)
+	}))
+
+	hash, err := first.Hash()
+	assert.NoError(t, err)
+
+	query := fmt.Sprintf(`
+		{
+		  evidence(hash: "%s") {
+			hash
+			round
+			step
+			pubkey
+			votes {
+			  hash
+			}
+		  }
+		}
+		`, hex.EncodeToString(hash))
+	response := fmt.Sprintf(`
+		{
+		  "data": {
+			"evidence": [
+			  {
+				"hash": "%s",
+				"round": 4,
+				"step": 2,
+				"pubkey": "%s",
+				"votes": [
+				  {"hash": "%s"},
+				  {"hash": "%s"}
+				]
+			  }
+			]
+		  }
+		}
+		`, hex.EncodeToString(hash), hex.EncodeToString(first.PubKeyBLS),
+		hex.EncodeToString(first.Votes[0].BlockHash), hex.EncodeToString(first.Votes[1].BlockHash))
+	assertQuery(t, query, response)
+
+	query = `
+		{
+		  evidence(range: [5, 10]) {
+			round
+			step
+		  }
+		}
+		`
+	response = `
+		{
+		  "data": {
+			"evidence": [
+			  {
+				"round": 7,
+				"step": 3
+			  }
+			]
+		  }
+		}
+		`
+	assertQuery(t, query, response)
+}
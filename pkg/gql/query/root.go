@@ -16,7 +16,8 @@ type Root struct {
 	Query *graphql.Object
 }
 
-// NewRoot returns a Root with blocks, transactions and mempool setup.
+// NewRoot returns a Root with blocks, transactions, mempool, evidence,
+// committees and balance setup.
 func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 	m := mempool{rpcBus: rpcBus}
 
@@ -28,6 +29,10 @@ func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 					"blocks":       blocks{}.getQuery(),
 					"transactions": transactions{}.getQuery(),
 					"mempool":      m.getQuery(),
+					"evidence":     evidence{}.getQuery(),
+					"committee":    committee{}.getQuery(),
+					"certificate":  certificate{}.getQuery(),
+					"balance":      balance{rpcBus: rpcBus}.getQuery(),
 				},
 			},
 		),
@@ -9,6 +9,7 @@ package query
 import (
 	"bytes"
 	"encoding/hex"
+	"math"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/graphql-go/graphql"
@@ -21,9 +22,11 @@ import (
 const (
 	txsFetchLimit = 10000
 
-	txidArg   = "txid"
-	txidsArg  = "txids"
-	txlastArg = "last"
+	txidArg    = "txid"
+	txidsArg   = "txids"
+	txlastArg  = "last"
+	txtypeArg  = "txtype"
+	txrangeArg = "range"
 )
 
 type (
@@ -117,6 +120,12 @@ func (t transactions) getQuery() *graphql.Field {
 			txlastArg: &graphql.ArgumentConfig{
 				Type: graphql.Int,
 			},
+			txtypeArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+			txrangeArg: &graphql.ArgumentConfig{
+				Type: graphql.NewList(graphql.Int),
+			},
 		},
 		Resolve: t.resolve,
 	}
@@ -141,6 +150,38 @@ func (t transactions) resolve(p graphql.ResolveParams) (interface{}, error) {
 		return t.fetchTxsByHash(db, ids)
 	}
 
+	txType, ok := p.Args[txtypeArg].(int)
+	if ok {
+		count, countOK := p.Args[txlastArg].(int)
+		if !countOK {
+			count = txsFetchLimit - 1
+		}
+
+		if count <= 0 {
+			return nil, errors.New("invalid count")
+		}
+
+		from, to := uint64(0), uint64(math.MaxUint64)
+
+		heightRange, found := p.Args[txrangeArg].([]interface{})
+		if found {
+			if len(heightRange) != 2 {
+				return nil, errors.New("invalid range")
+			}
+
+			fromHeight, fromOK := heightRange[0].(int)
+			toHeight, toOK := heightRange[1].(int)
+
+			if !fromOK || !toOK || fromHeight < 0 || toHeight < fromHeight {
+				return nil, errors.New("invalid range")
+			}
+
+			from, to = uint64(fromHeight), uint64(toHeight)
+		}
+
+		return t.fetchTxsByType(db, core.TxType(txType), from, to, count)
+	}
+
 	count, ok := p.Args[txlastArg].(int)
 	if ok {
 		if count <= 0 {
@@ -188,6 +229,52 @@ func (t transactions) fetchTxsByHash(db database.DB, txids []interface{}) ([]que
 	return txs, err
 }
 
+// Fetch up to `count` number of txs of a type, in the [from, to] height
+// range, by means of the tx type index.
+func (t transactions) fetchTxsByType(db database.DB, txType core.TxType, from, to uint64, count int) ([]queryTx, error) {
+	txs := make([]queryTx, 0)
+
+	if count >= txsFetchLimit {
+		msg := "requested txs count exceeds the limit"
+		log.WithField("txsFetchLimit", txsFetchLimit).
+			Warn(msg)
+		return txs, errors.New(msg)
+	}
+
+	err := db.View(func(t database.Transaction) error {
+		txIDs, err := t.FetchTxIDsByType(txType, from, to, count)
+		if err != nil {
+			return err
+		}
+
+		for _, txID := range txIDs {
+			tx, _, hash, err := t.FetchBlockTxByHash(txID)
+			if err == database.ErrTxNotFound {
+				// tx of a pruned block
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			header, err := t.FetchBlockHeader(hash)
+			if err != nil {
+				return err
+			}
+
+			d, err := newQueryTx(tx, header.Hash, header.Timestamp)
+			if err == nil {
+				txs = append(txs, d)
+			}
+		}
+
+		return nil
+	})
+
+	return txs, err
+}
+
 // Fetch `count` number of txs from lastly accepted blocks.
 func (t transactions) fetchLastTxs(db database.DB, count int) ([]queryTx, error) {
 	txs := make([]queryTx, 0)
@@ -103,6 +103,35 @@ func TestLastTxs(t *testing.T) {
 	assertQuery(t, query, response)
 }
 
+func TestTxsByType(t *testing.T) {
+	query := `
+		{
+			transactions(txtype: 3, range: [0, 1])
+			{
+				txid
+				blockhash
+			}
+		}
+		`
+	response := fmt.Sprintf(`
+		{
+			"data": {
+				"transactions": [
+					{
+						"blockhash": "%s",
+						"txid": "%s"
+					},
+					{
+						"blockhash": "%s",
+						"txid": "%s"
+					}
+				]
+			}
+		}
+	`, block2, bid2Hash, block1, bid1Hash)
+	assertQuery(t, query, response)
+}
+
 func TestTxOutput(t *testing.T) {
 	query := `
 		{      
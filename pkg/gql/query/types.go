@@ -8,6 +8,7 @@ package query
 
 import (
 	"encoding/hex"
+	"strconv"
 	"time"
 
 	"github.com/graphql-go/graphql"
@@ -111,6 +112,154 @@ var Transaction = graphql.NewObject(
 	},
 )
 
+// Evidence is the graphql object representing the evidence of an equivocation.
+var Evidence = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Evidence",
+		Fields: graphql.Fields{
+			"hash": &graphql.Field{
+				Type: Hex,
+			},
+			"round": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"pubkey": &graphql.Field{
+				Type: Hex,
+			},
+			"votes": &graphql.Field{
+				Type: graphql.NewList(Vote),
+			},
+			"reporter": &graphql.Field{
+				Type: Hex,
+			},
+			"signature": &graphql.Field{
+				Type: Hex,
+			},
+		},
+	},
+)
+
+// Vote is the graphql object representing a signed vote.
+var Vote = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Vote",
+		Fields: graphql.Fields{
+			"hash": &graphql.Field{
+				Type: Hex,
+			},
+			"signature": &graphql.Field{
+				Type: Hex,
+			},
+		},
+	},
+)
+
+// CommitteeMember is the graphql object representing a member of a
+// committee, with its voting weight.
+var CommitteeMember = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "CommitteeMember",
+		Fields: graphql.Fields{
+			"pubkey": &graphql.Field{
+				Type: Hex,
+			},
+			"votes": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"stake": &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	},
+)
+
+// Committee is the graphql object representing the committee of a consensus
+// step.
+var Committee = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Committee",
+		Fields: graphql.Fields{
+			"round": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"phase": &graphql.Field{
+				Type: graphql.String,
+			},
+			"size": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"quorum": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"members": &graphql.Field{
+				Type: graphql.NewList(CommitteeMember),
+			},
+		},
+	},
+)
+
+// StepCheck is the graphql object representing the comparison of a
+// certificate bitset with the committee of the step.
+var StepCheck = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "StepCheck",
+		Fields: graphql.Fields{
+			"committee": &graphql.Field{
+				Type: Committee,
+			},
+			"bitset": &graphql.Field{
+				Type: Bitset,
+			},
+			"signers": &graphql.Field{
+				Type: graphql.NewList(CommitteeMember),
+			},
+			"votes": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"overflow": &graphql.Field{
+				Type: Bitset,
+			},
+		},
+	},
+)
+
+// CertificateCheck is the graphql object representing the comparison of a
+// block certificate with the committees of its reduction steps.
+var CertificateCheck = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "CertificateCheck",
+		Fields: graphql.Fields{
+			"height": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"hash": &graphql.Field{
+				Type: Hex,
+			},
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"step_one": &graphql.Field{
+				Type: StepCheck,
+			},
+			"step_two": &graphql.Field{
+				Type: StepCheck,
+			},
+			"valid": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			"error": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	},
+)
+
 // Output is the graphql object representing output.
 var Output = graphql.NewObject(
 	graphql.ObjectConfig{
@@ -190,3 +339,64 @@ var UnixTimestamp = graphql.NewScalar(graphql.ScalarConfig{
 		return nil
 	},
 })
+
+// Bitset scalar type represents a committee bitset, as a binary string. The
+// least significant bit stands for the first member of the committee.
+var Bitset = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Bitset",
+	Description: "Bitset scalar type represents a committee bitset",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case uint64:
+			return strconv.FormatUint(value, 2)
+		default:
+			return nil
+		}
+	},
+	ParseValue: func(value interface{}) interface{} {
+		// not implemented
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		// not implemented
+		return nil
+	},
+})
+
+// Amount scalar type represents an amount of DUSK, in atomic units, as a
+// decimal string. Amounts overflow the GraphQL Int.
+var Amount = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Amount",
+	Description: "Amount scalar type represents an amount of DUSK in atomic units",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case uint64:
+			return strconv.FormatUint(value, 10)
+		default:
+			return nil
+		}
+	},
+	ParseValue: func(value interface{}) interface{} {
+		// not implemented
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		// not implemented
+		return nil
+	},
+})
+
+// Balance type definition.
+var Balance = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Balance",
+		Fields: graphql.Fields{
+			"confirmed": &graphql.Field{
+				Type: Amount,
+			},
+			"unconfirmed": &graphql.Field{
+				Type: Amount,
+			},
+		},
+	},
+)
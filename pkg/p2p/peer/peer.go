@@ -88,13 +88,29 @@ func (g *GossipConnector) Write(b, header []byte, priority byte) (int, error) {
 	return n, err
 }
 
+// gossipPointConnector is a GossipConnector which writes only the
+// point-to-point messages addressed to its remote peer. The destination
+// address is read from the message header.
+type gossipPointConnector struct {
+	*GossipConnector
+}
+
+func (g *gossipPointConnector) Write(b, header []byte, priority byte) (int, error) {
+	if string(header) != g.Addr() {
+		return 0, nil
+	}
+
+	return g.GossipConnector.Write(b, header, priority)
+}
+
 // Writer abstracts all of the logic and fields needed to write messages to
 // other network nodes.
 type Writer struct {
 	*Connection
-	subscriber eventbus.Subscriber
-	gossipID   uint32
-	keepAlive  time.Duration
+	subscriber    eventbus.Subscriber
+	gossipID      uint32
+	gossipPointID uint32
+	keepAlive     time.Duration
 }
 
 // Reader abstracts all of the logic and fields needed to receive messages from
@@ -228,6 +244,8 @@ func Create(ctx context.Context, reader *Reader, writer *Writer) {
 	g := &GossipConnector{writer.Connection}
 	listener := eventbus.NewStreamListener(g)
 	writer.gossipID = writer.subscriber.Subscribe(topics.Gossip, listener)
+	pointListener := eventbus.NewStreamListener(&gossipPointConnector{g})
+	writer.gossipPointID = writer.subscriber.Subscribe(topics.GossipPoint, pointListener)
 	ringBuf := ring.NewBuffer(1000)
 
 	// On each new connection the node sends topics.Mempool to retrieve mempool
@@ -254,6 +272,7 @@ func (w *Writer) onDisconnect() {
 	_ = w.Conn.Close()
 
 	w.subscriber.Unsubscribe(topics.Gossip, w.gossipID)
+	w.subscriber.Unsubscribe(topics.GossipPoint, w.gossipPointID)
 
 	if config.Get().API.Enabled {
 		go func() {
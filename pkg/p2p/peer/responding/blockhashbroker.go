@@ -11,6 +11,7 @@ import (
 	"errors"
 
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -42,6 +43,18 @@ func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Mes
 		return nil, err
 	}
 
+	// A pruned node does not advertise the blocks it can not provide. As the
+	// inventory must follow the locator, nothing is advertised at all if
+	// the requesting peer is behind the pruned height.
+	prunedHeight, err := b.fetchPrunedHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	if height < prunedHeight {
+		return nil, nil
+	}
+
 	// Fill an inv message with all block hashes between the locator
 	// and the chain tip.
 	inv := &message.Inv{}
@@ -79,6 +92,54 @@ func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Mes
 	return nil, nil
 }
 
+// ProvideHeaders takes a GetHeaders wire message, finds the requesting peer's
+// height, and returns a headers message of up to config.MaxInvBlocks headers which
+// follow the provided locator. It serves the headers-first sync mode.
+func (b *BlockHashBroker) ProvideHeaders(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
+	msg := m.Payload().(message.GetBlocks)
+
+	height, err := b.fetchLocatorHeight(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := &message.Headers{}
+
+	for {
+		height++
+
+		var header *block.Header
+
+		err = b.db.View(func(t database.Transaction) error {
+			hash, err := t.FetchBlockHashByHeight(height)
+			if err != nil {
+				return err
+			}
+
+			header, err = t.FetchBlockHeader(hash)
+			return err
+		})
+
+		// We passed the tip of the chain
+		if err != nil {
+			break
+		}
+
+		headers.Headers = append(headers.Headers, header)
+
+		if len(headers.Headers) >= cfg.MaxInvBlocks {
+			break
+		}
+	}
+
+	if headers.Headers != nil {
+		buf, err := marshalHeaders(headers)
+		return []bytes.Buffer{buf}, err
+	}
+
+	return nil, nil
+}
+
 // Determine a peer's height from his locator hash.
 func (b *BlockHashBroker) fetchLocatorHeight(msg message.GetBlocks) (uint64, error) {
 	if len(msg.Locators) == 0 {
@@ -100,6 +161,18 @@ func (b *BlockHashBroker) fetchLocatorHeight(msg message.GetBlocks) (uint64, err
 	return height, err
 }
 
+func (b *BlockHashBroker) fetchPrunedHeight() (uint64, error) {
+	var height uint64
+
+	err := b.db.View(func(t database.Transaction) error {
+		var err error
+		height, err = t.FetchPrunedHeight()
+		return err
+	})
+
+	return height, err
+}
+
 func marshalInv(inv *message.Inv) (bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	if err := inv.Encode(buf); err != nil {
@@ -109,3 +182,13 @@ func marshalInv(inv *message.Inv) (bytes.Buffer, error) {
 	_ = topics.Prepend(buf, topics.Inv)
 	return *buf, nil
 }
+
+func marshalHeaders(headers *message.Headers) (bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	if err := headers.Encode(buf); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	_ = topics.Prepend(buf, topics.Headers)
+	return *buf, nil
+}
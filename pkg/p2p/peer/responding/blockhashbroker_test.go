@@ -7,14 +7,18 @@
 package responding_test
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	assert "github.com/stretchr/testify/require"
 )
@@ -54,6 +58,94 @@ func TestAdvertiseBlocks(t *testing.T) {
 	}
 }
 
+// Test the behavior of the block hash broker, upon receiving a GetHeaders message.
+func TestProvideHeaders(t *testing.T) {
+	assert := assert.New(t)
+	_, db := lite.CreateDBConnection()
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	hashes, blocks := generateBlocks(5)
+	assert.NoError(storeBlocks(db, blocks))
+
+	blockHashBroker := responding.NewBlockHashBroker(db)
+
+	// Make a GetHeaders, with the genesis block as the locator.
+	getHeaders := &message.GetBlocks{Locators: [][]byte{hashes[0]}}
+	msg := message.New(topics.GetHeaders, *getHeaders)
+
+	bufs, err := blockHashBroker.ProvideHeaders("", msg)
+	assert.NoError(err)
+
+	topic, _ := topics.Extract(&bufs[0])
+	assert.Equal(topics.Headers, topic)
+
+	headers := &message.Headers{}
+	assert.NoError(headers.Decode(&bufs[0]))
+
+	// All headers but the locator one should be provided, in order
+	assert.Len(headers.Headers, 4)
+
+	for i, header := range headers.Headers {
+		assert.True(header.Equals(blocks[i+1].Header))
+	}
+}
+
+// Test that a pruned node does not promise the blocks it no longer has.
+func TestPrunedNode(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "responding_pruned_")
+	assert.NoError(err)
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	drvr, err := database.From(heavy.DriverName)
+	assert.NoError(err)
+
+	db, err := drvr.Open(dir, protocol.DevNet, false)
+	assert.NoError(err)
+
+	defer func() {
+		_ = drvr.Close()
+	}()
+
+	hashes, blocks := generateBlocks(10)
+	assert.NoError(storeBlocks(db, blocks))
+
+	// Keep the bodies of the last 3 blocks only
+	pruner, err := heavy.NewPruner(db, 3, 0)
+	assert.NoError(err)
+
+	_, err = pruner.Prune()
+	assert.NoError(err)
+
+	// A peer behind the pruned height gets no inventory
+	bufs, err := responding.NewBlockHashBroker(db).AdvertiseMissingBlocks("", createGetBlocks(hashes[2]))
+	assert.NoError(err)
+	assert.Empty(bufs)
+
+	// A peer above the pruned height gets the blocks following its locator
+	bufs, err = responding.NewBlockHashBroker(db).AdvertiseMissingBlocks("", createGetBlocks(hashes[6]))
+	assert.NoError(err)
+	assert.Len(bufs, 1)
+
+	// Pruned blocks are skipped on GetData
+	bufs, err = responding.NewDataBroker(db, nil).MarshalObjects("", createGetData(hashes[5], hashes[9]))
+	assert.NoError(err)
+	assert.Len(bufs, 1)
+
+	_, _ = topics.Extract(&bufs[0])
+
+	blk := block.NewBlock()
+	assert.NoError(message.UnmarshalBlock(&bufs[0], blk))
+	assert.Equal(hashes[9], blk.Header.Hash)
+}
+
 // Generate a set of random blocks, which follow each other up in the chain.
 func generateBlocks(amount int) ([][]byte, []*block.Block) {
 	var hashes [][]byte
@@ -43,7 +43,8 @@ func (d *DataBroker) MarshalObjects(srcPeerID string, m message.Message) ([]byte
 	for _, obj := range msg.InvList {
 		switch obj.Type {
 		case message.InvTypeBlock:
-			// Fetch block from local state. It must be available
+			// Fetch block from local state. It must be available, unless
+			// it has already been pruned
 			var b *block.Block
 
 			err := d.db.View(func(t database.Transaction) error {
@@ -51,6 +52,11 @@ func (d *DataBroker) MarshalObjects(srcPeerID string, m message.Message) ([]byte
 				b, err = t.FetchBlock(obj.Hash)
 				return err
 			})
+
+			if err == database.ErrBlockPruned {
+				continue
+			}
+
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	headers := &message.Headers{}
+
+	for i := uint64(0); i < 5; i++ {
+		blk := helper.RandomBlock(200+i, 1)
+		headers.Headers = append(headers.Headers, blk.Header)
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(headers.Encode(buf))
+
+	decoded := &message.Headers{}
+	assert.NoError(decoded.Decode(buf))
+
+	assert.Equal(len(headers.Headers), len(decoded.Headers))
+
+	for i := range headers.Headers {
+		assert.True(headers.Headers[i].Equals(decoded.Headers[i]))
+	}
+}
+
+func TestDecodeTooManyHeaders(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, encoding.WriteVarInt(buf, 501))
+	assert.Error(t, (&message.Headers{}).Decode(buf))
+}
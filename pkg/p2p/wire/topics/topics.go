@@ -92,6 +92,16 @@ const (
 
 	// Kadcast wire point-to-point messaging.
 	KadcastPoint
+
+	// Headers-first sync wire messaging.
+	GetHeaders
+	Headers
+
+	// Gossip wire point-to-point messaging.
+	GossipPoint
+
+	// Mempool events.
+	EvictedTx
 )
 
 type topicBuf struct {
@@ -153,6 +163,11 @@ var Topics = [...]topicBuf{
 	{GetCandidate, *(bytes.NewBuffer([]byte{byte(GetCandidate)})), "getcandidate"},
 	{SyncProgress, *(bytes.NewBuffer([]byte{byte(SyncProgress)})), "syncprogress"},
 	{Kadcast, *(bytes.NewBuffer([]byte{byte(Kadcast)})), "kadcast"},
+	{KadcastPoint, *(bytes.NewBuffer([]byte{byte(KadcastPoint)})), "kadcastpoint"},
+	{GetHeaders, *(bytes.NewBuffer([]byte{byte(GetHeaders)})), "getheaders"},
+	{Headers, *(bytes.NewBuffer([]byte{byte(Headers)})), "headers"},
+	{GossipPoint, *(bytes.NewBuffer([]byte{byte(GossipPoint)})), "gossippoint"},
+	{EvictedTx, *(bytes.NewBuffer([]byte{byte(EvictedTx)})), "evictedtx"},
 }
 
 func checkConsistency(topics []topicBuf) {
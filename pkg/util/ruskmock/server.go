@@ -10,10 +10,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
@@ -47,6 +49,11 @@ type Server struct {
 	p      *user.Provisioners
 	height uint64
 
+	// spent holds the nullifiers of the transactions accepted since the
+	// server started.
+	lock  sync.RWMutex
+	spent map[string]struct{}
+
 	db *BuntStore
 }
 
@@ -65,9 +72,10 @@ func New(cfg *Config, c config.Registry) (*Server, error) {
 	}
 
 	srv := &Server{
-		cfg: cfg,
-		p:   user.NewProvisioners(),
-		db:  db,
+		cfg:   cfg,
+		p:     user.NewProvisioners(),
+		spent: make(map[string]struct{}),
+		db:    db,
 	}
 
 	grpcServer := grpc.NewServer()
@@ -149,21 +157,65 @@ func (s *Server) Serve(network, url string) error {
 	return nil
 }
 
-// VerifyStateTransition simulates a state transition validation.
+// VerifyStateTransition simulates a state transition validation. The
+// transactions are rejected if they can not be decoded, or if they spend a
+// nullifier twice, or one spent by an accepted transaction.
 func (s *Server) VerifyStateTransition(ctx context.Context, req *rusk.VerifyStateTransitionRequest) (*rusk.VerifyStateTransitionResponse, error) {
 	log.WithField("block_gas_limit", req.BlockGasLimit).
 		WithField("block_height", req.BlockHeight).
+		WithField("txs", len(req.Txs)).
 		Infoln("call received to VerifyStateTransition")
 
 	defer log.Infoln("finished call to VerifyStateTransition")
 
 	time.Sleep(stateTransitionDelay)
 
+	if !s.cfg.PassStateTransitionValidation {
+		return &rusk.VerifyStateTransitionResponse{Success: false}, nil
+	}
+
+	if err := s.verifyTransactions(req.Txs); err != nil {
+		log.WithError(err).Infoln("state transition rejected")
+		return &rusk.VerifyStateTransitionResponse{Success: false}, nil
+	}
+
 	return &rusk.VerifyStateTransitionResponse{
 		Success: true,
 	}, nil
 }
 
+func (s *Server) verifyTransactions(txs []*rusk.Transaction) error {
+	if len(txs) > 0 && !s.cfg.PassTransactionValidation {
+		return errors.New("transaction validation disabled")
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	nullifiers := make(map[string]struct{})
+
+	for _, tx := range txs {
+		payload := transactions.NewTransactionPayload()
+		if err := transactions.UnmarshalTransactionPayload(bytes.NewBuffer(tx.Payload), payload); err != nil {
+			return err
+		}
+
+		for _, n := range payload.Nullifiers {
+			if _, ok := s.spent[string(n)]; ok {
+				return fmt.Errorf("nullifier %s already spent", util.StringifyBytes(n))
+			}
+
+			if _, ok := nullifiers[string(n)]; ok {
+				return fmt.Errorf("nullifier %s spent twice", util.StringifyBytes(n))
+			}
+
+			nullifiers[string(n)] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
 // ExecuteStateTransition mocks a dry-run state transition.
 func (s *Server) ExecuteStateTransition(ctx context.Context, req *rusk.ExecuteStateTransitionRequest) (*rusk.ExecuteStateTransitionResponse, error) {
 	log.WithField("block_gas_limit", req.BlockGasLimit).
@@ -194,6 +246,11 @@ func (s *Server) Accept(ctx context.Context, req *rusk.ExecuteStateTransitionReq
 		return nil, err
 	}
 
+	if err := s.spend(req.Txs); err != nil {
+		log.WithError(err).Errorln("could not spend nullifiers")
+		return nil, err
+	}
+
 	return &rusk.ExecuteStateTransitionResponse{
 		Success:   s.cfg.PassStateTransition,
 		StateRoot: make([]byte, 32),
@@ -214,6 +271,11 @@ func (s *Server) Finalize(ctx context.Context, req *rusk.ExecuteStateTransitionR
 		return nil, err
 	}
 
+	if err := s.spend(req.Txs); err != nil {
+		log.WithError(err).Errorln("could not spend nullifiers")
+		return nil, err
+	}
+
 	return &rusk.ExecuteStateTransitionResponse{
 		Success:   true,
 		StateRoot: make([]byte, 32),
@@ -263,12 +325,18 @@ func (s *Server) GetNotesOwnedBy(ctx context.Context, req *rusk.GetNotesOwnedByR
 	return &rusk.GetNotesOwnedByResponse{}, nil
 }
 
-// GetAnchor impl.
+// GetAnchor returns an anchor derived from the height of the state, so that
+// it changes with every block.
 func (s *Server) GetAnchor(ctx context.Context, req *rusk.GetAnchorRequest) (*rusk.GetAnchorResponse, error) {
 	log.Infoln("call received to GetAnchor")
 	defer log.Infoln("finished call to GetAnchor")
 
-	return &rusk.GetAnchorResponse{}, nil
+	anchor := make([]byte, 32)
+	binary.LittleEndian.PutUint64(anchor, s.height)
+
+	return &rusk.GetAnchorResponse{
+		Anchor: anchor,
+	}, nil
 }
 
 // GetOpening impl.
@@ -349,6 +417,24 @@ func (s *Server) addConsensusNodes(txs []*rusk.Transaction, startHeight uint64)
 	return nil
 }
 
+func (s *Server) spend(txs []*rusk.Transaction) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, tx := range txs {
+		payload := transactions.NewTransactionPayload()
+		if err := transactions.UnmarshalTransactionPayload(bytes.NewBuffer(tx.Payload), payload); err != nil {
+			return err
+		}
+
+		for _, n := range payload.Nullifiers {
+			s.spent[string(n)] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
 // GenerateKeys returns the server's wallet private key, and a stealth address.
 // The response will contain Ristretto points under the hood.
 func (s *Server) GenerateKeys(ctx context.Context, req *rusk.GenerateKeysRequest) (*rusk.GenerateKeysResponse, error) {
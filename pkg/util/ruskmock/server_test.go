@@ -10,7 +10,10 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
@@ -72,6 +75,67 @@ func TestVerifyStateTransition(t *testing.T) {
 	assert.True(t, resp.Success)
 }
 
+func TestVerifyTransaction(t *testing.T) {
+	s := setupRuskMockTest(t, DefaultConfig())
+	defer cleanup(s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, _ := client.CreateStateClient(ctx, "localhost:10000")
+	prober := transactions.NewProxy(c, nil, nil, nil, 10*time.Second, 10*time.Second, config.BlockGasLimit).Prober()
+
+	txs := []*transactions.Transaction{transactions.RandTx(), transactions.RandTx(), transactions.RandTx()}
+
+	// Transactions submitted together are verified in a single batch
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(txs))
+
+	for i := range txs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			errs[i] = prober.VerifyTransaction(ctx, txs[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	// The results are cached
+	start := time.Now()
+
+	assert.NoError(t, prober.VerifyTransaction(ctx, txs[0]))
+	assert.Less(t, int64(time.Since(start)), int64(stateTransitionDelay))
+
+	// Once a block spends the first transaction, it is rejected
+	call := new(rusk.Transaction)
+	assert.NoError(t, transactions.MTransaction(call, txs[0]))
+
+	_, err := c.Accept(ctx, &rusk.ExecuteStateTransitionRequest{
+		Txs:         []*rusk.Transaction{call},
+		BlockHeight: 1,
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, prober.Refresh(ctx, 1))
+	assert.Equal(t, transactions.ErrVerificationFailed, prober.VerifyTransaction(ctx, txs[0]))
+	assert.NoError(t, prober.VerifyTransaction(ctx, txs[1]))
+	assert.NoError(t, prober.VerifyTransaction(ctx, txs[2]))
+
+	// So is any transaction spending the same nullifiers
+	double := transactions.RandTx()
+	double.Payload.Nullifiers = txs[0].Payload.Nullifiers
+
+	assert.Equal(t, transactions.ErrVerificationFailed, prober.VerifyTransaction(ctx, double))
+}
+
 func TestFailedVerifyStateTransition(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.PassStateTransitionValidation = false
@@ -186,8 +250,9 @@ func TestNewStake(t *testing.T) {
 
 func setupRuskMockTest(t *testing.T, cfg *Config) *Server {
 	c := config.Registry{}
-	// Hardcode wallet values, so that it always starts up correctly
-	c.Wallet.Store = walletDBName
+	// Hardcode wallet values, so that it always starts up correctly. The
+	// mock database is created two levels above the wallet store.
+	c.Wallet.Store = filepath.Join(t.TempDir(), walletDBName, walletDBName)
 	c.Wallet.File = "../../../devnet-wallets/wallet0.dat"
 
 	s, err := New(cfg, c)
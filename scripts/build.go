@@ -59,8 +59,9 @@ func main() {
 //nolint:gosec
 func install(cmdline []string) {
 	var (
-		race  = flag.Bool("race", false, "build Dusk exec with Race enabled")
-		debug = flag.Bool("debug", false, "build Dusk exec with Debug enabled")
+		race      = flag.Bool("race", false, "build Dusk exec with Race enabled")
+		debug     = flag.Bool("debug", false, "build Dusk exec with Debug enabled")
+		byzantine = flag.Bool("byzantine", false, "build Dusk exec with the byzantine behaviours, for the test harness")
 	)
 
 	_ = flag.CommandLine.Parse(cmdline)
@@ -91,6 +92,10 @@ func install(cmdline []string) {
 		argsInstall = append(argsInstall, "-gcflags=\"all=-N -l\"")
 	}
 
+	if *byzantine {
+		argsInstall = append(argsInstall, "-tags=byzantine")
+	}
+
 	cmd = exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), argsInstall...)
 	cmd.Args = append(cmd.Args, "-v")
 	cmd.Args = append(cmd.Args, packages...)